@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+const approveShortDesc = `Review a pending approval of deploying an artifact.  `
+
+const approveLongDesc = approveShortDesc + `
+The approval is one requested with 'kosli request approval'. The artifact and the git commits of
+the approval are printed, and you are prompted to approve or reject them and for a comment.
+Use ^--approve^ or ^--reject^, with ^--comment^, to review the approval without being prompted.
+
+The approver is taken from ^--approver^, or from the user who triggered the CI job. With ^--approver-token^,
+the identity token is sent to Kosli, which verifies it and takes the approver from it. The CLI never takes
+the approver from the token itself. The API token must belong to a user allowed to approve deployments of the flow.
+
+EXPRESSION can be specified as follows:
+- flowName
+    - the latest approval to flowName, at the time of the request
+    - e.g., **creator**
+- flowName#N
+    - the Nth approval, counting from 1
+    - e.g., **creator#453**
+- flowName~N
+    - the Nth approval behind the latest, at the time of the request
+    - e.g., **creator~56**
+
+The git commits are described with their messages and authors if they are in the git repository
+at ^--repo-root^.`
+
+const approveExample = `
+# review the latest approval of a flow interactively
+kosli approve yourFlowName \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# reject the 10th approval of a flow with a comment
+kosli approve yourFlowName#10 \
+	--reject \
+	--comment "The release notes are missing" \
+	--approver alice@example.com \
+	--api-token yourAPIToken \
+	--org yourOrgName
+`
+
+type approveOptions struct {
+	srcRepoRoot string
+	approve     bool
+	reject      bool
+	comment     string
+	approver    approverOptions
+}
+
+// PendingApproval is the part of an approval that is shown to its reviewer
+type PendingApproval struct {
+	Number              int    `json:"release_number"`
+	ArtifactFingerprint string `json:"base_artifact"`
+	ArtifactName        string `json:"artifact_name"`
+	Description         string `json:"description"`
+	State               string `json:"state"`
+	Commits             []struct {
+		Sha1 string `json:"commit_sha"`
+	} `json:"src_commit_list"`
+}
+
+func newApproveCmd(out io.Writer) *cobra.Command {
+	ci := WhichCI()
+	o := new(approveOptions)
+	cmd := &cobra.Command{
+		Use:     "approve EXPRESSION",
+		Short:   approveShortDesc,
+		Long:    approveLongDesc,
+		Example: approveExample,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			err = MuXRequiredFlags(cmd, []string{"approve", "reject"}, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.InOrStdin(), out, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.approve, "approve", false, approveFlag)
+	cmd.Flags().BoolVar(&o.reject, "reject", false, rejectFlag)
+	cmd.Flags().StringVar(&o.comment, "comment", "", approveCommentFlag)
+	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	addApproverFlags(cmd, &o.approver, ci)
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+func (o *approveOptions) run(in io.Reader, out io.Writer, args []string) error {
+	flowName, id, err := handleExpressions(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !o.approver.identified() {
+		return fmt.Errorf("the approver could not be identified. Use --approver or --approver-token")
+	}
+
+	approval, err := getPendingApproval(flowName, id)
+	if err != nil {
+		return err
+	}
+	if approval.State != "PENDING" {
+		return fmt.Errorf("approval %s#%d is not pending, its state is %s", flowName, approval.Number, approval.State)
+	}
+	o.printPendingApproval(out, flowName, approval)
+
+	state, comment, err := o.decision(bufio.NewReader(in), out)
+	if err != nil {
+		return err
+	}
+	if state == "" {
+		logger.Info("approval %s#%d was not reviewed", flowName, approval.Number)
+		return nil
+	}
+
+	review := o.approver.review(state, comment)
+	url := fmt.Sprintf("%s/api/v2/approvals/%s/%s/%d/reviews", global.Host, global.Org, flowName, approval.Number)
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Payload:  review,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("approval %s#%d %s", flowName, approval.Number, strings.ToLower(state))
+	}
+	return err
+}
+
+// getPendingApproval gets the approval with the given number from a flow,
+// the latest one if the number is negative
+func getPendingApproval(flowName string, id int) (*PendingApproval, error) {
+	url := fmt.Sprintf("%s/api/v2/approvals/%s/%s/%d", global.Host, global.Org, flowName, id)
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodGet,
+		URL:      url,
+		Password: global.ApiToken,
+	}
+	response, err := kosliClient.Do(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	approval := &PendingApproval{}
+	err = json.Unmarshal([]byte(response.Body), approval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the approval: %v", err)
+	}
+	return approval, nil
+}
+
+// printPendingApproval prints the artifact of the approval and its changelog.
+// The commits are described with their subjects and authors when they are in the local git repository.
+func (o *approveOptions) printPendingApproval(out io.Writer, flowName string, approval *PendingApproval) {
+	rows := []string{}
+	rows = append(rows, fmt.Sprintf("Approval:\t%s#%d", flowName, approval.Number))
+	rows = append(rows, fmt.Sprintf("Artifact name:\t%s", approval.ArtifactName))
+	rows = append(rows, fmt.Sprintf("Artifact fingerprint:\t%s", approval.ArtifactFingerprint))
+	if approval.Description != "" {
+		rows = append(rows, fmt.Sprintf("Description:\t%s", approval.Description))
+	}
+	if len(approval.Commits) == 0 {
+		rows = append(rows, "Changes:\tNone")
+	} else {
+		rows = append(rows, "Changes:")
+		gitView, err := gitview.New(o.srcRepoRoot)
+		if err != nil {
+			logger.Debug("the git commits of the approval are not described: %v", err)
+		}
+		for _, commit := range approval.Commits {
+			rows = append(rows, fmt.Sprintf("\t%s", describeCommit(gitView, commit.Sha1)))
+		}
+	}
+	tabFormattedPrint(out, []string{}, rows)
+}
+
+// describeCommit returns the short SHA of a git commit, followed by its subject and author
+// if the commit is in the git repository
+func describeCommit(gitView *gitview.GitView, sha1 string) string {
+	if gitView == nil {
+		return sha1
+	}
+	commit, err := gitView.GetCommitInfoFromCommitSHA(sha1)
+	if err != nil {
+		return sha1
+	}
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+	return fmt.Sprintf("%s %s (%s)", shortSHA(sha1), subject, commit.Author)
+}
+
+// decision returns the state of the review, APPROVED or REJECTED, and its comment.
+// They are read from the input unless --approve or --reject is set.
+// The state is empty if the review is cancelled.
+func (o *approveOptions) decision(in *bufio.Reader, out io.Writer) (string, string, error) {
+	if o.approve {
+		return "APPROVED", o.comment, nil
+	}
+	if o.reject {
+		return "REJECTED", o.comment, nil
+	}
+
+	fmt.Fprint(out, "Approve or reject these changes? [a]pprove, [r]eject, [c]ancel: ")
+	answer, err := readAnswer(in)
+	if err != nil {
+		return "", "", err
+	}
+	state := ""
+	switch strings.ToLower(answer) {
+	case "a", "approve":
+		state = "APPROVED"
+	case "r", "reject":
+		state = "REJECTED"
+	case "", "c", "cancel":
+		return "", "", nil
+	default:
+		return "", "", fmt.Errorf("invalid answer: %s. Expected one of approve, reject or cancel", answer)
+	}
+
+	comment := o.comment
+	if comment == "" {
+		fmt.Fprint(out, "Comment: ")
+		comment, err = readAnswer(in)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return state, comment, nil
+}
+
+// readAnswer reads a line from the input, without its surrounding spaces
+func readAnswer(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read the answer, use --approve or --reject when not running interactively: %v", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// defaultApprover is the approver of approvals whose approver cannot be identified
+const defaultApprover = "External"
+
+type approverOptions struct {
+	approver      string
+	approvalURL   string
+	approverToken string
+}
+
+// addApproverFlags adds the flags that identify the approver of an approval
+func addApproverFlags(cmd *cobra.Command, o *approverOptions, ci string) {
+	cmd.Flags().StringVar(&o.approver, "approver", "", approverFlag)
+	cmd.Flags().StringVar(&o.approverToken, "approver-token", "", approverTokenFlag)
+	cmd.Flags().StringVar(&o.approvalURL, "approval-url", DefaultValue(ci, "build-url"), approvalURLFlag)
+}
+
+// identity returns the approver, from --approver if set, otherwise from the actor environment
+// variables of the CI. It returns an empty string if the approver cannot be identified.
+// The approver is never taken from --approver-token, whose signature only Kosli can verify.
+func (o *approverOptions) identity() string {
+	if o.approver != "" {
+		return o.approver
+	}
+	return DefaultValue(WhichCI(), "approver")
+}
+
+// identified returns true if the approver is named, or can be identified by Kosli from --approver-token
+func (o *approverOptions) identified() bool {
+	return o.identity() != "" || o.approverToken != ""
+}
+
+// review returns an approval review with the given state and comment, by the approver.
+// With --approver-token, the raw token is sent for Kosli to verify it and take the approver from it.
+func (o *approverOptions) review(state, comment string) map[string]string {
+	review := map[string]string{
+		"state":        state,
+		"comment":      comment,
+		"approval_url": o.approvalURL,
+	}
+	if approver := o.identity(); approver != "" {
+		review["approved_by"] = approver
+	} else if o.approverToken == "" {
+		review["approved_by"] = defaultApprover
+	}
+	if o.approverToken != "" {
+		review["approver_token"] = o.approverToken
+	}
+	return review
+}
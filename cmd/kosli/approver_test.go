@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproverIdentity(t *testing.T) {
+	t.Setenv("KOSLI_TESTS", "True")
+	for _, tt := range []struct {
+		name    string
+		options approverOptions
+		want    string
+	}{
+		{
+			name:    "the approver flag is used",
+			options: approverOptions{approver: "bob", approverToken: "header.payload.signature"},
+			want:    "bob",
+		},
+		{
+			name:    "the approver is not taken from the token",
+			options: approverOptions{approverToken: "header.payload.signature"},
+			want:    "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.options.identity())
+		})
+	}
+}
+
+func TestApproverReview(t *testing.T) {
+	t.Setenv("KOSLI_TESTS", "True")
+	for _, tt := range []struct {
+		name    string
+		options approverOptions
+		want    map[string]string
+	}{
+		{
+			name:    "the raw token is sent for Kosli to identify the approver",
+			options: approverOptions{approverToken: "header.payload.signature", approvalURL: "https://example.com/run/1"},
+			want: map[string]string{
+				"state":          "APPROVED",
+				"comment":        "looks good",
+				"approval_url":   "https://example.com/run/1",
+				"approver_token": "header.payload.signature",
+			},
+		},
+		{
+			name:    "the named approver is sent",
+			options: approverOptions{approver: "bob", approvalURL: "https://example.com/run/1"},
+			want: map[string]string{
+				"state":        "APPROVED",
+				"comment":      "looks good",
+				"approved_by":  "bob",
+				"approval_url": "https://example.com/run/1",
+			},
+		},
+		{
+			name:    "an unidentified approver is external",
+			options: approverOptions{},
+			want: map[string]string{
+				"state":        "APPROVED",
+				"comment":      "looks good",
+				"approved_by":  defaultApprover,
+				"approval_url": "",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.options.review("APPROVED", "looks good"))
+		})
+	}
+}
@@ -22,6 +22,8 @@ func newAssertCmd(out io.Writer) *cobra.Command {
 		newAssertArtifactCmd(out),
 		newAssertSnapshotCmd(out),
 		newAssertApprovalCmd(out),
+		newAssertEnvironmentCmd(out),
+		newAssertPromotionCmd(out),
 	)
 
 	return cmd
@@ -14,6 +14,8 @@ const assertApprovalShortDesc = `Assert an artifact in Kosli has been approved f
 
 const assertApprovalLongDesc = assertApprovalShortDesc + `
 Exits with non-zero code if the artifact has not been approved.  
+With ^--wait^, the assertion is checked again while the artifact has no approvals or its latest approval is pending,
+until it is approved, rejected, or ^--timeout^ expires. Exits with code 2 if the timeout expires.  
 ` + fingerprintDesc
 
 const assertApprovalExample = `
@@ -31,12 +33,22 @@ kosli assert approval \
 	--org yourOrgName \
 	--flow yourFlowName \
 	--fingerprint yourArtifactFingerprint
+
+# Wait up to an hour for an artifact to be approved
+kosli assert approval \
+	--api-token yourAPIToken \
+	--org yourOrgName \
+	--flow yourFlowName \
+	--fingerprint yourArtifactFingerprint \
+	--wait \
+	--timeout 1h
 `
 
 type assertApprovalOptions struct {
 	fingerprintOptions *fingerprintOptions
 	fingerprint        string
 	flowName           string
+	waitOptions        waitOptions
 }
 
 func newAssertApprovalCmd(out io.Writer) *cobra.Command {
@@ -57,6 +69,11 @@ func newAssertApprovalCmd(out io.Writer) *cobra.Command {
 			if err != nil {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
 			}
+
+			err = o.waitOptions.validate()
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
 			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
 
 		},
@@ -68,6 +85,7 @@ func newAssertApprovalCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&o.fingerprint, "fingerprint", "F", "", fingerprintFlag)
 	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
 	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addWaitFlags(cmd, &o.waitOptions)
 	addDryRunFlag(cmd)
 
 	err := RequireFlags(cmd, []string{"flow"})
@@ -86,7 +104,12 @@ func (o *assertApprovalOptions) run(args []string) error {
 			return err
 		}
 	}
+	return o.waitOptions.poll(o.assertApproved)
+}
 
+// assertApproved returns nil if the latest approval of the artifact is approved,
+// and a pending error if the artifact has no approvals or its latest approval is pending
+func (o *assertApprovalOptions) assertApproved() error {
 	url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s/%s/approvals", global.Host, global.Org, o.flowName, o.fingerprint)
 
 	reqParams := &requests.RequestParams{
@@ -106,7 +129,7 @@ func (o *assertApprovalOptions) run(args []string) error {
 		return err
 	}
 	if len(approvals) == 0 {
-		return fmt.Errorf("artifact with fingerprint %s has no approvals created", o.fingerprint)
+		return &pendingError{fmt.Errorf("artifact with fingerprint %s has no approvals created", o.fingerprint)}
 	}
 
 	state, ok := approvals[len(approvals)-1]["state"].(string)
@@ -114,6 +137,8 @@ func (o *assertApprovalOptions) run(args []string) error {
 		approvalNumber := approvals[len(approvals)-1]["release_number"]
 		logger.Info("artifact with fingerprint %s is approved (approval no. [%v])", o.fingerprint, approvalNumber)
 		return nil
+	} else if ok && state == "PENDING" {
+		return &pendingError{fmt.Errorf("artifact with fingerprint %s is not approved", o.fingerprint)}
 	} else {
 		return fmt.Errorf("artifact with fingerprint %s is not approved", o.fingerprint)
 	}
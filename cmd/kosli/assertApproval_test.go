@@ -71,6 +71,18 @@ func (suite *AssertApprovalCommandTestSuite) TestAssertApprovalCmd() {
 			cmd:       fmt.Sprintf(`assert approval %s --artifact-type file --flow %s %s`, suite.artifactPath, suite.flowName, suite.defaultKosliArguments),
 			golden:    "Error: artifact with fingerprint fcf33337634c2577a5d86fd7ecb0a25a7c1bb5d89c14fd236f546a5759252c02 has no approvals created\n",
 		},
+		{
+			wantError:   true,
+			name:        "waiting for the approval of an artifact that does not have an approval times out",
+			cmd:         fmt.Sprintf(`assert approval --fingerprint %s --flow %s --wait --timeout 1s --poll-interval 100ms %s`, suite.fingerprint, suite.flowName, suite.defaultKosliArguments),
+			goldenRegex: "Error: timed out after 1s: artifact with fingerprint fcf33337634c2577a5d86fd7ecb0a25a7c1bb5d89c14fd236f546a5759252c02 has no approvals created\n",
+		},
+		{
+			wantError: true,
+			name:      "--wait with a timeout that is not positive fails",
+			cmd:       fmt.Sprintf(`assert approval --fingerprint %s --flow %s --wait --timeout 0s %s`, suite.fingerprint, suite.flowName, suite.defaultKosliArguments),
+			golden:    "Error: --timeout must be positive\nUsage: kosli assert approval [IMAGE-NAME | FILE-PATH | DIR-PATH] [flags]\n",
+		},
 		{
 			name:   "asserting approval of an existing artifact that has an approval (using --artifact-type) works and exits with zero code",
 			cmd:    fmt.Sprintf(`assert approval %s --artifact-type file --flow %s %s`, suite.artifactPath, suite.flowName, suite.defaultKosliArguments),
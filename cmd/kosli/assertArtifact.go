@@ -13,7 +13,10 @@ import (
 const assertArtifactShortDesc = `Assert the compliance status of an artifact in Kosli.  `
 
 const assertArtifactLongDesc = assertArtifactShortDesc + `
-Exits with non-zero code if the artifact has a non-compliant status.`
+Exits with non-zero code if the artifact has a non-compliant status.
+With ^--wait^, the assertion is checked again while the compliance of the artifact is incomplete,
+i.e. neither COMPLIANT nor NON-COMPLIANT, until it is complete or ^--timeout^ expires.
+Exits with code 2 if the timeout expires.`
 
 const assertArtifactExample = `
 # fail if an artifact has a non-compliant status (using the artifact fingerprint)
@@ -29,12 +32,22 @@ kosli assert artifact library/nginx:1.21 \
 	--flow yourFlowName \
 	--api-token yourAPIToken \
 	--org yourOrgName 
+
+# wait up to 15 minutes for the compliance of an artifact to be complete
+kosli assert artifact \
+	--fingerprint 184c799cd551dd1d8d5c5f9a5d593b2e931f5e36122ee5c793c1d08a19839cc0 \
+	--flow yourFlowName \
+	--wait \
+	--timeout 15m \
+	--api-token yourAPIToken \
+	--org yourOrgName 
 `
 
 type assertArtifactOptions struct {
 	fingerprintOptions *fingerprintOptions
 	fingerprint        string // This is calculated or provided by the user
 	flowName           string
+	waitOptions        waitOptions
 }
 
 func newAssertArtifactCmd(out io.Writer) *cobra.Command {
@@ -55,6 +68,11 @@ func newAssertArtifactCmd(out io.Writer) *cobra.Command {
 			if err != nil {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
 			}
+
+			err = o.waitOptions.validate()
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
 			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,6 +83,7 @@ func newAssertArtifactCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&o.fingerprint, "fingerprint", "F", "", fingerprintFlag)
 	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
 	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addWaitFlags(cmd, &o.waitOptions)
 	addDryRunFlag(cmd)
 
 	err := RequireFlags(cmd, []string{"flow"})
@@ -83,7 +102,12 @@ func (o *assertArtifactOptions) run(out io.Writer, args []string) error {
 			return err
 		}
 	}
+	return o.waitOptions.poll(o.assertCompliant)
+}
 
+// assertCompliant returns nil if the artifact is compliant,
+// and a pending error if its compliance is incomplete
+func (o *assertArtifactOptions) assertCompliant() error {
 	url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s/fingerprint/%s", global.Host, global.Org, o.flowName, o.fingerprint)
 
 	reqParams := &requests.RequestParams{
@@ -102,14 +126,18 @@ func (o *assertArtifactOptions) run(out io.Writer, args []string) error {
 		return err
 	}
 
-	if artifactData["state"].(string) == "COMPLIANT" {
+	state := artifactData["state"].(string)
+	if state == "COMPLIANT" {
 		logger.Info("COMPLIANT")
 		logger.Info("See more details at %s", artifactData["html_url"].(string))
-	} else {
-		return fmt.Errorf("%s: %s\nSee more details at %s", artifactData["state"].(string),
-			artifactData["state_info"].(string),
-			artifactData["html_url"].(string))
+		return nil
 	}
 
-	return nil
+	err = fmt.Errorf("%s: %s\nSee more details at %s", state,
+		artifactData["state_info"].(string),
+		artifactData["html_url"].(string))
+	if state != "NON-COMPLIANT" {
+		return &pendingError{err}
+	}
+	return err
 }
@@ -49,6 +49,12 @@ func (suite *AssertArtifactCommandTestSuite) TestAssertArtifactCmd() {
 			cmd:       fmt.Sprintf(`assert artifact --fingerprint 8e568bd886069f1290def0caabc1e97ce0e7b80c105e611258b57d76fcef234c  --flow %s --api-token secret`, suite.flowName),
 			golden:    "Error: --org is not set\nUsage: kosli assert artifact [IMAGE-NAME | FILE-PATH | DIR-PATH] [flags]\n",
 		},
+		{
+			wantError: true,
+			name:      "--wait with a poll interval that is not positive fails",
+			cmd:       fmt.Sprintf(`assert artifact --fingerprint %s --flow %s --wait --poll-interval 0s %s`, suite.fingerprint, suite.flowName, suite.defaultKosliArguments),
+			golden:    "Error: --poll-interval must be positive\nUsage: kosli assert artifact [IMAGE-NAME | FILE-PATH | DIR-PATH] [flags]\n",
+		},
 		{
 			wantError: true,
 			name:      "asserting a non existing artifact fails",
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kosli-dev/cli/internal/output"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+const assertEnvironmentShortDesc = `Assert that the artifacts running in an environment pass a set of checks.  `
+
+const assertEnvironmentLongDesc = assertEnvironmentShortDesc + `
+The checks are run on each artifact running in a snapshot of the environment:
+- ^--require-compliance^: the artifact is compliant.
+- ^--require-provenance^: the artifact was reported to a Kosli flow, which records where it was built from.
+- ^--max-age-days^: the artifact is not older than the given number of days, counted from its creation
+  in its Kosli flow. Artifacts that were not reported to a flow fail this check.
+- ^--max-running-days^: the artifact has not been running in the environment for more than the given
+  number of days, counted from its oldest running replica. This is not the age of the artifact itself.
+- ^--replicas^: the artifact runs the given number of replicas.
+- ^--subset-of^: the artifact is also running in another environment, e.g. everything running in prod
+  must be running in staging.
+
+At least one check is required. The failed checks are reported for each artifact.
+Exits with non-zero code if a check fails, or if no artifacts are running in the snapshot.
+
+The expected argument is an expression to specify the environment snapshot to assert,
+in the format of ^kosli get snapshot^, e.g. ^prod^ for the latest snapshot of prod,
+or ^prod#5^ for its fifth snapshot. ^--subset-of^ accepts the same expressions.
+`
+
+const assertEnvironmentExample = `
+# fail if an artifact running in staging is not compliant, or has no provenance
+kosli assert environment staging \
+	--require-compliance \
+	--require-provenance \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# fail if an artifact running in prod was created more than 90 days ago
+kosli assert environment prod \
+	--max-age-days 90 \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# fail if an artifact running in prod has been running for more than 30 days,
+# does not run 3 replicas, or is not running in staging
+kosli assert environment prod \
+	--max-running-days 30 \
+	--replicas 3 \
+	--subset-of staging \
+	--api-token yourAPIToken \
+	--org yourOrgName
+`
+
+// environmentCheckFlags are the flags that enable the checks of 'kosli assert environment'
+var environmentCheckFlags = []string{"require-compliance", "require-provenance", "max-age-days", "max-running-days", "replicas", "subset-of"}
+
+type assertEnvironmentOptions struct {
+	requireCompliance bool
+	requireProvenance bool
+	maxAgeDays        int
+	maxRunningDays    int
+	replicas          int
+	subsetOf          string
+	output            string
+}
+
+func newAssertEnvironmentCmd(out io.Writer) *cobra.Command {
+	o := new(assertEnvironmentOptions)
+	cmd := &cobra.Command{
+		Use:     "environment ENVIRONMENT-NAME-OR-EXPRESSION",
+		Short:   assertEnvironmentShortDesc,
+		Long:    assertEnvironmentLongDesc,
+		Example: assertEnvironmentExample,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			checks := 0
+			for _, name := range environmentCheckFlags {
+				if cmd.Flags().Changed(name) {
+					checks++
+				}
+			}
+			if checks == 0 {
+				return ErrorBeforePrintingUsage(cmd, fmt.Sprintf("at least one of %s is required", JoinFlagNames(environmentCheckFlags)))
+			}
+			if cmd.Flags().Changed("max-age-days") && o.maxAgeDays <= 0 {
+				return ErrorBeforePrintingUsage(cmd, "--max-age-days must be positive")
+			}
+			if cmd.Flags().Changed("max-running-days") && o.maxRunningDays <= 0 {
+				return ErrorBeforePrintingUsage(cmd, "--max-running-days must be positive")
+			}
+			if cmd.Flags().Changed("replicas") && o.replicas <= 0 {
+				return ErrorBeforePrintingUsage(cmd, "--replicas must be positive")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(out, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.requireCompliance, "require-compliance", false, requireComplianceFlag)
+	cmd.Flags().BoolVar(&o.requireProvenance, "require-provenance", false, requireProvenanceFlag)
+	cmd.Flags().IntVar(&o.maxAgeDays, "max-age-days", 0, maxAgeDaysFlag)
+	cmd.Flags().IntVar(&o.maxRunningDays, "max-running-days", 0, maxRunningDaysFlag)
+	cmd.Flags().IntVar(&o.replicas, "replicas", 0, replicasFlag)
+	cmd.Flags().StringVar(&o.subsetOf, "subset-of", "", subsetOfFlag)
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", outputFlag)
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+func (o *assertEnvironmentOptions) run(out io.Writer, args []string) error {
+	snapshot, err := getEnvironmentSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+	err = requireRunningArtifacts(snapshot, args[0])
+	if err != nil {
+		return err
+	}
+	checks, err := o.checks(snapshot)
+	if err != nil {
+		return err
+	}
+
+	failures := checkEnvironment(snapshot, checks)
+	if len(failures) == 0 && o.output == "table" {
+		names := []string{}
+		for _, c := range checks {
+			names = append(names, c.name())
+		}
+		logger.Info("the %d artifacts running in %s pass the checks: %s", len(runningArtifacts(snapshot)), args[0], strings.Join(names, ", "))
+		return nil
+	}
+
+	raw, err := json.Marshal(failures)
+	if err != nil {
+		return err
+	}
+	err = output.FormattedPrint(string(raw), o.output, out, 0,
+		map[string]output.FormatOutputFunc{
+			"table": printEnvironmentCheckFailuresAsTable,
+			"json":  output.PrintJson,
+		})
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d checks failed for the artifacts running in %s", len(failures), args[0])
+	}
+	return nil
+}
+
+// checks returns the checks enabled by the flags for the artifacts running in the snapshot
+func (o *assertEnvironmentOptions) checks(snapshot *Snapshot) ([]environmentCheck, error) {
+	checks := []environmentCheck{}
+	if o.requireCompliance {
+		checks = append(checks, complianceCheck{})
+	}
+	if o.requireProvenance {
+		checks = append(checks, provenanceCheck{})
+	}
+	if o.maxAgeDays > 0 {
+		createdAt, err := artifactCreationTimestamps(snapshot)
+		if err != nil {
+			return checks, err
+		}
+		checks = append(checks, maxAgeCheck{maxAge: time.Duration(o.maxAgeDays) * 24 * time.Hour, now: clockNow(), createdAt: createdAt})
+	}
+	if o.maxRunningDays > 0 {
+		checks = append(checks, maxRunningCheck{maxRunning: time.Duration(o.maxRunningDays) * 24 * time.Hour, now: clockNow()})
+	}
+	if o.replicas > 0 {
+		checks = append(checks, replicasCheck{replicas: o.replicas})
+	}
+	if o.subsetOf != "" {
+		snapshot, err := getEnvironmentSnapshot(o.subsetOf)
+		if err != nil {
+			return checks, err
+		}
+		checks = append(checks, newSubsetCheck(o.subsetOf, snapshot))
+	}
+	return checks, nil
+}
+
+// getEnvironmentSnapshot gets the environment snapshot that a snapshot expression refers to
+func getEnvironmentSnapshot(expression string) (*Snapshot, error) {
+	envName, id, err := handleSnapshotExpressions(expression)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/api/v2/snapshots/%s/%s/%s", global.Host, global.Org, envName, id)
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodGet,
+		URL:      url,
+		Password: global.ApiToken,
+	}
+	response, err := kosliClient.Do(reqParams)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	err = json.Unmarshal([]byte(response.Body), snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// artifactCreationTimestamps gets the creation timestamps of the artifacts running in the snapshot
+// from their Kosli flows, by fingerprint. Artifacts without a flow are left out.
+func artifactCreationTimestamps(snapshot *Snapshot) (map[string]int64, error) {
+	createdAt := make(map[string]int64)
+	for _, artifact := range runningArtifacts(snapshot) {
+		if artifact.FlowName == "" {
+			continue
+		}
+		url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s/fingerprint/%s", global.Host, global.Org, artifact.FlowName, artifact.Fingerprint)
+		reqParams := &requests.RequestParams{
+			Method:   http.MethodGet,
+			URL:      url,
+			Password: global.ApiToken,
+		}
+		response, err := kosliClient.Do(reqParams)
+		if err != nil {
+			return createdAt, err
+		}
+
+		var flowArtifact struct {
+			CreatedAt float64 `json:"created_at"`
+		}
+		err = json.Unmarshal([]byte(response.Body), &flowArtifact)
+		if err != nil {
+			return createdAt, err
+		}
+		createdAt[artifact.Fingerprint] = int64(flowArtifact.CreatedAt)
+	}
+	return createdAt, nil
+}
+
+func printEnvironmentCheckFailuresAsTable(raw string, out io.Writer, page int) error {
+	var failures []*EnvironmentCheckFailure
+	err := json.Unmarshal([]byte(raw), &failures)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"ARTIFACT", "FINGERPRINT", "CHECK", "REASON"}
+	rows := []string{}
+	for _, failure := range failures {
+		rows = append(rows, fmt.Sprintf("%s\t%s\t%s\t%s", failure.Artifact, shortSHA(failure.Fingerprint), failure.Check, failure.Reason))
+	}
+	tabFormattedPrint(out, header, rows)
+	return nil
+}
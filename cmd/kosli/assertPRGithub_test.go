@@ -42,6 +42,13 @@ func (suite *AssertPRGithubCommandTestSuite) TestAssertPRGithubCmd() {
 			--commit 19aab7f063147614451c88969602a10afbabb43d` + suite.defaultKosliArguments,
 			golden: "Error: no pull requests found for the given commit: 19aab7f063147614451c88969602a10afbabb43d\n",
 		},
+		{
+			wantError: true,
+			name:      "assert Github PR evidence with --require-four-eyes fails when commit has no PRs in github",
+			cmd: `assert pullrequest github --github-org kosli-dev --repository cli --require-four-eyes
+			--commit 19aab7f063147614451c88969602a10afbabb43d` + suite.defaultKosliArguments,
+			golden: "Error: no pull requests found for the given commit: 19aab7f063147614451c88969602a10afbabb43d\n",
+		},
 		{
 			wantError: true,
 			name:      "assert Github PR evidence fails when commit does not exist",
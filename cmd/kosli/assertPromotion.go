@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kosli-dev/cli/internal/output"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+// promotionEventsPageLimit is the number of environment events fetched per page
+const promotionEventsPageLimit = 50
+
+const assertPromotionShortDesc = `Assert that an artifact has run in a list of environments before it is promoted.  `
+
+const assertPromotionLongDesc = assertPromotionShortDesc + `
+Checks that the artifact with the given fingerprint has run in each of the ^--through^ environments,
+e.g. in dev and staging before it is deployed to prod. With ^--min-soak^, it must also have been running
+in each of them for at least the given duration.
+
+The environments the artifact ran in are found with ^kosli search^, and when it started and exited running
+in them with ^kosli log environment^. The soak time is the longest uninterrupted period the artifact was running
+in an environment, from when it started running there until it exited, or until now if it is still running.
+The periods of an artifact that exited and was deployed again are not added up.
+
+When and where the artifact ran is printed. Exits with non-zero code if the artifact did not run
+in an environment, or not for long enough.
+`
+
+const assertPromotionExample = `
+# fail if the artifact has not run in dev and staging
+kosli assert promotion 184c799cd551dd1d8d5c5f9a5d593b2e931f5e36122ee5c793c1d08a19839cc0 \
+	--through dev,staging \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# fail if the artifact has not run in dev and staging for at least 2 hours in each
+kosli assert promotion 184c799cd551dd1d8d5c5f9a5d593b2e931f5e36122ee5c793c1d08a19839cc0 \
+	--through dev,staging \
+	--min-soak 2h \
+	--api-token yourAPIToken \
+	--org yourOrgName
+`
+
+type assertPromotionOptions struct {
+	through []string
+	minSoak time.Duration
+	output  string
+}
+
+// PromotionStage is when and where an artifact ran in one of the environments it must be promoted through
+type PromotionStage struct {
+	Environment string   `json:"environment"`
+	StartedAt   *float64 `json:"started_at"`
+	ExitedAt    *float64 `json:"exited_at"`
+	Running     bool     `json:"running"`
+	SoakSeconds int64    `json:"soak_seconds"`
+	Passed      bool     `json:"passed"`
+	Reason      string   `json:"reason"`
+}
+
+func newAssertPromotionCmd(out io.Writer) *cobra.Command {
+	o := new(assertPromotionOptions)
+	cmd := &cobra.Command{
+		Use:     "promotion FINGERPRINT",
+		Short:   assertPromotionShortDesc,
+		Long:    assertPromotionLongDesc,
+		Example: assertPromotionExample,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			if o.minSoak < 0 {
+				return ErrorBeforePrintingUsage(cmd, "--min-soak must not be negative")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(out, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.through, "through", []string{}, throughFlag)
+	cmd.Flags().DurationVar(&o.minSoak, "min-soak", 0, minSoakFlag)
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", outputFlag)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"through"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *assertPromotionOptions) run(out io.Writer, args []string) error {
+	url := fmt.Sprintf("%s/api/v2/search/%s/sha/%s", global.Host, global.Org, args[0])
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodGet,
+		URL:      url,
+		Password: global.ApiToken,
+	}
+	response, err := kosliClient.Do(reqParams)
+	if err != nil {
+		return err
+	}
+
+	var searchResult SearchResponse
+	err = json.Unmarshal([]byte(response.Body), &searchResult)
+	if err != nil {
+		return err
+	}
+	if searchResult.ResolvedTo.Type == "commit" {
+		return fmt.Errorf("%s is a git commit, not an artifact fingerprint", args[0])
+	}
+	fingerprint := searchResult.ResolvedTo.FullMatch
+	running, exited := artifactEnvironments(searchResult.Artifacts)
+	firstSeen := artifactFirstSeenAt(searchResult.Artifacts)
+
+	stages := []*PromotionStage{}
+	for _, envName := range o.through {
+		var events []*environmentEvent
+		if running[envName] || exited[envName] {
+			events, err = getArtifactEnvironmentEvents(envName, fingerprint, firstSeen)
+			if err != nil {
+				return err
+			}
+		}
+		stages = append(stages, promotionStage(envName, running[envName], exited[envName], events, o.minSoak, clockNow()))
+	}
+
+	raw, err := json.Marshal(stages)
+	if err != nil {
+		return err
+	}
+	err = output.FormattedPrint(string(raw), o.output, out, 0,
+		map[string]output.FormatOutputFunc{
+			"table": printPromotionStagesAsTable,
+			"json":  output.PrintJson,
+		})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, stage := range stages {
+		if !stage.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("artifact with fingerprint %s was not promoted through %d of %d environments", fingerprint, failed, len(stages))
+	}
+	return nil
+}
+
+// artifactEnvironments returns the environments that the searched artifacts are running in,
+// and the ones they exited from. Search results may refer to environment snapshots, e.g. staging#5.
+func artifactEnvironments(artifacts []SearchArtifact) (running, exited map[string]bool) {
+	running = make(map[string]bool)
+	exited = make(map[string]bool)
+	for _, artifact := range artifacts {
+		for _, env := range artifact.RunningIn {
+			running[strings.Split(env, "#")[0]] = true
+		}
+		for _, env := range artifact.ExitedFrom {
+			exited[strings.Split(env, "#")[0]] = true
+		}
+	}
+	return running, exited
+}
+
+// artifactFirstSeenAt returns the time of the oldest event in the history of the searched artifacts,
+// before which they cannot have run in any environment, or 0 if they have no history
+func artifactFirstSeenAt(artifacts []SearchArtifact) float64 {
+	firstSeen := 0.0
+	for _, artifact := range artifacts {
+		for _, event := range artifact.History {
+			if timestamp, ok := event["timestamp"].(float64); ok && (firstSeen == 0 || timestamp < firstSeen) {
+				firstSeen = timestamp
+			}
+		}
+	}
+	return firstSeen
+}
+
+// environmentEvent is an event in the event log of an environment
+type environmentEvent struct {
+	Fingerprint string  `json:"sha256"`
+	ReportedAt  float64 `json:"reported_at"`
+	// Type is what happened to the artifact, as in the annotations of the snapshots,
+	// e.g. started when it started running and exited when its last instance stopped
+	Type string `json:"type"`
+}
+
+// getArtifactEnvironmentEvents returns the events of an environment for the artifact with the fingerprint.
+// The event log is listed from the latest event, and only down to the events reported before since,
+// when the artifact could not have run yet. All the event log is listed if since is 0.
+func getArtifactEnvironmentEvents(envName, fingerprint string, since float64) ([]*environmentEvent, error) {
+	artifactEvents := []*environmentEvent{}
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/api/v2/environments/%s/%s/events?page=%d&per_page=%d",
+			global.Host, global.Org, envName, page, promotionEventsPageLimit)
+
+		reqParams := &requests.RequestParams{
+			Method:   http.MethodGet,
+			URL:      url,
+			Password: global.ApiToken,
+		}
+		response, err := kosliClient.Do(reqParams)
+		if err != nil {
+			return artifactEvents, err
+		}
+
+		var events []*environmentEvent
+		err = json.Unmarshal([]byte(response.Body), &events)
+		if err != nil {
+			return artifactEvents, err
+		}
+		for _, event := range events {
+			if event.Fingerprint == fingerprint {
+				artifactEvents = append(artifactEvents, event)
+			}
+		}
+		if len(events) < promotionEventsPageLimit || events[len(events)-1].ReportedAt < since {
+			return artifactEvents, nil
+		}
+	}
+}
+
+// soakPeriod is an uninterrupted period an artifact was running in an environment.
+// exitedAt is nil if the artifact is still running.
+type soakPeriod struct {
+	startedAt float64
+	exitedAt  *float64
+}
+
+// promotionStage returns when and where the artifact ran in an environment, from its events
+// in that environment, and whether its longest uninterrupted run there lasted at least minSoak
+func promotionStage(envName string, running, exited bool, events []*environmentEvent, minSoak time.Duration, now time.Time) *PromotionStage {
+	stage := &PromotionStage{Environment: envName, Running: running}
+	if !running && !exited {
+		stage.Reason = fmt.Sprintf("the artifact has not run in %s", envName)
+		return stage
+	}
+
+	periods := soakPeriods(events, running)
+	if len(periods) == 0 {
+		stage.Reason = fmt.Sprintf("no events of the artifact were found in the event log of %s", envName)
+		return stage
+	}
+
+	var soak time.Duration
+	for _, period := range periods {
+		until := now
+		if period.exitedAt != nil {
+			until = time.Unix(int64(*period.exitedAt), 0)
+		}
+		if periodSoak := until.Sub(time.Unix(int64(period.startedAt), 0)); stage.StartedAt == nil || periodSoak > soak {
+			soak = periodSoak
+			stage.StartedAt = &period.startedAt
+			stage.ExitedAt = period.exitedAt
+		}
+	}
+	stage.SoakSeconds = int64(soak.Seconds())
+	if soak < minSoak {
+		stage.Reason = fmt.Sprintf("the artifact ran in %s for %s, less than %s", envName, soak.Round(time.Second), minSoak)
+		return stage
+	}
+	stage.Passed = true
+	return stage
+}
+
+// soakPeriods pairs the events of the artifact starting and exiting running in an environment
+// into the periods it was running there, oldest first. The last period is still running if the
+// artifact is running, and ends with the last event otherwise.
+func soakPeriods(events []*environmentEvent, running bool) []*soakPeriod {
+	sorted := append([]*environmentEvent{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ReportedAt < sorted[j].ReportedAt
+	})
+
+	periods := []*soakPeriod{}
+	var current *soakPeriod
+	for _, event := range sorted {
+		if event.Type == "started" && current == nil {
+			current = &soakPeriod{startedAt: event.ReportedAt}
+			periods = append(periods, current)
+		} else if event.Type == "exited" && current != nil {
+			exitedAt := event.ReportedAt
+			current.exitedAt = &exitedAt
+			current = nil
+		}
+	}
+	if current != nil && !running {
+		lastReportedAt := sorted[len(sorted)-1].ReportedAt
+		current.exitedAt = &lastReportedAt
+	}
+	return periods
+}
+
+func printPromotionStagesAsTable(raw string, out io.Writer, page int) error {
+	var stages []*PromotionStage
+	err := json.Unmarshal([]byte(raw), &stages)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"ENVIRONMENT", "STARTED", "EXITED", "SOAK", "RESULT", "REASON"}
+	rows := []string{}
+	for _, stage := range stages {
+		started, exited, soak := "N/A", "N/A", "N/A"
+		if stage.StartedAt != nil {
+			started, err = formattedTimestamp(*stage.StartedAt, true)
+			if err != nil {
+				return err
+			}
+			soak = (time.Duration(stage.SoakSeconds) * time.Second).String()
+			exited = "still running"
+		}
+		if stage.ExitedAt != nil {
+			exited, err = formattedTimestamp(*stage.ExitedAt, true)
+			if err != nil {
+				return err
+			}
+		}
+		result := "PASSED"
+		if !stage.Passed {
+			result = "FAILED"
+		}
+		rows = append(rows, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", stage.Environment, started, exited, soak, result, stage.Reason))
+	}
+	tabFormattedPrint(out, header, rows)
+	return nil
+}
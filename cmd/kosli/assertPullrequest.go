@@ -1,13 +1,23 @@
 package main
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/kosli-dev/cli/internal/types"
 	"github.com/spf13/cobra"
 )
 
 const assertPRDesc = `All Kosli pullrequests assertion commands. Return non-zero exit code if the assertion fails.`
 
+type assertPullRequestOptions struct {
+	provider        gitProvider
+	newRetriever    func() types.PRRetriever
+	commit          string
+	requireFourEyes bool
+	prNumber        int
+}
+
 func newAssertPRCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "pullrequest",
@@ -16,13 +26,62 @@ func newAssertPRCmd(out io.Writer) *cobra.Command {
 		Long:    assertPRDesc,
 	}
 
-	// Add subcommands
-	cmd.AddCommand(
-		newAssertPullRequestBitbucketCmd(out),
-		newAssertPullRequestGithubCmd(out),
-		newAssertPullRequestGitlabCmd(out),
-		newAssertPullRequestAzureCmd(out),
-	)
+	// Add a subcommand for each git provider
+	for _, provider := range gitProviders {
+		cmd.AddCommand(newAssertPullRequestProviderCmd(out, provider))
+	}
 
 	return cmd
 }
+
+func newAssertPullRequestProviderCmd(out io.Writer, provider gitProvider) *cobra.Command {
+	o := &assertPullRequestOptions{provider: provider}
+	shortDesc := fmt.Sprintf("Assert %s %s for a git commit exists.  ", withArticle(provider.DisplayName()), provider.Label())
+	cmd := &cobra.Command{
+		Use:     provider.Name(),
+		Aliases: provider.Aliases(),
+		Short:   shortDesc,
+		Long: shortDesc + fmt.Sprintf(`
+The command exits with non-zero exit code 
+if no %[1]ss were found for the commit,
+or, with --require-four-eyes, if a %[1]s does not satisfy the four-eyes rule.`, provider.Label()),
+		Example: fmt.Sprintf(`
+kosli assert pullrequest %s \
+%s	--commit yourGitCommit
+`, provider.Name(), exampleProviderFlags(provider)),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := provider.ValidateFlags(cmd)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	o.newRetriever = provider.AddFlags(cmd, ci)
+	cmd.Flags().StringVar(&o.commit, "commit", DefaultValue(ci, "git-commit"), commitPREvidenceFlag)
+	cmd.Flags().BoolVar(&o.requireFourEyes, "require-four-eyes", false, assertFourEyesFlag)
+	cmd.Flags().IntVar(&o.prNumber, "pr-number", 0, fmt.Sprintf(prNumberFlag, o.provider.Label()))
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append(provider.RequiredFlags(), "commit"))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *assertPullRequestOptions) run(args []string) error {
+	pullRequestsEvidence, err := getPullRequestsEvidence(o.newRetriever(), o.provider.Label(), o.commit, o.prNumber, true, o.requireFourEyes)
+	if err != nil {
+		return err
+	}
+	logger.Info("found [%d] pull request(s) in %s for commit: %s", len(pullRequestsEvidence), o.provider.DisplayName(), o.commit)
+	return nil
+}
@@ -25,15 +25,31 @@ Examples of valid expressions are:
 - prod (latest snapshot of prod)
 - prod#10 (snapshot number 10 of prod)
 - prod~2 (third latest snapshot of prod)
+
+With ^--wait^, the assertion is checked again while the latest snapshot of the environment is non-compliant,
+until a compliant snapshot is reported or ^--timeout^ expires. Only the assertion of the latest snapshot
+is checked again, as the other snapshots do not change. Exits with code 2 if the timeout expires.
 `
 
 const assertSnapshotExample = `
 kosli assert snapshot prod#5 \
 	--api-token yourAPIToken \
 	--org yourOrgName
+
+# wait up to 10 minutes for the environment to be compliant
+kosli assert snapshot prod \
+	--wait \
+	--timeout 10m \
+	--api-token yourAPIToken \
+	--org yourOrgName
 `
 
+type assertSnapshotOptions struct {
+	waitOptions waitOptions
+}
+
 func newAssertSnapshotCmd(out io.Writer) *cobra.Command {
+	o := new(assertSnapshotOptions)
 	cmd := &cobra.Command{
 		Use:     "snapshot ENVIRONMENT-NAME-OR-EXPRESSION",
 		Short:   assertSnapshotShortDesc,
@@ -45,22 +61,36 @@ func newAssertSnapshotCmd(out io.Writer) *cobra.Command {
 			if err != nil {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
 			}
+
+			err = o.waitOptions.validate()
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(out, args)
+			return o.run(out, args)
 		},
 	}
+	addWaitFlags(cmd, &o.waitOptions)
 	addDryRunFlag(cmd)
 
 	return cmd
 }
 
-func run(out io.Writer, args []string) error {
+func (o *assertSnapshotOptions) run(out io.Writer, args []string) error {
 	envName, id, err := handleExpressions(args[0])
 	if err != nil {
 		return err
 	}
+	return o.waitOptions.poll(func() error {
+		return assertSnapshotCompliant(envName, id)
+	})
+}
+
+// assertSnapshotCompliant returns nil if the snapshot of the environment is compliant,
+// and a pending error if the latest snapshot, which a new snapshot can replace, is not compliant
+func assertSnapshotCompliant(envName string, id int) error {
 	url := fmt.Sprintf("%s/api/v2/snapshots/%s/%s/%d", global.Host, global.Org, envName, id)
 
 	reqParams := &requests.RequestParams{
@@ -81,9 +111,10 @@ func run(out io.Writer, args []string) error {
 
 	if environmentData["compliant"].(bool) {
 		logger.Info("COMPLIANT")
-	} else {
-		return fmt.Errorf("INCOMPLIANT")
+		return nil
 	}
-
-	return nil
+	if id == -1 {
+		return &pendingError{fmt.Errorf("INCOMPLIANT")}
+	}
+	return fmt.Errorf("INCOMPLIANT")
 }
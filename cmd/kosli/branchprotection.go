@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+type EvidenceBranchProtectionPayload struct {
+	GenericEvidencePayload
+	GitProvider      string                  `json:"git_provider"`
+	BranchProtection *types.BranchProtection `json:"branch_protection"`
+	Violations       []string                `json:"violations"`
+}
+
+type branchProtectionCommitOptions struct {
+	payload          EvidenceBranchProtectionPayload
+	retriever        types.BranchProtectionRetriever
+	branch           string
+	userDataFilePath string
+}
+
+func (o *branchProtectionCommitOptions) run(args []string) error {
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/generic", global.Host, global.Org)
+
+	var err error
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	// Azure DevOps provides the full ref of the branch
+	branch := strings.TrimPrefix(o.branch, "refs/heads/")
+	o.payload.BranchProtection, err = o.retriever.BranchProtectionForCommit(branch, o.payload.CommitSHA)
+	if err != nil {
+		return err
+	}
+	o.payload.Compliant, o.payload.Violations = o.payload.BranchProtection.Evaluate()
+	for _, violation := range o.payload.Violations {
+		logger.Warning("%s", violation)
+	}
+
+	// branch protection evidence does not have files to upload
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("%s branch protection evidence is reported to commit: %s", o.payload.GitProvider, o.payload.CommitSHA)
+	}
+	return err
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/output"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+const changelogShortDesc = `Generate the release notes of the changes between two artifacts.  `
+
+const changelogLongDesc = changelogShortDesc + `
+The expected argument is a range of two artifact expressions, FROM..TO, where FROM is the
+previously released artifact and TO is the artifact to release.
+Each expression has the format <FLOW_NAME><SEPARATOR><COMMIT_SHA1|ARTIFACT_FINGERPRINT>:
+- flowName@<fingerprint>  artifact with a given fingerprint. The fingerprint can be short or complete.
+- flowName:<commit_sha>   artifact with a given commit SHA. The commit sha can be short or complete.
+
+The release notes list:
+- the artifacts of the flow of TO that were created from the commits of the range, with their compliance state.
+- the git commits reachable from the git commit of TO and not from the git commit of FROM.
+- with ^--git-provider^, the pull requests of these commits.
+- with ^--jira-base-url^, the Jira issues referenced by the commit messages and the pull requests.
+
+The git repository must contain the git commits of both artifacts. When it is a shallow clone without the
+history between them, the history is fetched to the depth of ^--auto-fetch-depth^, or the commits are got
+from the github, gitlab or azure ^--git-provider^, and the commits are truncated to the available history otherwise.
+The release notes are printed in Markdown, or in JSON with ^--output json^.`
+
+const changelogExample = `
+# print the release notes of the changes between two artifacts in Markdown
+kosli changelog yourFlowName@previousFingerprint..yourFlowName@newFingerprint \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# print the release notes of the changes between two artifacts, with their pull requests
+# in Github and the Jira issues they reference, in JSON
+kosli changelog yourFlowName:previousCommitSHA..yourFlowName:newCommitSHA \
+	--git-provider github \
+	--github-token yourGithubToken \
+	--github-org yourGithubOrg \
+	--repository yourGithubGitRepository \
+	--jira-base-url https://kosli.atlassian.net \
+	--jira-username user@domain.com \
+	--jira-api-token yourJiraAPIToken \
+	--output json \
+	--api-token yourAPIToken \
+	--org yourOrgName
+`
+
+type changelogOptions struct {
+	srcRepoRoot  string
+	rangeOptions gitview.RangeOptions
+	autoFetch    int
+	output       string
+	projectKeys  []string
+	withIssues   bool
+	newRetriever func() (types.PRRetriever, error)
+	newTracker   func() types.IssueTracker
+}
+
+// ReleaseNotes are the changes between two artifacts
+type ReleaseNotes struct {
+	From         *ReleaseArtifact      `json:"from"`
+	To           *ReleaseArtifact      `json:"to"`
+	Artifacts    []*ReleaseArtifact    `json:"artifacts"`
+	Commits      []*gitview.CommitInfo `json:"commits"`
+	PullRequests []*types.PREvidence   `json:"pull_requests"`
+	Issues       []*types.IssueInfo    `json:"issues"`
+	// CommitsTruncated is true when the git history only contains part of the commits
+	CommitsTruncated bool `json:"commits_truncated"`
+}
+
+// ReleaseArtifact is an artifact as returned by the Kosli API, with the fields of the release notes
+type ReleaseArtifact struct {
+	Flow        string `json:"flow_name"`
+	Name        string `json:"filename"`
+	Fingerprint string `json:"fingerprint"`
+	GitCommit   string `json:"git_commit"`
+	State       string `json:"state"`
+	URL         string `json:"html_url"`
+}
+
+func newChangelogCmd(out io.Writer) *cobra.Command {
+	o := new(changelogOptions)
+	cmd := &cobra.Command{
+		Use:     "changelog FROM..TO",
+		Short:   changelogShortDesc,
+		Long:    changelogLongDesc,
+		Example: changelogExample,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			o.withIssues = cmd.Flags().Changed("jira-base-url")
+			if o.withIssues {
+				err = jiraIssueTracker.ValidateFlags(cmd)
+				if err != nil {
+					return ErrorBeforePrintingUsage(cmd, err.Error())
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(out, args)
+		},
+	}
+
+	ci := WhichCI()
+	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	cmd.Flags().BoolVar(&o.rangeOptions.FirstParent, "first-parent", false, firstParentFlag)
+	cmd.Flags().StringSliceVar(&o.rangeOptions.Paths, "src-paths", []string{}, srcPathsFlag)
+	cmd.Flags().IntVar(&o.autoFetch, "auto-fetch-depth", 0, changelogFetchDepthFlag)
+	cmd.Flags().StringVarP(&o.output, "output", "o", "markdown", changelogOutputFlag)
+	cmd.Flags().StringSliceVar(&o.projectKeys, "project-keys", []string{}, fmt.Sprintf(issueProjectKeysFlag, "Jira"))
+	o.newTracker = jiraIssueTracker.AddFlags(cmd, ci)
+	o.newRetriever = addGitProviderFlags(cmd, ci, gitProviderChangelogFlag)
+
+	return cmd
+}
+
+func (o *changelogOptions) run(out io.Writer, args []string) error {
+	fromExpression, toExpression, err := parseArtifactRange(args[0])
+	if err != nil {
+		return err
+	}
+	notes := &ReleaseNotes{}
+	notes.From, err = getReleaseArtifact(fromExpression)
+	if err != nil {
+		return err
+	}
+	notes.To, err = getReleaseArtifact(toExpression)
+	if err != nil {
+		return err
+	}
+
+	retriever, err := o.newRetriever()
+	if err != nil {
+		return err
+	}
+	gitView, err := gitview.New(o.srcRepoRoot)
+	if err != nil {
+		return err
+	}
+	opts, err := o.changeLogOptions(retriever)
+	if err != nil {
+		return err
+	}
+	notes.Commits, notes.CommitsTruncated, err = gitView.CompleteCommitsBetween(notes.From.GitCommit, notes.To.GitCommit, opts, logger)
+	if err != nil {
+		return err
+	}
+	notes.Artifacts, err = releasedArtifacts(notes.From, notes.To, notes.Commits)
+	if err != nil {
+		return err
+	}
+
+	notes.PullRequests, err = releasedPullRequests(retriever, notes.Commits)
+	if err != nil {
+		return err
+	}
+	notes.Issues, err = o.releasedIssues(notes.Commits, notes.PullRequests)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(notes)
+	if err != nil {
+		return err
+	}
+	return output.FormattedPrint(string(raw), o.output, out, 0,
+		map[string]output.FormatOutputFunc{
+			"markdown": printReleaseNotesAsMarkdown,
+			"json":     output.PrintJson,
+		})
+}
+
+// changeLogOptions returns the options to list the released commits with. The history of a shallow clone
+// is completed through the git provider if it can list the commits between two commits.
+func (o *changelogOptions) changeLogOptions(retriever types.PRRetriever) (*gitview.ChangeLogOptions, error) {
+	opts := &gitview.ChangeLogOptions{RangeOptions: o.rangeOptions, AutoFetchDepth: o.autoFetch}
+	if o.autoFetch < 0 {
+		return opts, fmt.Errorf("--auto-fetch-depth must be 0 or more")
+	}
+	if comparer, ok := retriever.(gitview.CommitComparer); ok {
+		opts.Comparer = comparer
+	}
+	return opts, nil
+}
+
+// parseArtifactRange returns the FROM and TO artifact expressions of a FROM..TO range
+func parseArtifactRange(artifactRange string) (string, string, error) {
+	items := strings.Split(artifactRange, "..")
+	if len(items) != 2 || items[0] == "" || items[1] == "" {
+		return "", "", fmt.Errorf("invalid artifact range: %s. Expected FROM..TO, e.g. flowName@fingerprint1..flowName@fingerprint2", artifactRange)
+	}
+	return items[0], items[1], nil
+}
+
+// getReleaseArtifact returns the artifact of an artifact expression.
+// An expression with a commit SHA must match a single artifact.
+func getReleaseArtifact(expression string) (*ReleaseArtifact, error) {
+	flowName, id, separator, err := handleArtifactExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	idType := "commit_sha"
+	if separator == "@" {
+		idType = "fingerprint"
+	}
+
+	url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s/%s/%s", global.Host, global.Org, flowName, idType, id)
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodGet,
+		URL:      url,
+		Password: global.ApiToken,
+	}
+	response, err := kosliClient.Do(reqParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// the API returns a list of artifacts for a commit SHA and a single artifact for a fingerprint
+	body := response.Body
+	if !strings.HasPrefix(body, "[") {
+		body = "[" + body + "]"
+	}
+	var artifacts []*ReleaseArtifact
+	err = json.Unmarshal([]byte(body), &artifacts)
+	if err != nil {
+		return nil, err
+	}
+	if len(artifacts) != 1 {
+		return nil, fmt.Errorf("%s matches %d artifacts. Use the fingerprint of the artifact instead", expression, len(artifacts))
+	}
+	if artifacts[0].Flow == "" {
+		artifacts[0].Flow = flowName
+	}
+	return artifacts[0], nil
+}
+
+// releasedArtifacts returns the artifacts of the flow of to that were created from the released commits,
+// newest first. The artifacts of the flow are listed until from is found.
+func releasedArtifacts(from, to *ReleaseArtifact, commits []*gitview.CommitInfo) ([]*ReleaseArtifact, error) {
+	released := make(map[string]bool)
+	for _, commit := range commits {
+		released[commit.Sha1] = true
+	}
+
+	artifacts := []*ReleaseArtifact{}
+	perPage := 50
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s?page=%d&per_page=%d",
+			global.Host, global.Org, to.Flow, page, perPage)
+		reqParams := &requests.RequestParams{
+			Method:   http.MethodGet,
+			URL:      url,
+			Password: global.ApiToken,
+		}
+		response, err := kosliClient.Do(reqParams)
+		if err != nil {
+			return artifacts, err
+		}
+		var pageArtifacts []*ReleaseArtifact
+		err = json.Unmarshal([]byte(response.Body), &pageArtifacts)
+		if err != nil {
+			return artifacts, err
+		}
+		for _, artifact := range pageArtifacts {
+			if artifact.Fingerprint == from.Fingerprint && artifact.GitCommit == from.GitCommit {
+				return artifacts, nil
+			}
+			if released[artifact.GitCommit] {
+				if artifact.Flow == "" {
+					artifact.Flow = to.Flow
+				}
+				artifacts = append(artifacts, artifact)
+			}
+		}
+		if len(pageArtifacts) < perPage {
+			return artifacts, nil
+		}
+	}
+}
+
+// releasedPullRequests returns the unique pull requests of the released commits,
+// or none if there is no retriever
+func releasedPullRequests(retriever types.PRRetriever, commits []*gitview.CommitInfo) ([]*types.PREvidence, error) {
+	pullRequests := []*types.PREvidence{}
+	if retriever == nil {
+		return pullRequests, nil
+	}
+	seen := make(map[string]bool)
+	for _, commit := range commits {
+		commitPullRequests, err := types.FindPREvidence(retriever, commit.Sha1, 0)
+		if err != nil {
+			return pullRequests, err
+		}
+		for _, pr := range commitPullRequests {
+			if !seen[pr.URL] {
+				seen[pr.URL] = true
+				pullRequests = append(pullRequests, pr)
+			}
+		}
+	}
+	return pullRequests, nil
+}
+
+// releasedIssues returns the Jira issues referenced by the released commits and pull requests,
+// or none if Jira is not configured
+func (o *changelogOptions) releasedIssues(commits []*gitview.CommitInfo, pullRequests []*types.PREvidence) ([]*types.IssueInfo, error) {
+	issues := []*types.IssueInfo{}
+	if !o.withIssues {
+		return issues, nil
+	}
+	tracker := o.newTracker()
+	matcher, err := types.NewIssueKeyMatcher([]string{tracker.IssueKeyPattern()}, o.projectKeys)
+	if err != nil {
+		return issues, err
+	}
+	texts := []string{}
+	for _, commit := range commits {
+		texts = append(texts, commit.Message)
+	}
+	for _, pr := range pullRequests {
+		texts = append(texts, pr.Title, pr.Body)
+	}
+	for _, issueID := range matcher.FindKeys(texts...) {
+		issue, err := tracker.GetIssueInfo(issueID)
+		if err != nil {
+			return issues, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// printReleaseNotesAsMarkdown prints release notes in Markdown
+func printReleaseNotesAsMarkdown(raw string, out io.Writer, page int) error {
+	var notes ReleaseNotes
+	err := json.Unmarshal([]byte(raw), &notes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "# Changes from %s to %s\n\n", markdownArtifact(notes.From), markdownArtifact(notes.To))
+
+	fmt.Fprintf(out, "## Artifacts\n\n")
+	if len(notes.Artifacts) == 0 {
+		fmt.Fprintf(out, "No artifacts were created from these changes.\n\n")
+	} else {
+		fmt.Fprintf(out, "| Artifact | Fingerprint | Git commit | Compliance |\n| --- | --- | --- | --- |\n")
+		for _, artifact := range notes.Artifacts {
+			fmt.Fprintf(out, "| %s | %s | %s | %s |\n", markdownLink(artifact.Name, artifact.URL),
+				shortSHA(artifact.Fingerprint), shortSHA(artifact.GitCommit), artifact.State)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(notes.PullRequests) > 0 {
+		fmt.Fprintf(out, "## Pull requests\n\n")
+		for _, pr := range notes.PullRequests {
+			title := pr.Title
+			if title == "" {
+				title = pr.URL
+			}
+			line := "- " + markdownLink(title, pr.URL)
+			if author := pr.Author.Login; author != "" {
+				line += " by @" + author
+			}
+			fmt.Fprintln(out, line)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(notes.Issues) > 0 {
+		fmt.Fprintf(out, "## Issues\n\n")
+		for _, issue := range notes.Issues {
+			if !issue.IssueExists {
+				fmt.Fprintf(out, "- %s (not found)\n", issue.IssueID)
+				continue
+			}
+			line := fmt.Sprintf("- %s %s", markdownLink(issue.IssueID, issue.IssueURL), issue.Summary)
+			if issue.Status != "" {
+				line += fmt.Sprintf(" (%s)", issue.Status)
+			}
+			fmt.Fprintln(out, strings.TrimSpace(line))
+		}
+		fmt.Fprintln(out)
+	}
+
+	fmt.Fprintf(out, "## Commits\n\n")
+	if len(notes.Commits) == 0 {
+		fmt.Fprintf(out, "No commits.\n")
+	}
+	if notes.CommitsTruncated {
+		fmt.Fprintf(out, "Only the %d commits of the available git history are listed.\n\n", len(notes.Commits))
+	}
+	for _, commit := range notes.Commits {
+		subject := strings.SplitN(commit.Message, "\n", 2)[0]
+		fmt.Fprintf(out, "- `%s` %s (%s)\n", shortSHA(commit.Sha1), subject, commit.Author)
+	}
+	return nil
+}
+
+// markdownArtifact returns the name and short fingerprint of an artifact, linked to the artifact in Kosli
+func markdownArtifact(artifact *ReleaseArtifact) string {
+	return markdownLink(fmt.Sprintf("%s@%s", artifact.Flow, shortSHA(artifact.Fingerprint)), artifact.URL)
+}
+
+// markdownLink returns a Markdown link, or the text alone if there is no URL
+func markdownLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+// shortSHA returns the first 7 characters of a SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
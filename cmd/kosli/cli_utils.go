@@ -43,13 +43,16 @@ var supportedCIs = []string{bitbucket, github, teamcity, gitlab, azureDevops, ci
 var ciTemplates = map[string]map[string]string{
 	github: {
 		"git-commit": "${GITHUB_SHA}",
+		"branch":     "${GITHUB_REF_NAME}",
 		"repository": "${GITHUB_REPOSITORY}",
 		"org":        "${GITHUB_REPOSITORY_OWNER}",
 		"commit-url": "${GITHUB_SERVER_URL}/${GITHUB_REPOSITORY}/commit/${GITHUB_SHA}",
 		"build-url":  "${GITHUB_SERVER_URL}/${GITHUB_REPOSITORY}/actions/runs/${GITHUB_RUN_ID}",
+		"approver":   "${GITHUB_ACTOR}",
 	},
 	bitbucket: {
 		"git-commit": "${BITBUCKET_COMMIT}",
+		"branch":     "${BITBUCKET_BRANCH}",
 		"repository": "${BITBUCKET_REPO_SLUG}",
 		"workspace":  "${BITBUCKET_WORKSPACE}",
 		"commit-url": "https://bitbucket.org/${BITBUCKET_WORKSPACE}/${BITBUCKET_REPO_SLUG}/commits/${BITBUCKET_COMMIT}",
@@ -60,24 +63,30 @@ var ciTemplates = map[string]map[string]string{
 	},
 	gitlab: {
 		"git-commit": "${CI_COMMIT_SHA}",
+		"branch":     "${CI_COMMIT_REF_NAME}",
 		"repository": "${CI_PROJECT_NAME}",
 		"build-url":  "${CI_JOB_URL}",
 		"commit-url": "${CI_PROJECT_URL}/-/commit/${CI_COMMIT_SHA}",
 		"namespace":  "${CI_PROJECT_NAMESPACE}",
+		"approver":   "${GITLAB_USER_LOGIN}",
 	},
 	azureDevops: {
 		"git-commit": "${BUILD_SOURCEVERSION}",
+		"branch":     "${BUILD_SOURCEBRANCH}",
 		"repository": "${BUILD_REPOSITORY_NAME}",
 		"build-url":  "${SYSTEM_COLLECTIONURI}${SYSTEM_TEAMPROJECT}/_build/results?buildId=${BUILD_BUILDID}",
 		"commit-url": "${SYSTEM_COLLECTIONURI}${SYSTEM_TEAMPROJECT}/_git/${BUILD_REPOSITORY_NAME}/commit/${BUILD_SOURCEVERSION}",
 		"org-url":    "${SYSTEM_COLLECTIONURI}",
 		"project":    "${SYSTEM_TEAMPROJECT}",
+		"approver":   "${BUILD_REQUESTEDFOREMAIL}",
 	},
 	circleci: {
 		"git-commit": "${CIRCLE_SHA1}",
+		"branch":     "${CIRCLE_BRANCH}",
 		"repository": "${CIRCLE_PROJECT_REPONAME}",
 		"commit-url": "${CIRCLE_REPOSITORY_URL}/commit/${CIRCLE_SHA1}",
 		"build-url":  "${CIRCLE_BUILD_URL}",
+		"approver":   "${CIRCLE_USERNAME}",
 	},
 }
 
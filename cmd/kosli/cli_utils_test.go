@@ -95,6 +95,16 @@ func (suite *CliUtilsTestSuite) TestDefaultValue() {
 			},
 			want: "https://bitbucket.org/example_space/example_slug/commits/example_commit",
 		},
+		{
+			name: "Lookup the approver default for Gitlab.",
+			args: args{
+				ci:               gitlab,
+				flag:             "approver",
+				envVars:          map[string]string{"GITLAB_USER_LOGIN": "alice"},
+				unsetTestsEnvVar: true,
+			},
+			want: "alice",
+		},
 		{
 			name: "Lookup an existing default for Teamcity.",
 			args: args{
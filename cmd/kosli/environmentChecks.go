@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// environmentCheck is a check of the artifacts running in an environment snapshot.
+// New checks implement it and are added to the checks of 'kosli assert environment'.
+type environmentCheck interface {
+	// name is the name of the check in the failure report
+	name() string
+	// check returns why the artifact fails the check, or an empty string if it passes
+	check(artifact *Artifact) string
+}
+
+// EnvironmentCheckFailure is a failed check of an artifact running in an environment
+type EnvironmentCheckFailure struct {
+	Artifact    string `json:"artifact"`
+	Fingerprint string `json:"fingerprint"`
+	Check       string `json:"check"`
+	Reason      string `json:"reason"`
+}
+
+// runningArtifacts returns the artifacts that are running in the snapshot,
+// as the snapshot also lists the artifacts that exited since the previous snapshot
+func runningArtifacts(snapshot *Snapshot) []*Artifact {
+	running := []*Artifact{}
+	for i := range snapshot.Artifacts {
+		if snapshot.Artifacts[i].Annotation.Now != 0 {
+			running = append(running, &snapshot.Artifacts[i])
+		}
+	}
+	return running
+}
+
+// requireRunningArtifacts returns an error if no artifacts are running in the snapshot,
+// as the checks would otherwise pass without checking anything
+func requireRunningArtifacts(snapshot *Snapshot, expression string) error {
+	if len(runningArtifacts(snapshot)) == 0 {
+		return fmt.Errorf("no artifacts are running in %s", expression)
+	}
+	return nil
+}
+
+// checkEnvironment runs the checks on each artifact running in the snapshot
+// and returns the failures, ordered by artifact
+func checkEnvironment(snapshot *Snapshot, checks []environmentCheck) []*EnvironmentCheckFailure {
+	failures := []*EnvironmentCheckFailure{}
+	for _, artifact := range runningArtifacts(snapshot) {
+		for _, c := range checks {
+			if reason := c.check(artifact); reason != "" {
+				failures = append(failures, &EnvironmentCheckFailure{
+					Artifact:    artifact.Name,
+					Fingerprint: artifact.Fingerprint,
+					Check:       c.name(),
+					Reason:      reason,
+				})
+			}
+		}
+	}
+	return failures
+}
+
+// complianceCheck checks that the artifacts are compliant
+type complianceCheck struct{}
+
+func (complianceCheck) name() string {
+	return "compliance"
+}
+
+func (complianceCheck) check(artifact *Artifact) string {
+	if !artifact.Compliant {
+		return "the artifact is not compliant"
+	}
+	return ""
+}
+
+// provenanceCheck checks that the artifacts were reported to a Kosli flow,
+// which records where they were built from
+type provenanceCheck struct{}
+
+func (provenanceCheck) name() string {
+	return "provenance"
+}
+
+func (provenanceCheck) check(artifact *Artifact) string {
+	if artifact.FlowName == "" {
+		return "the artifact has no provenance: it was not reported to any Kosli flow"
+	}
+	return ""
+}
+
+// maxRunningCheck checks that the artifacts have not been running for longer than maxRunning,
+// from the creation of their oldest running replica
+type maxRunningCheck struct {
+	maxRunning time.Duration
+	now        time.Time
+}
+
+func (maxRunningCheck) name() string {
+	return "max-running"
+}
+
+func (c maxRunningCheck) check(artifact *Artifact) string {
+	if len(artifact.CreationTimestamp) == 0 {
+		return ""
+	}
+	since := artifact.CreationTimestamp[0]
+	for _, timestamp := range artifact.CreationTimestamp {
+		if timestamp < since {
+			since = timestamp
+		}
+	}
+	running := c.now.Sub(time.Unix(since, 0))
+	if running > c.maxRunning {
+		return fmt.Sprintf("the artifact has been running for %d days, more than %d days",
+			int(running.Hours()/24), int(c.maxRunning.Hours()/24))
+	}
+	return ""
+}
+
+// maxAgeCheck checks that the artifacts are not older than maxAge,
+// from their creation in their Kosli flow
+type maxAgeCheck struct {
+	maxAge time.Duration
+	now    time.Time
+	// createdAt are the creation timestamps of the artifacts in their flows, by fingerprint
+	createdAt map[string]int64
+}
+
+func (maxAgeCheck) name() string {
+	return "max-age"
+}
+
+func (c maxAgeCheck) check(artifact *Artifact) string {
+	if artifact.FlowName == "" {
+		return "the age of the artifact is unknown: it was not reported to any Kosli flow"
+	}
+	createdAt, ok := c.createdAt[artifact.Fingerprint]
+	if !ok {
+		return fmt.Sprintf("the age of the artifact is unknown: it was not found in flow %s", artifact.FlowName)
+	}
+	age := c.now.Sub(time.Unix(createdAt, 0))
+	if age > c.maxAge {
+		return fmt.Sprintf("the artifact was created %d days ago in flow %s, more than %d days",
+			int(age.Hours()/24), artifact.FlowName, int(c.maxAge.Hours()/24))
+	}
+	return ""
+}
+
+// replicasCheck checks that the artifacts run the expected number of replicas
+type replicasCheck struct {
+	replicas int
+}
+
+func (replicasCheck) name() string {
+	return "replicas"
+}
+
+func (c replicasCheck) check(artifact *Artifact) string {
+	if replicas := len(artifact.CreationTimestamp); replicas != c.replicas {
+		return fmt.Sprintf("the artifact runs %d replicas, expected %d", replicas, c.replicas)
+	}
+	return ""
+}
+
+// subsetCheck checks that the artifacts also run in another environment,
+// e.g. that everything running in prod was running in staging
+type subsetCheck struct {
+	environment  string
+	fingerprints map[string]bool
+}
+
+// newSubsetCheck returns a subsetCheck of the artifacts running in the snapshot of another environment
+func newSubsetCheck(environment string, snapshot *Snapshot) *subsetCheck {
+	fingerprints := make(map[string]bool)
+	for _, artifact := range runningArtifacts(snapshot) {
+		fingerprints[artifact.Fingerprint] = true
+	}
+	return &subsetCheck{environment: environment, fingerprints: fingerprints}
+}
+
+func (*subsetCheck) name() string {
+	return "subset-of"
+}
+
+func (c *subsetCheck) check(artifact *Artifact) string {
+	if !c.fingerprints[artifact.Fingerprint] {
+		return fmt.Sprintf("the artifact is not running in %s", c.environment)
+	}
+	return ""
+}
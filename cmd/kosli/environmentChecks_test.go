@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEnvironment(t *testing.T) {
+	now := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return now.Add(-time.Duration(days) * 24 * time.Hour).Unix()
+	}
+	snapshot := &Snapshot{
+		Type: "K8S",
+		Artifacts: []Artifact{
+			{
+				Name: "backend:1.2", Fingerprint: "8e568bd886069f12", FlowName: "backend", Compliant: true,
+				CreationTimestamp: []int64{daysAgo(2), daysAgo(1)}, Annotation: Annotation{Type: "started", Now: 2},
+			},
+			{
+				Name: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Compliant: false,
+				CreationTimestamp: []int64{daysAgo(45)}, Annotation: Annotation{Type: "unchanged", Was: 1, Now: 1},
+			},
+			{
+				Name: "backend:1.1", Fingerprint: "110d048bf1fce72b", FlowName: "backend", Compliant: false,
+				CreationTimestamp: []int64{daysAgo(60)}, Annotation: Annotation{Type: "exited", Was: 1, Now: 0},
+			},
+		},
+	}
+	staging := &Snapshot{
+		Type: "K8S",
+		Artifacts: []Artifact{
+			{Name: "backend:1.2", Fingerprint: "8e568bd886069f12", Annotation: Annotation{Now: 1}},
+			{Name: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Annotation: Annotation{Was: 1, Now: 0}},
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		checks           []environmentCheck
+		expectedFailures []*EnvironmentCheckFailure
+	}{
+		{
+			name:   "exited artifacts are not checked",
+			checks: []environmentCheck{complianceCheck{}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "compliance", Reason: "the artifact is not compliant"},
+			},
+		},
+		{
+			name:   "artifacts without a flow have no provenance",
+			checks: []environmentCheck{provenanceCheck{}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "provenance", Reason: "the artifact has no provenance: it was not reported to any Kosli flow"},
+			},
+		},
+		{
+			name:   "artifacts running for longer than the max running time fail, from their oldest replica",
+			checks: []environmentCheck{maxRunningCheck{maxRunning: 30 * 24 * time.Hour, now: now}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "max-running", Reason: "the artifact has been running for 45 days, more than 30 days"},
+			},
+		},
+		{
+			name: "artifacts older than the max age in their flow fail, and artifacts without a flow have no age",
+			checks: []environmentCheck{maxAgeCheck{maxAge: 30 * 24 * time.Hour, now: now, createdAt: map[string]int64{
+				"8e568bd886069f12": daysAgo(40),
+			}}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "backend:1.2", Fingerprint: "8e568bd886069f12", Check: "max-age", Reason: "the artifact was created 40 days ago in flow backend, more than 30 days"},
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "max-age", Reason: "the age of the artifact is unknown: it was not reported to any Kosli flow"},
+			},
+		},
+		{
+			name:   "artifacts with another number of replicas fail",
+			checks: []environmentCheck{replicasCheck{replicas: 2}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "replicas", Reason: "the artifact runs 1 replicas, expected 2"},
+			},
+		},
+		{
+			name:   "artifacts that are not running in the other environment fail",
+			checks: []environmentCheck{newSubsetCheck("staging", staging)},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "subset-of", Reason: "the artifact is not running in staging"},
+			},
+		},
+		{
+			name:   "failures are ordered by artifact, then by check",
+			checks: []environmentCheck{provenanceCheck{}, replicasCheck{replicas: 1}},
+			expectedFailures: []*EnvironmentCheckFailure{
+				{Artifact: "backend:1.2", Fingerprint: "8e568bd886069f12", Check: "replicas", Reason: "the artifact runs 2 replicas, expected 1"},
+				{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "provenance", Reason: "the artifact has no provenance: it was not reported to any Kosli flow"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedFailures, checkEnvironment(snapshot, tt.checks))
+		})
+	}
+}
+
+func TestRequireRunningArtifacts(t *testing.T) {
+	snapshot := &Snapshot{Artifacts: []Artifact{
+		{Name: "backend:1.1", Fingerprint: "110d048bf1fce72b", Annotation: Annotation{Type: "exited", Was: 1, Now: 0}},
+	}}
+	require.EqualError(t, requireRunningArtifacts(snapshot, "prod"), "no artifacts are running in prod")
+	require.EqualError(t, requireRunningArtifacts(&Snapshot{}, "prod#3"), "no artifacts are running in prod#3")
+
+	snapshot.Artifacts = append(snapshot.Artifacts, Artifact{Name: "backend:1.2", Annotation: Annotation{Type: "started", Now: 1}})
+	require.NoError(t, requireRunningArtifacts(snapshot, "prod"))
+}
+
+func TestPrintEnvironmentCheckFailuresAsTable(t *testing.T) {
+	raw, err := json.Marshal([]*EnvironmentCheckFailure{
+		{Artifact: "nginx:1.21", Fingerprint: "184c799cd551dd1d", Check: "compliance", Reason: "the artifact is not compliant"},
+	})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	err = printEnvironmentCheckFailuresAsTable(string(raw), out, 0)
+	require.NoError(t, err)
+	require.Equal(t, "ARTIFACT    FINGERPRINT  CHECK       REASON\n"+
+		"nginx:1.21  184c799      compliance  the artifact is not compliant\n", out.String())
+}
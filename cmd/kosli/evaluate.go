@@ -0,0 +1,24 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const evaluateDesc = `All Kosli policy evaluation commands. Return non-zero exit code if a policy rule fails.`
+
+func newEvaluateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evaluate",
+		Short: evaluateDesc,
+		Long:  evaluateDesc,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(
+		newEvaluateArtifactCmd(out),
+	)
+
+	return cmd
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kosli-dev/cli/internal/output"
+	"github.com/kosli-dev/cli/internal/policy"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+const evaluateArtifactShortDesc = `Evaluate the rules of a local policy file against an artifact in Kosli.  `
+
+const evaluateArtifactLongDesc = evaluateArtifactShortDesc + `
+The policy file is a YAML file with a list of rules. Each rule has a name, a CEL expression
+(https://github.com/google/cel-spec) and an optional message explaining why the rule fails:
+
+	rules:
+	  - name: compliant
+	    expression: artifact.state == "COMPLIANT"
+	  - name: not running in prod
+	    expression: '!artifact.running.exists(e, e.environment_name == "prod")'
+	  - name: no Friday deployments
+	    expression: now.getDayOfWeek("Europe/Oslo") != 5
+	    message: we do not deploy on Fridays
+
+The expressions refer to the artifact as ^artifact^, which has the fields of the artifact
+as returned by ^kosli get artifact --output json^, and to the time of the evaluation as ^now^.
+A rule fails if its expression is false, or cannot be evaluated, e.g. because the artifact does not have a field.
+
+The result of each rule is printed. Exits with non-zero code if a rule fails.
+` + fingerprintDesc
+
+const evaluateArtifactExample = `
+# evaluate a policy against an artifact (using the artifact fingerprint)
+kosli evaluate artifact \
+	--fingerprint 184c799cd551dd1d8d5c5f9a5d593b2e931f5e36122ee5c793c1d08a19839cc0 \
+	--policy policy.yaml \
+	--flow yourFlowName \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# evaluate a policy against an artifact (using the artifact name and type)
+# and print the results in JSON
+kosli evaluate artifact library/nginx:1.21 \
+	--artifact-type docker \
+	--policy policy.yaml \
+	--output json \
+	--flow yourFlowName \
+	--api-token yourAPIToken \
+	--org yourOrgName
+`
+
+type evaluateArtifactOptions struct {
+	fingerprintOptions *fingerprintOptions
+	fingerprint        string // This is calculated or provided by the user
+	flowName           string
+	policyFile         string
+	output             string
+}
+
+func newEvaluateArtifactCmd(out io.Writer) *cobra.Command {
+	o := new(evaluateArtifactOptions)
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "artifact [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   evaluateArtifactShortDesc,
+		Long:    evaluateArtifactLongDesc,
+		Example: evaluateArtifactExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.fingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(out, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.fingerprint, "fingerprint", "F", "", fingerprintFlag)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	cmd.Flags().StringVarP(&o.policyFile, "policy", "p", "", policyFileFlag)
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", outputFlag)
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"flow", "policy"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *evaluateArtifactOptions) run(out io.Writer, args []string) error {
+	rules, err := policy.Load(o.policyFile)
+	if err != nil {
+		return err
+	}
+
+	if o.fingerprint == "" {
+		o.fingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/api/v2/artifacts/%s/%s/fingerprint/%s", global.Host, global.Org, o.flowName, o.fingerprint)
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodGet,
+		URL:      url,
+		Password: global.ApiToken,
+	}
+	response, err := kosliClient.Do(reqParams)
+	if err != nil {
+		return err
+	}
+
+	var artifactData map[string]interface{}
+	err = json.Unmarshal([]byte(response.Body), &artifactData)
+	if err != nil {
+		return err
+	}
+
+	results, err := rules.Evaluate("artifact", artifactData, time.Now())
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	err = output.FormattedPrint(string(raw), o.output, out, 0,
+		map[string]output.FormatOutputFunc{
+			"table": printPolicyResultsAsTable,
+			"json":  output.PrintJson,
+		})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d policy rules failed for artifact with fingerprint %s", failed, len(results), o.fingerprint)
+	}
+	return nil
+}
+
+func printPolicyResultsAsTable(raw string, out io.Writer, page int) error {
+	var results []*policy.Result
+	err := json.Unmarshal([]byte(raw), &results)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"RULE", "RESULT", "MESSAGE"}
+	rows := []string{}
+	for _, result := range results {
+		outcome := "PASSED"
+		if !result.Passed {
+			outcome = "FAILED"
+		}
+		rows = append(rows, fmt.Sprintf("%s\t%s\t%s", result.Rule, outcome, result.Message))
+	}
+	tabFormattedPrint(out, header, rows)
+	return nil
+}
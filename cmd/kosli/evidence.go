@@ -1,8 +1,13 @@
 package main
 
 import (
-	"github.com/kosli-dev/cli/internal/jira"
+	"fmt"
+
+	"github.com/kosli-dev/cli/internal/coverage"
+	"github.com/kosli-dev/cli/internal/gitview"
 	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/kosli-dev/cli/internal/vulnscan"
 )
 
 type TypedEvidencePayload struct {
@@ -24,7 +29,85 @@ type GenericEvidencePayload struct {
 
 type JiraEvidencePayload struct {
 	TypedEvidencePayload
-	JiraResults []*jira.JiraIssueInfo `json:"jira_results"`
+	JiraResults []*types.IssueInfo `json:"jira_results"`
+	Violations  []string           `json:"violations,omitempty"`
+}
+
+type IssueEvidencePayload struct {
+	TypedEvidencePayload
+	IssueTracker string             `json:"issue_tracker"`
+	Issues       []*types.IssueInfo `json:"issues"`
+	Violations   []string           `json:"violations,omitempty"`
+}
+
+// IssueCommitReferences are the issues referenced by the commits of an artifact
+type IssueCommitReferences struct {
+	Commits              []*CommitIssueReferences `json:"commits"`
+	CommitsWithoutIssues []string                 `json:"commits_without_issues"`
+}
+
+// CommitIssueReferences are the issues referenced by a commit
+type CommitIssueReferences struct {
+	CommitSHA string   `json:"commit_sha"`
+	IssueIDs  []string `json:"issue_ids"`
+}
+
+type ArtifactJiraEvidencePayload struct {
+	JiraEvidencePayload
+	IssueCommitReferences
+}
+
+type ArtifactIssueEvidencePayload struct {
+	IssueEvidencePayload
+	IssueCommitReferences
+}
+
+type SignatureEvidencePayload struct {
+	TypedEvidencePayload
+	Signatures       []*gitview.CommitSignature `json:"signatures"`
+	UnsignedCommits  []string                   `json:"unsigned_commits"`
+	UntrustedCommits []string                   `json:"untrusted_commits"`
+	Compliant        bool                       `json:"is_compliant"`
+}
+
+type EvidenceVulnerabilityScanPayload struct {
+	GenericEvidencePayload
+	ScanSummary *vulnscan.Summary `json:"scan_summary"`
+	Violations  []string          `json:"violations"`
+}
+
+type vulnerabilityPolicyOptions struct {
+	failOnSeverity string
+	ignoreUnfixed  bool
+}
+
+// vulnerabilityScanner is a scanner whose JSON reports are reported by the
+// 'report evidence artifact|commit <scanner>' commands
+type vulnerabilityScanner struct {
+	// name is the name of the scanner commands
+	name string
+	// displayName is the name of the scanner in help texts
+	displayName string
+	// resultsFileFlag is the description of the --scan-results flag
+	resultsFileFlag string
+	// parse parses a JSON report of the scanner
+	parse func(path string) (*vulnscan.Summary, error)
+}
+
+var (
+	trivyScanner = &vulnerabilityScanner{name: "trivy", displayName: "Trivy", resultsFileFlag: trivyResultsFileFlag, parse: vulnscan.ParseTrivyFile}
+	grypeScanner = &vulnerabilityScanner{name: "grype", displayName: "Grype", resultsFileFlag: grypeResultsFileFlag, parse: vulnscan.ParseGrypeFile}
+)
+
+type EvidenceCoveragePayload struct {
+	GenericEvidencePayload
+	CoverageSummary *coverage.Summary `json:"coverage_summary"`
+	Violations      []string          `json:"violations"`
+}
+
+type coverageThresholdOptions struct {
+	minLineCoverage   float64
+	minBranchCoverage float64
 }
 
 type WorkflowEvidencePayload struct {
@@ -59,3 +142,46 @@ func newEvidenceForm(payload interface{}, evidencePaths []string) (
 
 	return form, cleanupNeeded, evidencePath, nil
 }
+
+// evaluate sets the scan summary and the compliance of a vulnerability scan evidence payload
+func (o *vulnerabilityPolicyOptions) evaluate(payload *EvidenceVulnerabilityScanPayload, summary *vulnscan.Summary) {
+	payload.ScanSummary = summary
+	payload.Compliant, payload.Violations = summary.Evaluate(vulnscan.Policy{
+		FailOnSeverity: o.failOnSeverity,
+		IgnoreUnfixed:  o.ignoreUnfixed,
+	})
+	payload.Description = fmt.Sprintf("%s scan of %s: %d vulnerabilities (%d fixable, %d unfixable)",
+		summary.Scanner, summary.Target, summary.Total, summary.Fixable, summary.Unfixable)
+	for _, violation := range payload.Violations {
+		logger.Warning("%s scan evidence is non-compliant: %s", summary.Scanner, violation)
+	}
+}
+
+// warnOnImageDigestMismatch logs a warning if the scanned image is not the artifact the evidence is reported to
+func warnOnImageDigestMismatch(summary *vulnscan.Summary, fingerprint string) {
+	if summary.ImageDigest != "" && !summary.MatchesDigest(fingerprint) {
+		logger.Warning("the scanned image digest '%s' does not match the artifact fingerprint: %s",
+			summary.ImageDigest, fingerprint)
+	}
+}
+
+// evaluate parses the coverage reports and sets the summary and the compliance of a coverage evidence payload
+func (o *coverageThresholdOptions) evaluate(payload *EvidenceCoveragePayload, coverageFilePaths []string) error {
+	summary, err := coverage.ParseFiles(coverageFilePaths)
+	if err != nil {
+		return err
+	}
+	payload.CoverageSummary = summary
+	payload.Compliant, payload.Violations = summary.Evaluate(coverage.Thresholds{
+		MinLineCoverage:   o.minLineCoverage,
+		MinBranchCoverage: o.minBranchCoverage,
+	})
+	payload.Description = fmt.Sprintf("line coverage: %.2f%%", summary.LineCoverage)
+	if summary.BranchCoverage != nil {
+		payload.Description += fmt.Sprintf(", branch coverage: %.2f%%", *summary.BranchCoverage)
+	}
+	for _, violation := range payload.Violations {
+		logger.Warning("coverage evidence is non-compliant: %s", violation)
+	}
+	return nil
+}
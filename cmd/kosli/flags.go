@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/kosli-dev/cli/internal/aws"
 	azUtils "github.com/kosli-dev/cli/internal/azure"
 	bbUtils "github.com/kosli-dev/cli/internal/bitbucket"
+	giteaUtils "github.com/kosli-dev/cli/internal/gitea"
 	ghUtils "github.com/kosli-dev/cli/internal/github"
 	gitlabUtils "github.com/kosli-dev/cli/internal/gitlab"
 	"github.com/spf13/cobra"
@@ -30,6 +33,8 @@ func addDryRunFlag(cmd *cobra.Command) {
 func addBitbucketFlags(cmd *cobra.Command, bbConfig *bbUtils.Config, ci string) {
 	cmd.Flags().StringVar(&bbConfig.Username, "bitbucket-username", "", bbUsernameFlag)
 	cmd.Flags().StringVar(&bbConfig.Password, "bitbucket-password", "", bbPasswordFlag)
+	cmd.Flags().StringVar(&bbConfig.AccessToken, "bitbucket-access-token", "", bbAccessTokenFlag)
+	cmd.Flags().StringVar(&bbConfig.BaseURL, "bitbucket-base-url", "", bbBaseURLFlag)
 	cmd.Flags().StringVar(&bbConfig.Workspace, "bitbucket-workspace", DefaultValue(ci, "workspace"), bbWorkspaceFlag)
 	cmd.Flags().StringVar(&bbConfig.Repository, "repository", DefaultValue(ci, "repository"), repositoryFlag)
 }
@@ -55,12 +60,21 @@ func addGitlabFlags(cmd *cobra.Command, gitlabConfig *gitlabUtils.GitlabConfig,
 	cmd.Flags().StringVar(&gitlabConfig.Repository, "repository", DefaultValue(ci, "repository"), repositoryFlag)
 }
 
+func addGiteaFlags(cmd *cobra.Command, giteaConfig *giteaUtils.GiteaConfig, ci string) {
+	cmd.Flags().StringVar(&giteaConfig.Token, "gitea-token", "", giteaTokenFlag)
+	cmd.Flags().StringVar(&giteaConfig.Org, "gitea-org", "", giteaOrgFlag)
+	cmd.Flags().StringVar(&giteaConfig.BaseURL, "gitea-base-url", "", giteaBaseURLFlag)
+	cmd.Flags().StringVar(&giteaConfig.Repository, "repository", DefaultValue(ci, "repository"), repositoryFlag)
+}
+
 func addArtifactPRFlags(cmd *cobra.Command, o *pullRequestArtifactOptions, ci string) {
 	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
 	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
 	cmd.Flags().StringVar(&o.commit, "commit", DefaultValue(ci, "git-commit"), commitPREvidenceFlag)
 	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
 	cmd.Flags().BoolVar(&o.assert, "assert", false, assertPREvidenceFlag)
+	cmd.Flags().BoolVar(&o.requireFourEyes, "require-four-eyes", false, requireFourEyesFlag)
+	cmd.Flags().IntVar(&o.prNumber, "pr-number", 0, fmt.Sprintf(prNumberFlag, o.provider.Label()))
 }
 
 func addArtifactEvidenceFlags(cmd *cobra.Command, payload *TypedEvidencePayload, ci string) {
@@ -72,6 +86,14 @@ func addCommitPRFlags(cmd *cobra.Command, o *pullRequestCommitOptions, ci string
 	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
 	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
 	cmd.Flags().BoolVar(&o.assert, "assert", false, assertPREvidenceFlag)
+	cmd.Flags().BoolVar(&o.requireFourEyes, "require-four-eyes", false, requireFourEyesFlag)
+	cmd.Flags().IntVar(&o.prNumber, "pr-number", 0, fmt.Sprintf(prNumberFlag, o.provider.Label()))
+}
+
+func addCommitBranchProtectionFlags(cmd *cobra.Command, o *branchProtectionCommitOptions, ci string) {
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVar(&o.branch, "branch", DefaultValue(ci, "branch"), branchProtectionBranchFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
 }
 
 func addCommitEvidenceFlags(cmd *cobra.Command, payload *TypedEvidencePayload, ci string) {
@@ -87,6 +109,23 @@ func addEvidenceFlags(cmd *cobra.Command, payload *TypedEvidencePayload, ci stri
 	cmd.Flags().StringVar(&payload.EvidenceURL, "evidence-url", "", evidenceURLFlag)
 }
 
+func addVulnerabilityPolicyFlags(cmd *cobra.Command, o *vulnerabilityPolicyOptions) {
+	cmd.Flags().StringVar(&o.failOnSeverity, "fail-on-severity", "", failOnSeverityFlag)
+	cmd.Flags().BoolVar(&o.ignoreUnfixed, "ignore-unfixed", false, ignoreUnfixedFlag)
+}
+
+func addCoverageThresholdFlags(cmd *cobra.Command, o *coverageThresholdOptions) {
+	cmd.Flags().Float64Var(&o.minLineCoverage, "min-coverage", 0, minCoverageFlag)
+	cmd.Flags().Float64Var(&o.minBranchCoverage, "min-branch-coverage", 0, minBranchCoverageFlag)
+}
+
+func addJunitResultsFlags(cmd *cobra.Command, o *junitResultsOptions) {
+	cmd.Flags().StringVarP(&o.testResultsDir, "results-dir", "R", ".", resultsDirFlag)
+	cmd.Flags().StringSliceVar(&o.testResultsFiles, "results-files", []string{}, resultsFilesFlag)
+	cmd.Flags().BoolVar(&o.includeFailures, "include-failures", false, includeFailuresFlag)
+	cmd.Flags().BoolVar(&o.dedupeRetries, "dedupe-retries", false, dedupeRetriesFlag)
+}
+
 func addListFlags(cmd *cobra.Command, o *listOptions) {
 	cmd.Flags().StringVarP(&o.output, "output", "o", "table", outputFlag)
 	cmd.Flags().IntVar(&o.pageNumber, "page", 1, pageNumberFlag)
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	azUtils "github.com/kosli-dev/cli/internal/azure"
+	bbUtils "github.com/kosli-dev/cli/internal/bitbucket"
+	giteaUtils "github.com/kosli-dev/cli/internal/gitea"
+	ghUtils "github.com/kosli-dev/cli/internal/github"
+	gitlabUtils "github.com/kosli-dev/cli/internal/gitlab"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// gitProvider is a git provider that can find the pull requests of a commit.
+// Each registered provider gets an 'assert pullrequest' and a
+// 'report evidence artifact|commit pullrequest' command, and a
+// 'report evidence commit branch-protection' command if it has BranchProtectionHelp.
+type gitProvider interface {
+	// Name is the name of the provider commands and the git_provider reported to Kosli
+	Name() string
+	// DisplayName is the name of the provider in help texts and messages
+	DisplayName() string
+	Aliases() []string
+	// Label is what the provider calls a pull request, e.g. "merge request"
+	Label() string
+	// AddFlags adds the provider flags to a command, and returns the function that
+	// creates the retriever from the flag values once they are parsed
+	AddFlags(cmd *cobra.Command, ci string) func() types.PRRetriever
+	// RequiredFlags are the provider flags that must be set
+	RequiredFlags() []string
+	// ExampleFlags are the provider flags and values used in the command examples
+	ExampleFlags() []string
+	// OnPremExampleFlags are the extra provider flags and values used in the command examples
+	// for a self-hosted server of the provider, or nil if there is no such example
+	OnPremExampleFlags() []string
+	// ValidateFlags returns an error if the provider flags of a command are not a valid combination
+	ValidateFlags(cmd *cobra.Command) error
+	// BranchProtectionHelp describes how the provider branch protection is read, for the help of
+	// its branch-protection command, or is empty if the provider has no such command.
+	// The retriever of such a provider must also be a types.BranchProtectionRetriever.
+	BranchProtectionHelp() string
+}
+
+// prProvider is a gitProvider defined by its values
+type prProvider struct {
+	name          string
+	displayName   string
+	aliases       []string
+	label         string
+	requiredFlags []string
+	exampleFlags  []string
+	// onPremExampleFlags are the flags added to exampleFlags in the example for a self-hosted server
+	onPremExampleFlags []string
+	// branchProtectionHelp is the end of the long help of the branch-protection command
+	branchProtectionHelp string
+	addFlags             func(cmd *cobra.Command, ci string) func() types.PRRetriever
+	validateFlags        func(cmd *cobra.Command) error
+}
+
+func (p *prProvider) Name() string                 { return p.name }
+func (p *prProvider) DisplayName() string          { return p.displayName }
+func (p *prProvider) Aliases() []string            { return p.aliases }
+func (p *prProvider) Label() string                { return p.label }
+func (p *prProvider) RequiredFlags() []string      { return p.requiredFlags }
+func (p *prProvider) ExampleFlags() []string       { return p.exampleFlags }
+func (p *prProvider) OnPremExampleFlags() []string { return p.onPremExampleFlags }
+func (p *prProvider) BranchProtectionHelp() string { return p.branchProtectionHelp }
+func (p *prProvider) ValidateFlags(cmd *cobra.Command) error {
+	if p.validateFlags == nil {
+		return nil
+	}
+	return p.validateFlags(cmd)
+}
+func (p *prProvider) AddFlags(cmd *cobra.Command, ci string) func() types.PRRetriever {
+	return p.addFlags(cmd, ci)
+}
+
+// gitProviders are the providers of the pull request commands
+var gitProviders = []gitProvider{}
+
+// registerGitProvider adds a provider to the pull request commands.
+// It must be called before the commands are created, e.g. from an init function.
+func registerGitProvider(provider gitProvider) {
+	gitProviders = append(gitProviders, provider)
+}
+
+func init() {
+	registerGitProvider(&prProvider{
+		name:          "bitbucket",
+		displayName:   "Bitbucket",
+		aliases:       []string{"bb"},
+		label:         "pull request",
+		requiredFlags: []string{"bitbucket-workspace", "repository"},
+		exampleFlags: []string{
+			"--bitbucket-username yourBitbucketUsername",
+			"--bitbucket-password yourBitbucketPassword",
+			"--bitbucket-workspace yourBitbucketWorkspace",
+			"--repository yourBitbucketGitRepository",
+		},
+		branchProtectionHelp: `A branch is protected if at least one branch restriction matches it. Bitbucket does not name the
+required builds, so all the builds of the commit must pass when passing builds are required to merge.
+`,
+		addFlags: func(cmd *cobra.Command, ci string) func() types.PRRetriever {
+			config := new(bbUtils.Config)
+			addBitbucketFlags(cmd, config, ci)
+			return func() types.PRRetriever {
+				config.Logger = logger
+				config.KosliClient = kosliClient
+				return config
+			}
+		},
+		validateFlags: func(cmd *cobra.Command) error {
+			err := MuXRequiredFlags(cmd, []string{"bitbucket-password", "bitbucket-access-token"}, true)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("bitbucket-password") && !cmd.Flags().Changed("bitbucket-username") {
+				return fmt.Errorf("--bitbucket-username is required when using --bitbucket-password")
+			}
+			return nil
+		},
+	})
+	registerGitProvider(&prProvider{
+		name:          "github",
+		displayName:   "Github",
+		aliases:       []string{"gh"},
+		label:         "pull request",
+		requiredFlags: []string{"github-token", "github-org", "repository"},
+		exampleFlags: []string{
+			"--github-token yourGithubToken",
+			"--github-org yourGithubOrg",
+			"--repository yourGithubGitRepository",
+		},
+		branchProtectionHelp: `Required status checks and protection rules are read from the Github branch protection API
+and from the repository rulesets that apply to the branch.
+`,
+		addFlags: func(cmd *cobra.Command, ci string) func() types.PRRetriever {
+			values := new(ghUtils.GithubFlagsTempValueHolder)
+			addGithubFlags(cmd, values, ci)
+			return func() types.PRRetriever {
+				return ghUtils.NewGithubConfig(values.Token, values.BaseURL, values.Org, values.Repository)
+			}
+		},
+	})
+	registerGitProvider(&prProvider{
+		name:          "gitlab",
+		displayName:   "Gitlab",
+		aliases:       []string{"gl"},
+		label:         "merge request",
+		requiredFlags: []string{"gitlab-token", "gitlab-org", "repository"},
+		exampleFlags: []string{
+			"--gitlab-token yourGitlabToken",
+			"--gitlab-org yourGitlabOrg",
+			"--repository yourGitlabGitRepository",
+		},
+		onPremExampleFlags: []string{
+			"--gitlab-base-url https://gitlab.example.org",
+		},
+		branchProtectionHelp: `A branch is protected if it matches at least one protected branch, including wildcard protected
+branches (e.g. release/*). When it matches several, the most restrictive of their rules are reported.
+Required approvals are read from the project's approval rules, and checks are the jobs of the commit's pipelines.
+Jobs that are allowed to fail are not required to pass.
+`,
+		addFlags: func(cmd *cobra.Command, ci string) func() types.PRRetriever {
+			config := new(gitlabUtils.GitlabConfig)
+			addGitlabFlags(cmd, config, ci)
+			return func() types.PRRetriever { return config }
+		},
+	})
+	registerGitProvider(&prProvider{
+		name:          "azure",
+		displayName:   "Azure DevOps",
+		aliases:       []string{"az"},
+		label:         "pull request",
+		requiredFlags: []string{"azure-token", "azure-org-url", "project", "repository"},
+		exampleFlags: []string{
+			"--azure-token yourAzureToken",
+			"--azure-org-url https://dev.azure.com/myOrg",
+			"--project yourAzureDevOpsProject",
+			"--repository yourAzureGitRepository",
+		},
+		branchProtectionHelp: `A branch is protected if it has at least one enabled blocking branch policy. Required checks are
+the build validation and status check policies of the branch.
+Force pushes are controlled by repository permissions and are not reported.
+`,
+		addFlags: func(cmd *cobra.Command, ci string) func() types.PRRetriever {
+			values := new(azUtils.AzureFlagsTempValueHolder)
+			addAzureFlags(cmd, values, ci)
+			return func() types.PRRetriever {
+				return azUtils.NewAzureConfig(values.Token, values.OrgUrl, values.Project, values.Repository)
+			}
+		},
+	})
+	registerGitProvider(&prProvider{
+		name:          "gitea",
+		displayName:   "Gitea",
+		aliases:       []string{"forgejo"},
+		label:         "pull request",
+		requiredFlags: []string{"gitea-token", "gitea-org", "gitea-base-url", "repository"},
+		exampleFlags: []string{
+			"--gitea-base-url https://gitea.example.com",
+			"--gitea-token yourGiteaToken",
+			"--gitea-org yourGiteaOrg",
+			"--repository yourGiteaGitRepository",
+		},
+		addFlags: func(cmd *cobra.Command, ci string) func() types.PRRetriever {
+			config := new(giteaUtils.GiteaConfig)
+			addGiteaFlags(cmd, config, ci)
+			return func() types.PRRetriever { return config }
+		},
+	})
+}
+
+// exampleProviderFlags returns the example flags of a provider, one per line, to be used in command examples
+func exampleProviderFlags(provider gitProvider) string {
+	return "\t" + strings.Join(provider.ExampleFlags(), " \\\n\t") + " \\\n"
+}
+
+// exampleOnPremProviderFlags returns the example flags of a provider for a self-hosted server,
+// one per line, or an empty string if the provider has no such example
+func exampleOnPremProviderFlags(provider gitProvider) string {
+	if len(provider.OnPremExampleFlags()) == 0 {
+		return ""
+	}
+	flags := append([]string{}, provider.OnPremExampleFlags()...)
+	flags = append(flags, provider.ExampleFlags()...)
+	return "\t" + strings.Join(flags, " \\\n\t") + " \\\n"
+}
+
+// withArticle returns the name preceded by its indefinite article, e.g. "a Github" or "an Azure DevOps"
+func withArticle(name string) string {
+	if name != "" && strings.ContainsRune("AEIOUaeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// addGitProviderFlags adds --git-provider, described by description, and the flags of all git providers
+// to a command that can optionally use a git provider.
+// It returns the function that creates the retriever of the selected provider,
+// which is nil when no provider is selected.
+func addGitProviderFlags(cmd *cobra.Command, ci, description string) func() (types.PRRetriever, error) {
+	var providerName string
+	cmd.Flags().StringVar(&providerName, "git-provider", "", description)
+
+	newRetrievers := make(map[string]func() types.PRRetriever)
+	providerFlags := make(map[string]*pflag.FlagSet)
+	for _, provider := range gitProviders {
+		providerCmd := &cobra.Command{}
+		newRetrievers[provider.Name()] = provider.AddFlags(providerCmd, ci)
+		providerFlags[provider.Name()] = providerCmd.Flags()
+		// flags shared by several providers, e.g. --repository, are only added once
+		cmd.Flags().AddFlagSet(providerCmd.Flags())
+	}
+
+	return func() (types.PRRetriever, error) {
+		if providerName == "" {
+			return nil, nil
+		}
+		var provider gitProvider
+		names := []string{}
+		for _, p := range gitProviders {
+			names = append(names, p.Name())
+			if p.Name() == providerName {
+				provider = p
+			}
+		}
+		if provider == nil {
+			return nil, fmt.Errorf("%s is not a valid git provider. Valid git providers are: %v", providerName, names)
+		}
+
+		var err error
+		providerFlags[providerName].VisitAll(func(flag *pflag.Flag) {
+			// copy the values of the shared flags that are bound to another provider
+			if shared := cmd.Flags().Lookup(flag.Name); shared != flag && err == nil {
+				err = flag.Value.Set(shared.Value.String())
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range provider.RequiredFlags() {
+			if providerFlags[providerName].Lookup(name).Value.String() == "" {
+				return nil, fmt.Errorf("--%s is required when using --git-provider %s", name, providerName)
+			}
+		}
+		err = provider.ValidateFlags(cmd)
+		if err != nil {
+			return nil, err
+		}
+		return newRetrievers[providerName](), nil
+	}
+}
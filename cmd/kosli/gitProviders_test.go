@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// Define the suite, and absorb the built-in basic suite
+// functionality from testify - including a T() method which
+// returns the current testing context
+type GitProvidersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *GitProvidersTestSuite) TestEachProviderHasPullRequestCommands() {
+	root, err := newRootCmd(io.Discard, []string{})
+	require.NoError(suite.T(), err)
+	for _, path := range []string{
+		"assert pullrequest",
+		"report evidence artifact pullrequest",
+		"report evidence commit pullrequest",
+	} {
+		suite.Run(path, func() {
+			cmd, _, err := root.Find(strings.Fields(path))
+			require.NoError(suite.T(), err)
+			names := []string{}
+			for _, sub := range cmd.Commands() {
+				names = append(names, sub.Name())
+			}
+			for _, provider := range gitProviders {
+				require.Contains(suite.T(), names, provider.Name())
+			}
+			require.Len(suite.T(), names, len(gitProviders))
+		})
+	}
+}
+
+func (suite *GitProvidersTestSuite) TestProviderNamesAndAliasesAreUnique() {
+	seen := map[string]bool{}
+	for _, provider := range gitProviders {
+		for _, name := range append([]string{provider.Name()}, provider.Aliases()...) {
+			require.False(suite.T(), seen[name], "%s is used by more than one git provider", name)
+			seen[name] = true
+		}
+	}
+}
+
+func (suite *GitProvidersTestSuite) TestPullRequestCommandsHelp() {
+	root, err := newRootCmd(io.Discard, []string{})
+	require.NoError(suite.T(), err)
+
+	cmd, _, err := root.Find([]string{"report", "evidence", "artifact", "pullrequest", "azure"})
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), strings.HasPrefix(cmd.Short, "Report an Azure DevOps pull request evidence"), cmd.Short)
+
+	cmd, _, err = root.Find([]string{"report", "evidence", "artifact", "pullrequest", "gitlab"})
+	require.NoError(suite.T(), err)
+	require.Contains(suite.T(), cmd.Example, "# report a merge request evidence (from an on-prem Gitlab) to kosli for a docker image")
+	require.Contains(suite.T(), cmd.Example, "--gitlab-base-url https://gitlab.example.org")
+	require.True(suite.T(), strings.HasPrefix(cmd.Flags().Lookup("pr-number").Usage, "[optional] The number of the merge request to use"))
+
+	cmd, _, err = root.Find([]string{"assert", "pullrequest", "github"})
+	require.NoError(suite.T(), err)
+	require.Contains(suite.T(), cmd.Flags().Lookup("pr-number").Usage, "recently merged pull requests")
+}
+
+func (suite *GitProvidersTestSuite) TestBitbucketCredentialsFlags() {
+	tests := []cmdTestCase{
+		{
+			wantError: true,
+			name:      "bitbucket PR commands require a password or an access token",
+			cmd:       `assert pullrequest bitbucket --bitbucket-workspace kosli --repository cli --commit 2492011ef04a9da09d35be706cf6a4c5bc6f1e69`,
+			golden:    "Error: at least one of --bitbucket-password, --bitbucket-access-token is required\nUsage: kosli assert pullrequest bitbucket [flags]\n",
+		},
+		{
+			wantError: true,
+			name:      "bitbucket PR commands do not accept both a password and an access token",
+			cmd: `assert pullrequest bitbucket --bitbucket-workspace kosli --repository cli --commit 2492011ef04a9da09d35be706cf6a4c5bc6f1e69
+				--bitbucket-username kosli --bitbucket-password secret --bitbucket-access-token secret`,
+			golden: "Error: only one of --bitbucket-password, --bitbucket-access-token is allowed\nUsage: kosli assert pullrequest bitbucket [flags]\n",
+		},
+		{
+			wantError: true,
+			name:      "bitbucket PR commands require a username with a password",
+			cmd: `assert pullrequest bitbucket --bitbucket-workspace kosli --repository cli --commit 2492011ef04a9da09d35be706cf6a4c5bc6f1e69
+				--bitbucket-password secret`,
+			golden: "Error: --bitbucket-username is required when using --bitbucket-password\nUsage: kosli assert pullrequest bitbucket [flags]\n",
+		},
+	}
+
+	runTestCmd(suite.T(), tests)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestGitProvidersTestSuite(t *testing.T) {
+	suite.Run(t, new(GitProvidersTestSuite))
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	azUtils "github.com/kosli-dev/cli/internal/azure"
+	ghUtils "github.com/kosli-dev/cli/internal/github"
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/jira"
+	"github.com/kosli-dev/cli/internal/linear"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// issueTracker is an issue tracker that commits reference issues of.
+// Each registered tracker gets a 'report evidence artifact|commit' command.
+type issueTracker interface {
+	// Name is the name of the tracker commands and the issue_tracker reported to Kosli
+	Name() string
+	// DisplayName is the name of the tracker in help texts and messages
+	DisplayName() string
+	// KeyDesc describes the form of the issue references of the tracker in help texts
+	KeyDesc() string
+	// AddFlags adds the tracker flags to a command, and returns the function that
+	// creates the tracker from the flag values once they are parsed
+	AddFlags(cmd *cobra.Command, ci string) func() types.IssueTracker
+	// RequiredFlags are the tracker flags that must be set
+	RequiredFlags() []string
+	// ExampleFlags are the tracker flags and values used in the command examples
+	ExampleFlags() []string
+	// ValidateFlags returns an error if the tracker flags of a command are not a valid combination
+	ValidateFlags(cmd *cobra.Command) error
+}
+
+// trackerBackend is an issueTracker defined by its values
+type trackerBackend struct {
+	name          string
+	displayName   string
+	keyDesc       string
+	requiredFlags []string
+	exampleFlags  []string
+	addFlags      func(cmd *cobra.Command, ci string) func() types.IssueTracker
+	validateFlags func(cmd *cobra.Command) error
+}
+
+func (t *trackerBackend) Name() string            { return t.name }
+func (t *trackerBackend) DisplayName() string     { return t.displayName }
+func (t *trackerBackend) KeyDesc() string         { return t.keyDesc }
+func (t *trackerBackend) RequiredFlags() []string { return t.requiredFlags }
+func (t *trackerBackend) ExampleFlags() []string  { return t.exampleFlags }
+func (t *trackerBackend) ValidateFlags(cmd *cobra.Command) error {
+	if t.validateFlags == nil {
+		return nil
+	}
+	return t.validateFlags(cmd)
+}
+func (t *trackerBackend) AddFlags(cmd *cobra.Command, ci string) func() types.IssueTracker {
+	return t.addFlags(cmd, ci)
+}
+
+// jiraIssueTracker is the tracker of the 'report evidence artifact|commit jira' commands,
+// which are documented separately and report to the Jira evidence endpoints
+var jiraIssueTracker issueTracker = &trackerBackend{
+	name:          "jira",
+	displayName:   "Jira",
+	requiredFlags: []string{"jira-base-url"},
+	addFlags: func(cmd *cobra.Command, ci string) func() types.IssueTracker {
+		config := new(jira.JiraConfig)
+		cmd.Flags().StringVar(&config.BaseURL, "jira-base-url", "", jiraBaseUrlFlag)
+		cmd.Flags().StringVar(&config.Username, "jira-username", "", jiraUsernameFlag)
+		cmd.Flags().StringVar(&config.APIToken, "jira-api-token", "", jiraAPITokenFlag)
+		cmd.Flags().StringVar(&config.PAT, "jira-pat", "", jiraPATFlag)
+		return func() types.IssueTracker {
+			config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+			return config
+		}
+	},
+	validateFlags: func(cmd *cobra.Command) error {
+		err := MuXRequiredFlags(cmd, []string{"jira-pat", "jira-api-token"}, true)
+		if err != nil {
+			return err
+		}
+		return MuXRequiredFlags(cmd, []string{"jira-pat", "jira-username"}, true)
+	},
+}
+
+// issueTrackers are the trackers of the generated issue evidence commands
+var issueTrackers = []issueTracker{}
+
+// registerIssueTracker adds a tracker to the issue evidence commands.
+// It must be called before the commands are created, e.g. from an init function.
+func registerIssueTracker(tracker issueTracker) {
+	issueTrackers = append(issueTrackers, tracker)
+}
+
+func init() {
+	registerIssueTracker(&trackerBackend{
+		name:          "linear",
+		displayName:   "Linear",
+		keyDesc:       "a team key followed by dash and one or more digits, e.g. ENG-123",
+		requiredFlags: []string{"linear-api-key"},
+		exampleFlags:  []string{"--linear-api-key yourLinearAPIKey"},
+		addFlags: func(cmd *cobra.Command, ci string) func() types.IssueTracker {
+			config := new(linear.LinearConfig)
+			cmd.Flags().StringVar(&config.APIKey, "linear-api-key", "", linearAPIKeyFlag)
+			cmd.Flags().StringVar(&config.APIURL, "linear-api-url", linear.DefaultAPIURL, linearAPIURLFlag)
+			return func() types.IssueTracker { return config }
+		},
+	})
+	registerIssueTracker(&trackerBackend{
+		name:          "azure-boards",
+		displayName:   "Azure Boards",
+		keyDesc:       "AB# followed by the work item ID, e.g. AB#123",
+		requiredFlags: []string{"azure-token", "azure-org-url", "project"},
+		exampleFlags: []string{
+			"--azure-token yourAzureToken",
+			"--azure-org-url https://dev.azure.com/myOrg",
+			"--project yourAzureDevOpsProject",
+		},
+		addFlags: func(cmd *cobra.Command, ci string) func() types.IssueTracker {
+			values := new(azUtils.AzureFlagsTempValueHolder)
+			cmd.Flags().StringVar(&values.Token, "azure-token", "", azureTokenFlag)
+			cmd.Flags().StringVar(&values.OrgUrl, "azure-org-url", DefaultValue(ci, "org-url"), azureOrgUrlFlag)
+			cmd.Flags().StringVar(&values.Project, "project", DefaultValue(ci, "project"), azureProjectFlag)
+			return func() types.IssueTracker {
+				return azUtils.NewAzureConfig(values.Token, values.OrgUrl, values.Project, "")
+			}
+		},
+	})
+	registerIssueTracker(&trackerBackend{
+		name:          "github-issues",
+		displayName:   "Github Issues",
+		keyDesc:       "# or GH- followed by the issue number, e.g. #123 or GH-123",
+		requiredFlags: []string{"github-token", "github-org", "repository"},
+		exampleFlags: []string{
+			"--github-token yourGithubToken",
+			"--github-org yourGithubOrg",
+			"--repository yourGithubGitRepository",
+		},
+		addFlags: func(cmd *cobra.Command, ci string) func() types.IssueTracker {
+			values := new(ghUtils.GithubFlagsTempValueHolder)
+			addGithubFlags(cmd, values, ci)
+			return func() types.IssueTracker {
+				return ghUtils.NewGithubConfig(values.Token, values.BaseURL, values.Org, values.Repository)
+			}
+		},
+	})
+}
+
+// issueOptions are the options shared by the issue evidence commands
+type issueOptions struct {
+	tracker          issueTracker
+	newTracker       func() types.IssueTracker
+	userDataFilePath string
+	evidencePaths    []string
+	srcRepoRoot      string
+	projectKeys      []string
+	keyPatterns      []string
+	rules            types.IssueRules
+	assert           bool
+	newRetriever     func() (types.PRRetriever, error)
+}
+
+// addIssueFlags adds the flags of the tracker, the issue rules and the git provider flags to a command
+func addIssueFlags(cmd *cobra.Command, o *issueOptions, ci string) {
+	name := o.tracker.DisplayName()
+	o.newTracker = o.tracker.AddFlags(cmd, ci)
+	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	cmd.Flags().StringSliceVarP(&o.evidencePaths, "evidence-paths", "e", []string{}, evidencePathsFlag)
+	cmd.Flags().StringSliceVar(&o.projectKeys, "project-keys", []string{}, fmt.Sprintf(issueProjectKeysFlag, name))
+	cmd.Flags().StringSliceVar(&o.keyPatterns, "issue-key-patterns", []string{}, fmt.Sprintf(issueKeyPatternsFlag, name, o.newTracker().IssueKeyPattern()))
+	cmd.Flags().BoolVar(&o.rules.RequireIssues, "require-issues", false, fmt.Sprintf(issueRequireIssuesFlag, name))
+	cmd.Flags().StringSliceVar(&o.rules.AllowedStatuses, "allowed-statuses", []string{}, fmt.Sprintf(issueAllowedStatusesFlag, name))
+	cmd.Flags().StringSliceVar(&o.rules.FixVersionRequiredTypes, "require-fix-version-for-types", []string{}, fmt.Sprintf(issueFixVersionTypesFlag, name))
+	cmd.Flags().BoolVar(&o.assert, "assert", false, fmt.Sprintf(assertIssuesFlag, name))
+	o.newRetriever = addGitProviderFlags(cmd, ci, gitProviderFlag)
+}
+
+// exampleTrackerFlags returns the example flags of a tracker, one per line, to be used in command examples
+func exampleTrackerFlags(tracker issueTracker) string {
+	return "\t" + strings.Join(tracker.ExampleFlags(), " \\\n\t") + " \\\n"
+}
+
+// issueKeyFinder finds the issue keys referenced by commits
+type issueKeyFinder struct {
+	matcher   *types.IssueKeyMatcher
+	retriever types.PRRetriever
+}
+
+func (o *issueOptions) newIssueKeyFinder() (*issueKeyFinder, error) {
+	patterns := o.keyPatterns
+	if len(patterns) == 0 {
+		patterns = []string{o.newTracker().IssueKeyPattern()}
+	}
+	matcher, err := types.NewIssueKeyMatcher(patterns, o.projectKeys)
+	if err != nil {
+		return nil, err
+	}
+	retriever, err := o.newRetriever()
+	if err != nil {
+		return nil, err
+	}
+	return &issueKeyFinder{matcher: matcher, retriever: retriever}, nil
+}
+
+// find returns the issue keys found in the commit message and, with --git-provider,
+// in the titles and descriptions of the pull requests of the commit.
+// If useBranch is true, the branch name is used when no keys are found in these.
+func (f *issueKeyFinder) find(commit *gitview.CommitInfo, useBranch bool) ([]string, error) {
+	texts := []string{commit.Message}
+	if f.retriever != nil {
+		pullRequests, err := types.FindPREvidence(f.retriever, commit.Sha1, 0)
+		if err != nil {
+			return []string{}, err
+		}
+		for _, pr := range pullRequests {
+			texts = append(texts, pr.Title, pr.Body)
+		}
+	}
+
+	issueIDs := f.matcher.FindKeys(texts...)
+	if len(issueIDs) == 0 && useBranch {
+		issueIDs = f.matcher.FindKeys(commit.Branch)
+	}
+	return issueIDs, nil
+}
+
+// getIssues returns the information of the issues from the tracker, and a log line for each issue
+func (o *issueOptions) getIssues(issueIDs []string) ([]*types.IssueInfo, string, error) {
+	tracker := o.newTracker()
+	results := []*types.IssueInfo{}
+	issueLog := ""
+	for _, issueID := range issueIDs {
+		result, err := tracker.GetIssueInfo(issueID)
+		if err != nil {
+			return results, issueLog, err
+		}
+		results = append(results, result)
+		issueExistLog := "issue not found"
+		if result.IssueExists {
+			issueExistLog = "issue found"
+		}
+		issueLog += fmt.Sprintf("\n\t%s: %s", result.IssueID, issueExistLog)
+	}
+	return results, issueLog, nil
+}
+
+// checkIssueViolations returns an error with the violations when asserting, and logs them as warnings otherwise
+func (o *issueOptions) checkIssueViolations(subject string, violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	if o.assert {
+		return fmt.Errorf("%s issue references of %s do not comply:\n%s",
+			o.tracker.DisplayName(), subject, strings.Join(violations, "\n"))
+	}
+	for _, violation := range violations {
+		logger.Warning("%s", violation)
+	}
+	return nil
+}
+
+// evidenceType is the type of the evidence endpoints of the tracker.
+// Jira has its own endpoints, the other trackers report to the issues endpoints.
+func (o *issueOptions) evidenceType() string {
+	if o.tracker == jiraIssueTracker {
+		return "jira"
+	}
+	return "issues"
+}
+
+// newIssueEvidencePayload returns the evidence payload of the issues,
+// which is the jira_results payload of the Jira evidence endpoints for Jira
+func (o *issueOptions) newIssueEvidencePayload(typed TypedEvidencePayload, issues []*types.IssueInfo, violations []string) interface{} {
+	if o.tracker == jiraIssueTracker {
+		return &JiraEvidencePayload{TypedEvidencePayload: typed, JiraResults: issues, Violations: violations}
+	}
+	return &IssueEvidencePayload{TypedEvidencePayload: typed, IssueTracker: o.tracker.Name(), Issues: issues, Violations: violations}
+}
+
+// newArtifactIssueEvidencePayload returns the evidence payload of the issues referenced by the commits of an artifact
+func (o *issueOptions) newArtifactIssueEvidencePayload(typed TypedEvidencePayload, issues []*types.IssueInfo, violations []string, references IssueCommitReferences) interface{} {
+	if o.tracker == jiraIssueTracker {
+		return &ArtifactJiraEvidencePayload{
+			JiraEvidencePayload:   JiraEvidencePayload{TypedEvidencePayload: typed, JiraResults: issues, Violations: violations},
+			IssueCommitReferences: references,
+		}
+	}
+	return &ArtifactIssueEvidencePayload{
+		IssueEvidencePayload:  IssueEvidencePayload{TypedEvidencePayload: typed, IssueTracker: o.tracker.Name(), Issues: issues, Violations: violations},
+		IssueCommitReferences: references,
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/testHelpers"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// Define the suite, and absorb the built-in basic suite
+// functionality from testify - including a T() method which
+// returns the current testing context
+type IssueTrackersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *IssueTrackersTestSuite) TestEachTrackerHasEvidenceCommands() {
+	root, err := newRootCmd(io.Discard, []string{})
+	require.NoError(suite.T(), err)
+	for _, path := range []string{
+		"report evidence artifact",
+		"report evidence commit",
+	} {
+		suite.Run(path, func() {
+			cmd, _, err := root.Find(strings.Fields(path))
+			require.NoError(suite.T(), err)
+			names := []string{}
+			for _, sub := range cmd.Commands() {
+				names = append(names, sub.Name())
+			}
+			for _, tracker := range append(issueTrackers, jiraIssueTracker) {
+				require.Contains(suite.T(), names, tracker.Name())
+			}
+		})
+	}
+}
+
+func (suite *IssueTrackersTestSuite) TestTrackerNamesAreUnique() {
+	seen := map[string]bool{}
+	for _, tracker := range append(issueTrackers, jiraIssueTracker) {
+		require.False(suite.T(), seen[tracker.Name()], "%s is used by more than one issue tracker", tracker.Name())
+		seen[tracker.Name()] = true
+	}
+}
+
+func (suite *IssueTrackersTestSuite) TestReportCommitWithoutIssues() {
+	tmpDir, err := os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err)
+	defer os.RemoveAll(tmpDir)
+	_, workTree, fs, err := testHelpers.InitializeGitRepo(tmpDir)
+	require.NoError(suite.T(), err)
+	commitSha, err := testHelpers.CommitToRepo(workTree, fs, "test commit without references")
+	require.NoError(suite.T(), err)
+
+	tests := []cmdTestCase{
+		{
+			name: "github issues evidence is reported with the issue tracker",
+			cmd: fmt.Sprintf(`report evidence commit github-issues --name issues --build-url example.com
+				--github-token secret --github-org kosli-dev --repository cli
+				--repo-root %s --commit %s --org docs-cmd-test-user --api-token secret --dry-run`, tmpDir, commitSha),
+			goldenRegex: `"issue_tracker": "github-issues",\n\s+"issues": \[\]`,
+		},
+		{
+			wantError: true,
+			name:      "linear evidence requires --linear-api-key",
+			cmd: fmt.Sprintf(`report evidence commit linear --name issues --build-url example.com
+				--repo-root %s --commit %s --org docs-cmd-test-user --api-token secret`, tmpDir, commitSha),
+			golden: "Error: required flag(s) \"linear-api-key\" not set\n",
+		},
+	}
+
+	runTestCmd(suite.T(), tests)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestIssueTrackersTestSuite(t *testing.T) {
+	suite.Run(t, new(IssueTrackersTestSuite))
+}
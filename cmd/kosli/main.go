@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -56,6 +57,10 @@ func main() {
 			logger.Warning("Encountered an error but --dry-run is enabled. Exiting with 0 exit code.")
 			os.Exit(0)
 		}
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			logger.ErrorWithExitCode(exitErr.Code, err.Error())
+		}
 		logger.Error(err.Error())
 	}
 }
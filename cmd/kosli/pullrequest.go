@@ -5,12 +5,8 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"reflect"
+	"strings"
 
-	azUtils "github.com/kosli-dev/cli/internal/azure"
-	bbUtils "github.com/kosli-dev/cli/internal/bitbucket"
-	ghUtils "github.com/kosli-dev/cli/internal/github"
-	gitlabUtils "github.com/kosli-dev/cli/internal/gitlab"
 	"github.com/kosli-dev/cli/internal/requests"
 	"github.com/kosli-dev/cli/internal/types"
 )
@@ -23,9 +19,12 @@ type PullRequestEvidencePayload struct {
 
 type pullRequestOptions struct {
 	payload          PullRequestEvidencePayload
-	retriever        interface{}
+	provider         gitProvider
+	newRetriever     func() types.PRRetriever
 	userDataFilePath string
 	assert           bool
+	requireFourEyes  bool
+	prNumber         int
 }
 
 type pullRequestArtifactOptions struct {
@@ -35,10 +34,6 @@ type pullRequestArtifactOptions struct {
 	pullRequestOptions
 }
 
-func (o *pullRequestArtifactOptions) getRetriever() types.PRRetriever {
-	return o.retriever.(types.PRRetriever)
-}
-
 func (o *pullRequestArtifactOptions) run(out io.Writer, args []string) error {
 	var err error
 	if o.payload.ArtifactFingerprint == "" {
@@ -49,7 +44,7 @@ func (o *pullRequestArtifactOptions) run(out io.Writer, args []string) error {
 	}
 
 	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/pull_request", global.Host, global.Org, o.flowName)
-	pullRequestsEvidence, err := getPullRequestsEvidence(o.getRetriever(), o.commit, o.assert)
+	pullRequestsEvidence, err := getPullRequestsEvidence(o.newRetriever(), o.provider.Label(), o.commit, o.prNumber, o.assert, o.requireFourEyes)
 	if err != nil {
 		return err
 	}
@@ -60,8 +55,8 @@ func (o *pullRequestArtifactOptions) run(out io.Writer, args []string) error {
 		return err
 	}
 
-	label := ""
-	o.payload.GitProvider, label = getGitProviderAndLabel(o.retriever)
+	label := o.provider.Label()
+	o.payload.GitProvider = o.provider.Name()
 
 	// PR evidence does not have files to upload
 	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{})
@@ -90,32 +85,10 @@ func (o *pullRequestArtifactOptions) run(out io.Writer, args []string) error {
 	return err
 }
 
-func getGitProviderAndLabel(retriever interface{}) (string, string) {
-	label := "pull request"
-	provider := ""
-	t := reflect.TypeOf(retriever)
-	switch t {
-	case reflect.TypeOf(&gitlabUtils.GitlabConfig{}):
-		provider = "gitlab"
-		label = "merge request"
-	case reflect.TypeOf(&ghUtils.GithubConfig{}):
-		provider = "github"
-	case reflect.TypeOf(&azUtils.AzureConfig{}):
-		provider = "azure"
-	case reflect.TypeOf(&bbUtils.Config{}):
-		provider = "bitbucket"
-	}
-	return provider, label
-}
-
 type pullRequestCommitOptions struct {
 	pullRequestOptions
 }
 
-func (o *pullRequestCommitOptions) getRetriever() types.PRRetriever {
-	return o.retriever.(types.PRRetriever)
-}
-
 func (o *pullRequestCommitOptions) run(args []string) error {
 	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/pull_request", global.Host, global.Org)
 
@@ -125,14 +98,14 @@ func (o *pullRequestCommitOptions) run(args []string) error {
 		return err
 	}
 
-	pullRequestsEvidence, err := getPullRequestsEvidence(o.getRetriever(), o.payload.CommitSHA, o.assert)
+	pullRequestsEvidence, err := getPullRequestsEvidence(o.newRetriever(), o.provider.Label(), o.payload.CommitSHA, o.prNumber, o.assert, o.requireFourEyes)
 	if err != nil {
 		return err
 	}
 
 	o.payload.PullRequests = pullRequestsEvidence
-	label := ""
-	o.payload.GitProvider, label = getGitProviderAndLabel(o.retriever)
+	label := o.provider.Label()
+	o.payload.GitProvider = o.provider.Name()
 
 	// PR evidence does not have files to upload
 	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{})
@@ -160,21 +133,49 @@ func (o *pullRequestCommitOptions) run(args []string) error {
 	return err
 }
 
-func getPullRequestsEvidence(retriever types.PRRetriever, commit string, assert bool) ([]*types.PREvidence, error) {
-	pullRequestsEvidence, err := retriever.PREvidenceForCommit(commit)
+// getPullRequestsEvidence returns the pull requests evidence for a commit, or for prNumber if it is set.
+// label is what the git provider calls a pull request.
+// If requireFourEyes is set, pull requests that do not satisfy the four-eyes rule
+// are an error when asserting and a warning otherwise.
+func getPullRequestsEvidence(retriever types.PRRetriever, label, commit string, prNumber int, assert, requireFourEyes bool) ([]*types.PREvidence, error) {
+	pullRequestsEvidence, err := types.FindPREvidence(retriever, commit, prNumber)
 	if err != nil {
 		return pullRequestsEvidence, err
 	}
+	for _, pr := range pullRequestsEvidence {
+		logger.Debug("found %s %s by %s", label, pr.URL, pr.ResolvedBy)
+	}
 	if len(pullRequestsEvidence) == 0 {
-		name := "pull requests"
-		if reflect.TypeOf(retriever) == reflect.TypeOf(&gitlabUtils.GitlabConfig{}) {
-			name = "merge requests"
-		}
-
 		if assert {
-			return pullRequestsEvidence, fmt.Errorf("no %s found for the given commit: %s", name, commit)
+			return pullRequestsEvidence, fmt.Errorf("no %ss found for the given commit: %s", label, commit)
+		}
+		logger.Info("no %ss found for given commit: %s", label, commit)
+	}
+	if requireFourEyes {
+		violations := fourEyesViolations(pullRequestsEvidence)
+		if len(violations) > 0 {
+			if assert {
+				return pullRequestsEvidence, fmt.Errorf("four-eyes rule is not satisfied for commit %s:\n%s",
+					commit, strings.Join(violations, "\n"))
+			}
+			for _, violation := range violations {
+				logger.Warning("%s", violation)
+			}
 		}
-		logger.Info("no %s found for given commit: %s", name, commit)
 	}
 	return pullRequestsEvidence, nil
 }
+
+// fourEyesViolations returns the four-eyes violations of the pull requests, prefixed with their URL
+func fourEyesViolations(pullRequestsEvidence []*types.PREvidence) []string {
+	violations := []string{}
+	for _, pr := range pullRequestsEvidence {
+		if pr.FourEyes == nil || pr.FourEyes.Passed {
+			continue
+		}
+		for _, violation := range pr.FourEyes.Violations {
+			violations = append(violations, fmt.Sprintf("%s: %s", pr.URL, violation))
+		}
+	}
+	return violations
+}
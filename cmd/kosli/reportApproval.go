@@ -8,6 +8,7 @@ import (
 	"github.com/kosli-dev/cli/internal/gitview"
 
 	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +38,16 @@ kosli report approval \
 	--org yourOrgName \
 	--flow yourFlowName \
 	--fingerprint yourArtifactFingerprint
+
+# Report that an artifact has been approved by a named approver.
+kosli report approval \
+	--api-token yourAPIToken \
+	--approver alice@example.com \
+	--approval-url https://example.com/approvals/42 \
+	--oldest-commit $(git rev-parse HEAD~5) \
+	--org yourOrgName \
+	--flow yourFlowName \
+	--fingerprint yourArtifactFingerprint
 `
 
 type reportApprovalOptions struct {
@@ -45,19 +56,28 @@ type reportApprovalOptions struct {
 	oldestSrcCommit    string
 	newestSrcCommit    string
 	srcRepoRoot        string
+	rangeOptions       gitview.RangeOptions
+	autoFetchDepth     int
+	newRetriever       func() (types.PRRetriever, error)
 	userDataFile       string
+	approver           approverOptions
 	payload            ApprovalPayload
 }
 
 type ApprovalPayload struct {
-	ArtifactFingerprint string              `json:"artifact_fingerprint"`
-	Description         string              `json:"description"`
-	CommitList          []string            `json:"src_commit_list"`
-	Reviews             []map[string]string `json:"approvals"`
-	UserData            interface{}         `json:"user_data"`
+	ArtifactFingerprint string   `json:"artifact_fingerprint"`
+	Description         string   `json:"description"`
+	CommitList          []string `json:"src_commit_list"`
+	// CommitListTruncated is true when the git history only contains part of the commits of the approval
+	CommitListTruncated bool `json:"src_commit_list_truncated"`
+	// CommitsList has the details of the commits in CommitList, only sent with --file-stats
+	CommitsList []*gitview.CommitInfo `json:"commits_list,omitempty"`
+	Reviews     []map[string]string   `json:"approvals"`
+	UserData    interface{}           `json:"user_data"`
 }
 
 func newReportApprovalCmd(out io.Writer) *cobra.Command {
+	ci := WhichCI()
 	o := new(reportApprovalOptions)
 	o.fingerprintOptions = new(fingerprintOptions)
 	cmd := &cobra.Command{
@@ -89,6 +109,12 @@ func newReportApprovalCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&o.oldestSrcCommit, "oldest-commit", "", oldestCommitFlag)
 	cmd.Flags().StringVar(&o.newestSrcCommit, "newest-commit", "HEAD", newestCommitFlag)
 	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	cmd.Flags().BoolVar(&o.rangeOptions.FirstParent, "first-parent", false, firstParentFlag)
+	cmd.Flags().StringSliceVar(&o.rangeOptions.Paths, "src-paths", []string{}, srcPathsFlag)
+	cmd.Flags().BoolVar(&o.rangeOptions.FileStats, "file-stats", false, fileStatsFlag)
+	cmd.Flags().IntVar(&o.autoFetchDepth, "auto-fetch-depth", 0, approvalAutoFetchDepthFlag)
+	o.newRetriever = addGitProviderFlags(cmd, ci, gitProviderApprovalFlag)
+	addApproverFlags(cmd, &o.approver, ci)
 	addFingerprintFlags(cmd, o.fingerprintOptions)
 	addDryRunFlag(cmd)
 
@@ -114,7 +140,7 @@ func (o *reportApprovalOptions) run(args []string, request bool) error {
 		return err
 	}
 
-	o.payload.CommitList, err = o.payloadCommitList()
+	o.payload.CommitList, o.payload.CommitsList, o.payload.CommitListTruncated, err = o.payloadCommitList()
 	if err != nil {
 		return err
 	}
@@ -146,25 +172,21 @@ func (o *reportApprovalOptions) payloadArtifactSHA256(args []string) (string, er
 	return o.payload.ArtifactFingerprint, nil
 }
 
+// payloadReviews returns the review of a reported approval, by the approver,
+// and no reviews for a requested approval
 func (o *reportApprovalOptions) payloadReviews(request bool) []map[string]string {
-	if !request {
-		return []map[string]string{
-			{
-				"state":        "APPROVED",
-				"comment":      o.payload.Description,
-				"approved_by":  "External",
-				"approval_url": "undefined",
-			},
-		}
-	} else {
+	if request {
 		return []map[string]string{}
 	}
+	return []map[string]string{o.approver.review("APPROVED", o.payload.Description)}
 }
 
-func (o *reportApprovalOptions) payloadCommitList() ([]string, error) {
-	commits, err := o.commitsHistory()
+// payloadCommitList returns the SHAs of the commits of the approval,
+// their details when the file stats are asked for, and whether the list is truncated
+func (o *reportApprovalOptions) payloadCommitList() ([]string, []*gitview.CommitInfo, bool, error) {
+	commits, truncated, err := o.commitsHistory()
 	if err != nil {
-		return nil, err
+		return nil, nil, false, err
 	}
 
 	// Need this line to make sure an empty list is converted to [] and not null in SendPayload
@@ -172,18 +194,24 @@ func (o *reportApprovalOptions) payloadCommitList() ([]string, error) {
 	for _, commit := range commits {
 		commitList = append(commitList, commit.Sha1)
 	}
-	return commitList, nil
+	if !o.rangeOptions.FileStats {
+		return commitList, nil, truncated, nil
+	}
+	return commitList, commits, truncated, nil
 }
 
-func (o *reportApprovalOptions) commitsHistory() ([]*gitview.CommitInfo, error) {
+// commitsHistory returns the commits between the oldest and newest commits of the approval,
+// completing the history of a shallow clone as configured by the flags
+func (o *reportApprovalOptions) commitsHistory() ([]*gitview.CommitInfo, bool, error) {
 	gitView, err := gitview.New(o.srcRepoRoot)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	commits, err := gitView.CommitsBetween(o.oldestSrcCommit, o.newestSrcCommit, logger)
+	opts, err := newChangeLogOptions(o.autoFetchDepth, o.newRetriever)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return commits, nil
+	opts.RangeOptions = o.rangeOptions
+	return gitView.CompleteCommitsBetween(o.oldestSrcCommit, o.newestSrcCommit, opts, logger)
 }
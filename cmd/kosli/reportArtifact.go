@@ -9,6 +9,7 @@ import (
 
 	"github.com/kosli-dev/cli/internal/gitview"
 	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +19,11 @@ type reportArtifactOptions struct {
 	gitReference       string
 	srcRepoRoot        string
 	name               string
+	autoFetchDepth     int
+	firstParent        bool
+	srcPaths           []string
+	fileStats          bool
+	newRetriever       func() (types.PRRetriever, error)
 	payload            ArtifactPayload
 }
 
@@ -29,6 +35,8 @@ type ArtifactPayload struct {
 	CommitUrl   string                `json:"commit_url"`
 	RepoUrl     string                `json:"repo_url"`
 	CommitsList []*gitview.CommitInfo `json:"commits_list"`
+	// CommitsListTruncated is true when the git history only contains part of the changelog
+	CommitsListTruncated bool `json:"commits_list_truncated"`
 }
 
 const reportArtifactShortDesc = `Report an artifact creation to a Kosli flow.  `
@@ -56,6 +64,21 @@ kosli report artifact ANOTHER_FILE.txt \
 	--org yourOrgName \
 	--flow yourFlowName \
 	--fingerprint yourArtifactFingerprint 
+
+# Report to a Kosli flow that a file type artifact has been created from a shallow git clone,
+# getting the changelog since the previous artifact from Github if it is not in the clone
+kosli report artifact FILE.tgz \
+	--api-token yourApiToken \
+	--artifact-type file \
+	--build-url https://exampleci.com \
+	--commit-url https://github.com/YourOrg/YourProject/commit/yourCommitShaThatThisArtifactWasBuiltFrom \
+	--git-commit yourCommitShaThatThisArtifactWasBuiltFrom \
+	--org yourOrgName \
+	--flow yourFlowName \
+	--git-provider github \
+	--github-token yourGithubToken \
+	--github-org yourGithubOrg \
+	--repository yourGithubGitRepository
 `
 
 func newReportArtifactCmd(out io.Writer) *cobra.Command {
@@ -91,6 +114,11 @@ func newReportArtifactCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&o.payload.CommitUrl, "commit-url", "u", DefaultValue(ci, "commit-url"), commitUrlFlag)
 	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
 	cmd.Flags().StringVarP(&o.name, "name", "n", "", artifactName)
+	cmd.Flags().IntVar(&o.autoFetchDepth, "auto-fetch-depth", 0, autoFetchDepthFlag)
+	cmd.Flags().BoolVar(&o.firstParent, "first-parent", false, firstParentFlag)
+	cmd.Flags().StringSliceVar(&o.srcPaths, "src-paths", []string{}, srcPathsFlag)
+	cmd.Flags().BoolVar(&o.fileStats, "file-stats", false, fileStatsFlag)
+	o.newRetriever = addGitProviderFlags(cmd, ci, gitProviderCompareFlag)
 	addFingerprintFlags(cmd, o.fingerprintOptions)
 
 	addDryRunFlag(cmd)
@@ -135,9 +163,17 @@ func (o *reportArtifactOptions) run(args []string) error {
 	}
 	o.payload.GitCommit = commitObject.Sha1
 
-	previousCommit, err := o.latestCommit(currentBranch(gitView))
+	changeLogOpts, err := newChangeLogOptions(o.autoFetchDepth, o.newRetriever)
+	if err != nil {
+		return err
+	}
+	changeLogOpts.FirstParent = o.firstParent
+	changeLogOpts.Paths = o.srcPaths
+	changeLogOpts.FileStats = o.fileStats
+
+	previousCommit, err := latestCommit(o.flowName, o.payload.Fingerprint, currentBranch(gitView))
 	if err == nil {
-		o.payload.CommitsList, err = gitView.ChangeLog(o.payload.GitCommit, previousCommit, logger)
+		o.payload.CommitsList, o.payload.CommitsListTruncated, err = gitView.ChangeLog(o.payload.GitCommit, previousCommit, changeLogOpts, logger)
 		if err != nil && !global.DryRun {
 			return err
 		}
@@ -167,10 +203,10 @@ func (o *reportArtifactOptions) run(args []string) error {
 }
 
 // latestCommit retrieves the git commit of the latest artifact for a flow in Kosli
-func (o *reportArtifactOptions) latestCommit(branchName string) (string, error) {
+func latestCommit(flowName, fingerprint, branchName string) (string, error) {
 	latestCommitUrl := fmt.Sprintf(
 		"%s/api/v2/artifacts/%s/%s/%s/latest_commit%s",
-		global.Host, global.Org, o.flowName, o.payload.Fingerprint, asBranchParameter(branchName))
+		global.Host, global.Org, flowName, fingerprint, asBranchParameter(branchName))
 
 	reqParams := &requests.RequestParams{
 		Method:   http.MethodGet,
@@ -189,14 +225,53 @@ func (o *reportArtifactOptions) latestCommit(branchName string) (string, error)
 	}
 	latestCommit := latestCommitResponse["latest_commit"]
 	if latestCommit == nil {
-		logger.Debug("no previous artifacts were found for flow: %s", o.flowName)
+		logger.Debug("no previous artifacts were found for flow: %s", flowName)
 		return "", nil
 	} else {
-		logger.Debug("latest artifact for flow: %s has the git commit: %s", o.flowName, latestCommit.(string))
+		logger.Debug("latest artifact for flow: %s has the git commit: %s", flowName, latestCommit.(string))
 		return latestCommit.(string), nil
 	}
 }
 
+// artifactChangeRange returns the commits between the git commit of the previous artifact in the flow
+// and the git commit of an artifact, as reported by 'kosli report artifact'
+func artifactChangeRange(gv *gitview.GitView, flowName, fingerprint, commit string) ([]*gitview.CommitInfo, error) {
+	previousCommit, err := latestCommit(flowName, fingerprint, currentBranch(gv))
+	if err != nil {
+		if !global.DryRun {
+			return []*gitview.CommitInfo{}, err
+		}
+		previousCommit = ""
+	}
+	commits, truncated, err := gv.ChangeLog(commit, previousCommit, nil, logger)
+	if truncated {
+		logger.Warning("only %d commits of the change range of %s are available", len(commits), commit)
+	}
+	return commits, err
+}
+
+// newChangeLogOptions returns the options to complete the changelog of a shallow clone with.
+// The changelog is got from the selected git provider, if any, when fetching is disabled or fails.
+func newChangeLogOptions(autoFetchDepth int, newRetriever func() (types.PRRetriever, error)) (*gitview.ChangeLogOptions, error) {
+	opts := &gitview.ChangeLogOptions{AutoFetchDepth: autoFetchDepth}
+	if autoFetchDepth < 0 {
+		return opts, fmt.Errorf("--auto-fetch-depth must be 0 or more")
+	}
+	if newRetriever == nil {
+		return opts, nil
+	}
+	retriever, err := newRetriever()
+	if err != nil || retriever == nil {
+		return opts, err
+	}
+	comparer, ok := retriever.(gitview.CommitComparer)
+	if !ok {
+		return opts, fmt.Errorf("the changelog can only be got from the github, gitlab and azure git providers")
+	}
+	opts.Comparer = comparer
+	return opts, nil
+}
+
 func currentBranch(gv *gitview.GitView) string {
 	branchName, _ := gv.BranchName()
 	return branchName
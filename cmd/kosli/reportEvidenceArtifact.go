@@ -21,7 +21,18 @@ func newReportEvidenceArtifactCmd(out io.Writer) *cobra.Command {
 		newReportEvidenceArtifactGenericCmd(out),
 		newReportEvidenceArtifactJunitCmd(out),
 		newReportEvidenceArtifactSnykCmd(out),
+		newReportEvidenceArtifactSBOMCmd(out),
+		newReportEvidenceArtifactSARIFCmd(out),
+		newReportEvidenceArtifactVulnScanCmd(out, trivyScanner),
+		newReportEvidenceArtifactVulnScanCmd(out, grypeScanner),
+		newReportEvidenceArtifactCoverageCmd(out),
+		newReportEvidenceArtifactJiraCmd(out),
+		newReportEvidenceArtifactSignaturesCmd(out),
 	)
+	// Add a subcommand for each issue tracker
+	for _, tracker := range issueTrackers {
+		cmd.AddCommand(newReportEvidenceArtifactIssuesCmd(out, tracker))
+	}
 
 	return cmd
 }
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceArtifactCoverageOptions struct {
+	fingerprintOptions *fingerprintOptions
+	thresholdOptions   coverageThresholdOptions
+	flowName           string
+	coverageFilePaths  []string
+	userDataFilePath   string
+	payload            EvidenceCoveragePayload
+}
+
+const reportEvidenceArtifactCoverageShortDesc = `Report code coverage evidence for an artifact in a Kosli flow.  `
+
+const reportEvidenceArtifactCoverageLongDesc = reportEvidenceArtifactCoverageShortDesc + `
+The --coverage-files are parsed and uploaded to Kosli's evidence vault.
+Cobertura XML, LCOV, JaCoCo XML and Go coverprofile reports are supported.
+Line and branch coverage are computed in total and per package. When several reports are provided, they are combined.
+If the coverage is at least --min-coverage (and --min-branch-coverage) the evidence is reported as compliant.
+Otherwise the evidence is reported as non-compliant.
+` + fingerprintDesc
+
+const reportEvidenceArtifactCoverageExample = `
+# report code coverage evidence about a file artifact:
+kosli report evidence artifact coverage FILE.tgz \
+	--artifact-type file \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--coverage-files coverage.out
+
+# report code coverage evidence about an artifact using an available Sha256 digest,
+# requiring at least 80% line coverage:
+kosli report evidence artifact coverage \
+	--fingerprint yourSha256 \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--coverage-files coverage.xml,lcov.info \
+	--min-coverage 80
+`
+
+func newReportEvidenceArtifactCoverageCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceArtifactCoverageOptions)
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "coverage [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   reportEvidenceArtifactCoverageShortDesc,
+		Long:    reportEvidenceArtifactCoverageLongDesc,
+		Example: reportEvidenceArtifactCoverageExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	cmd.Flags().StringSliceVar(&o.coverageFilePaths, "coverage-files", []string{}, coverageFilesFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addCoverageThresholdFlags(cmd, &o.thresholdOptions)
+
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"flow", "build-url", "name", "coverage-files"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceArtifactCoverageOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/generic", global.Host, global.Org, o.flowName)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = o.thresholdOptions.evaluate(&o.payload, o.coverageFilePaths)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, o.coverageFilePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("coverage evidence is reported to artifact: %s", o.payload.ArtifactFingerprint)
+	}
+	return err
+}
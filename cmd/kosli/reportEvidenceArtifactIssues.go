@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceArtifactIssuesOptions struct {
+	issueOptions
+	fingerprintOptions *fingerprintOptions
+	flowName           string
+	commit             string
+	payload            TypedEvidencePayload
+}
+
+func newReportEvidenceArtifactIssuesCmd(out io.Writer, tracker issueTracker) *cobra.Command {
+	o := new(reportEvidenceArtifactIssuesOptions)
+	o.tracker = tracker
+	o.fingerprintOptions = new(fingerprintOptions)
+	shortDesc := fmt.Sprintf("Report %s evidence for an artifact in a Kosli flow.", tracker.DisplayName())
+	example := fmt.Sprintf(`kosli report evidence artifact %s yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--commit yourArtifactGitCommit \
+%s	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken`, tracker.Name(), exampleTrackerFlags(tracker))
+	cmd := &cobra.Command{
+		Use:   tracker.Name() + " [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short: shortDesc,
+		Long: shortDesc + fmt.Sprintf(`  
+Parses all the commits that went into the artifact, since the git commit of the previous artifact in the flow,
+for %[1]s issue references of the form '%[2]s'. Issue keys are looked up in the commit messages and,
+if ^--git-provider^ is set, in the titles and descriptions of the pull requests of each commit.  
+Each referenced issue is looked up once in %[1]s, and the evidence lists the issues referenced by each commit
+as well as the commits that reference no issue. Merge commits are not required to reference an issue.
+
+The same rules as for ^kosli report evidence commit %[3]s^ can be applied. With ^--require-issues^,
+each commit in the change range must reference at least one %[1]s issue.
+Use ^--assert^ to fail the command when the rules are not satisfied.
+`, tracker.DisplayName(), tracker.KeyDesc(), tracker.Name()) + fingerprintDesc,
+		Example: fmt.Sprintf(`
+# report %[1]s evidence for an artifact
+%[2]s
+
+# fail if any commit of the artifact does not reference a %[1]s issue
+%[2]s \
+	--require-issues \
+	--assert
+`, tracker.DisplayName(), example),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.validate(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	o.addFlags(cmd)
+	return cmd
+}
+
+func (o *reportEvidenceArtifactIssuesOptions) addFlags(cmd *cobra.Command) {
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload, ci)
+	cmd.Flags().StringVar(&o.commit, "commit", DefaultValue(ci, "git-commit"), commitIssuesEvidenceFlag)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	addIssueFlags(cmd, &o.issueOptions, ci)
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append([]string{"commit", "flow", "build-url", "name"}, o.tracker.RequiredFlags()...))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+}
+
+func (o *reportEvidenceArtifactIssuesOptions) validate(cmd *cobra.Command, args []string) error {
+	err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+	if err != nil {
+		return ErrorBeforePrintingUsage(cmd, err.Error())
+	}
+
+	err = o.tracker.ValidateFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+	if err != nil {
+		return ErrorBeforePrintingUsage(cmd, err.Error())
+	}
+	return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+}
+
+func (o *reportEvidenceArtifactIssuesOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/%s", global.Host, global.Org, o.flowName, o.evidenceType())
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	gv, err := gitview.New(o.srcRepoRoot)
+	if err != nil {
+		return err
+	}
+
+	o.commit, err = gv.ResolveRevision(o.commit)
+	if err != nil {
+		return err
+	}
+
+	commits, err := artifactChangeRange(gv, o.flowName, o.payload.ArtifactFingerprint, o.commit)
+	if err != nil {
+		return err
+	}
+
+	finder, err := o.newIssueKeyFinder()
+	if err != nil {
+		return err
+	}
+
+	issueIDs := []string{}
+	seen := map[string]bool{}
+	references := IssueCommitReferences{
+		Commits:              []*CommitIssueReferences{},
+		CommitsWithoutIssues: []string{},
+	}
+	for _, commit := range commits {
+		commitIssueIDs, err := finder.find(commit, false)
+		if err != nil {
+			return err
+		}
+		references.Commits = append(references.Commits, &CommitIssueReferences{CommitSHA: commit.Sha1, IssueIDs: commitIssueIDs})
+		for _, issueID := range commitIssueIDs {
+			if !seen[issueID] {
+				seen[issueID] = true
+				issueIDs = append(issueIDs, issueID)
+			}
+		}
+	}
+
+	logger.Debug("the following %s references are found in %d commit(s): %v", o.tracker.DisplayName(), len(commits), issueIDs)
+
+	issues, issueLog, err := o.getIssues(issueIDs)
+	if err != nil {
+		return err
+	}
+
+	// references to pull requests, e.g. in squash merge commits, are not issue references
+	pullRequests := map[string]bool{}
+	for _, issue := range issues {
+		if issue.IssueType == types.PullRequestIssueType {
+			pullRequests[issue.IssueID] = true
+		}
+	}
+	for i, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		referencesIssue := false
+		for _, issueID := range references.Commits[i].IssueIDs {
+			if !pullRequests[issueID] {
+				referencesIssue = true
+			}
+		}
+		if !referencesIssue {
+			references.CommitsWithoutIssues = append(references.CommitsWithoutIssues, commit.Sha1)
+		}
+	}
+
+	// issues are required per commit, not for the artifact as a whole
+	rules := o.rules
+	rules.RequireIssues = false
+	_, violations := rules.Evaluate(issues)
+	if o.rules.RequireIssues {
+		for _, commitSHA := range references.CommitsWithoutIssues {
+			violations = append(violations, fmt.Sprintf("commit %s references no %s issue", commitSHA, o.tracker.DisplayName()))
+		}
+	}
+	err = o.checkIssueViolations("artifact "+o.payload.ArtifactFingerprint, violations)
+	if err != nil {
+		return err
+	}
+
+	payload := o.newArtifactIssueEvidencePayload(o.payload, issues, violations, references)
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(payload, o.evidencePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("%s evidence is reported to artifact: %s", o.tracker.DisplayName(), o.payload.ArtifactFingerprint)
+		logger.Info("  Issues references reported: %s", issueLog)
+		if len(references.CommitsWithoutIssues) > 0 {
+			logger.Info("  Commits without issue references: %v", references.CommitsWithoutIssues)
+		}
+	}
+	return err
+}
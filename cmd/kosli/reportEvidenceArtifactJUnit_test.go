@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -95,3 +96,59 @@ func (suite *ArtifactEvidenceJUnitCommandTestSuite) TestArtifactEvidenceJUnitCom
 func TestArtifactEvidenceJUnitCommandTestSuite(t *testing.T) {
 	suite.Run(t, new(ArtifactEvidenceJUnitCommandTestSuite))
 }
+
+func TestParseJunitTimestamp(t *testing.T) {
+	for _, tt := range []struct {
+		timestamp string
+		want      int64
+		wantError bool
+	}{
+		// pytest
+		{timestamp: "2023-01-08T03:37:44.123456", want: 1673149064},
+		// Ruby minitest and Jest
+		{timestamp: "2023-01-08T03:37:44+00:00", want: 1673149064},
+		{timestamp: "2023-01-08T05:37:44.123+02:00", want: 1673149064},
+		// go-junit-report
+		{timestamp: "2023-01-08T03:37:44Z", want: 1673149064},
+		// Gradle and Maven surefire
+		{timestamp: "2023-01-08T03:37:44", want: 1673149064},
+		// NUnit
+		{timestamp: "2023-01-08 03:37:44Z", want: 1673149064},
+		{timestamp: "2023-01-07T22:37:44-0500", want: 1673149064},
+		{timestamp: "08/01/2023 03:37:44", wantError: true},
+	} {
+		t.Run(tt.timestamp, func(t *testing.T) {
+			createdAt, err := parseJunitTimestamp(tt.timestamp)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, createdAt.Unix())
+			}
+		})
+	}
+}
+
+func TestIngestJunitFiles(t *testing.T) {
+	files := []string{"testdata/junit/jest_retries.xml"}
+
+	results, err := ingestJunitFiles(files, false, false)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, 5, results[0].Total)
+	require.Equal(t, 3, results[0].Failures)
+	require.Equal(t, float64(1687335330), results[0].Timestamp)
+	require.Empty(t, results[0].FailedTests)
+
+	results, err = ingestJunitFiles(files, true, true)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, 3, results[0].Total)
+	require.Equal(t, 1, results[0].Failures)
+	require.Equal(t, 1, results[0].Skipped)
+	require.Equal(t, 1, results[0].Flaky)
+	require.Equal(t, []string{"cart.adds an item"}, results[0].FlakyTests)
+	require.Equal(t, []*JUnitFailedTest{
+		{Name: "removes an item", Classname: "cart", Status: "failed", Message: "AssertionError: expected 0 to equal 1"},
+	}, results[0].FailedTests)
+}
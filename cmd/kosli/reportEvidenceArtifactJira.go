@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const reportEvidenceArtifactJiraShortDesc = `Report Jira evidence for an artifact in a Kosli flow.`
+
+const reportEvidenceArtifactJiraLongDesc = reportEvidenceArtifactJiraShortDesc + `  
+Parses all the commits that went into the artifact, since the git commit of the previous artifact in the flow,
+for Jira issue references. Issue keys are looked up in the commit messages and, if ^--git-provider^ is set, in the titles
+and descriptions of the pull requests of each commit.  
+Each referenced issue is looked up once in Jira, and the evidence lists the issues referenced by each commit
+as well as the commits that reference no issue. Merge commits are not required to reference an issue.
+
+The same rules as for ^kosli report evidence commit jira^ can be applied. With ^--require-issues^,
+each commit in the change range must reference at least one Jira issue.
+Use ^--assert^ to fail the command when the rules are not satisfied.
+` + fingerprintDesc
+
+const reportEvidenceArtifactJiraExample = `
+# report Jira evidence for an artifact using Jira API token
+kosli report evidence artifact jira yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--commit yourArtifactGitCommit \
+	--jira-base-url https://kosli.atlassian.net \
+	--jira-username user@domain.com \
+	--jira-api-token yourJiraAPIToken \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken
+
+# fail if any commit of the artifact does not reference a Jira issue
+kosli report evidence artifact jira yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--commit yourArtifactGitCommit \
+	--jira-base-url https://kosli.atlassian.net \
+	--jira-username user@domain.com \
+	--jira-api-token yourJiraAPIToken \
+	--require-issues \
+	--assert \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken
+`
+
+func newReportEvidenceArtifactJiraCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceArtifactIssuesOptions)
+	o.tracker = jiraIssueTracker
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "jira [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   reportEvidenceArtifactJiraShortDesc,
+		Long:    reportEvidenceArtifactJiraLongDesc,
+		Example: reportEvidenceArtifactJiraExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.validate(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	o.addFlags(cmd)
+	return cmd
+}
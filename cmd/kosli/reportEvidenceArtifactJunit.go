@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/utils"
 	"github.com/spf13/cobra"
 
 	junit "github.com/joshdk/go-junit"
@@ -21,29 +22,62 @@ type EvidenceJUnitPayload struct {
 }
 
 type JUnitResults struct {
-	Name      string  `json:"name"`
-	Failures  int     `json:"failures"`
-	Errors    int     `json:"errors"`
-	Skipped   int     `json:"skipped"`
-	Total     int     `json:"total"`
-	Duration  float64 `json:"duration"`
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Name        string             `json:"name"`
+	Failures    int                `json:"failures"`
+	Errors      int                `json:"errors"`
+	Skipped     int                `json:"skipped"`
+	Total       int                `json:"total"`
+	Duration    float64            `json:"duration"`
+	Timestamp   float64            `json:"timestamp,omitempty"`
+	FailedTests []*JUnitFailedTest `json:"failed_tests,omitempty"`
+	Flaky       int                `json:"flaky,omitempty"`
+	FlakyTests  []string           `json:"flaky_tests,omitempty"`
+}
+
+type JUnitFailedTest struct {
+	Name      string `json:"name"`
+	Classname string `json:"classname,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+type junitResultsOptions struct {
+	testResultsDir   string
+	testResultsFiles []string
+	includeFailures  bool
+	dedupeRetries    bool
 }
 
 type reportEvidenceArtifactJunitOptions struct {
 	fingerprintOptions *fingerprintOptions
+	resultsOptions     junitResultsOptions
 	flowName           string
-	testResultsDir     string
 	userDataFilePath   string
 	payload            EvidenceJUnitPayload
 }
 
+// the maximum length of a failure message included in the evidence
+const junitFailureMessageMaxLength = 1000
+
+// junitTimestampLayouts are the timestamp formats used in <testsuite timestamp="..."> by common
+// test runners: ISO-8601 with or without fractional seconds, timezone offset, or 'T' separator.
+// Timestamps without an offset are considered UTC.
+var junitTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999Z07",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
 const reportEvidenceArtifactJunitShortDesc = `Report JUnit test evidence for an artifact in a Kosli flow.  `
 
 const reportEvidenceArtifactJunitLongDesc = reportEvidenceArtifactJunitShortDesc + `  
-All .xml files from --results-dir are parsed and uploaded to Kosli's evidence vault.  
+All .xml files from --results-dir (or the files matching --results-files) are parsed and uploaded to Kosli's evidence vault.  
 If there are no failing tests and no errors the evidence is reported as compliant. Otherwise the evidence is reported as non-compliant.  
-` + fingerprintDesc
+` + junitResultsDesc + fingerprintDesc
 
 const reportEvidenceArtifactJunitExample = `
 # report JUnit test evidence about a file artifact:
@@ -65,6 +99,19 @@ kosli report evidence artifact junit \
 	--api-token yourAPIToken \
 	--org yourOrgName	\
 	--results-dir yourFolderWithJUnitResults
+
+# report JUnit test evidence about an artifact from the files matching a glob pattern,
+# including the names and messages of failing tests and counting retried tests once:
+kosli report evidence artifact junit \
+	--fingerprint yourSha256 \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--results-files "build/**/TEST-*.xml" \
+	--include-failures \
+	--dedupe-retries
 `
 
 func newReportEvidenceArtifactJunitCmd(out io.Writer) *cobra.Command {
@@ -81,6 +128,11 @@ func newReportEvidenceArtifactJunitCmd(out io.Writer) *cobra.Command {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
 			}
 
+			err = MuXRequiredFlags(cmd, []string{"results-dir", "results-files"}, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
 			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
 			if err != nil {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
@@ -95,8 +147,8 @@ func newReportEvidenceArtifactJunitCmd(out io.Writer) *cobra.Command {
 	ci := WhichCI()
 	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
 	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
-	cmd.Flags().StringVarP(&o.testResultsDir, "results-dir", "R", ".", resultsDirFlag)
 	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addJunitResultsFlags(cmd, &o.resultsOptions)
 
 	addFingerprintFlags(cmd, o.fingerprintOptions)
 	addDryRunFlag(cmd)
@@ -123,13 +175,8 @@ func (o *reportEvidenceArtifactJunitOptions) run(args []string) error {
 		return err
 	}
 
-	o.payload.JUnitResults, err = ingestJunitDir(o.testResultsDir)
-	if err != nil {
-		return err
-	}
-
-	// prepare the files to upload as evidence. We are only interested in the actual Junit XMl files
-	junitFilenames, err := getJunitFilenames(o.testResultsDir)
+	var junitFilenames []string
+	o.payload.JUnitResults, junitFilenames, err = o.resultsOptions.ingest()
 	if err != nil {
 		return err
 	}
@@ -158,15 +205,38 @@ func (o *reportEvidenceArtifactJunitOptions) run(args []string) error {
 	return err
 }
 
-func ingestJunitDir(testResultsDir string) ([]*JUnitResults, error) {
-	results := []*JUnitResults{}
-	suites, err := junit.IngestDir(testResultsDir)
+// ingest parses the JUnit files from the results dir, or matching the results files patterns.
+// It returns the results of each test suite and the JUnit files to upload as evidence.
+func (o *junitResultsOptions) ingest() ([]*JUnitResults, []string, error) {
+	var filenames []string
+	var err error
+	source := fmt.Sprintf("%s directory", o.testResultsDir)
+	if len(o.testResultsFiles) > 0 {
+		source = strings.Join(o.testResultsFiles, ", ")
+		filenames, err = utils.GlobFiles(o.testResultsFiles)
+	} else {
+		// We are only interested in the actual Junit XMl files
+		filenames, err = getJunitFilenames(o.testResultsDir)
+	}
 	if err != nil {
-		return results, err
+		return nil, nil, err
 	}
 
-	if len(suites) == 0 {
-		return results, fmt.Errorf("no tests found in %s directory", testResultsDir)
+	results, err := ingestJunitFiles(filenames, o.includeFailures, o.dedupeRetries)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil, fmt.Errorf("no tests found in %s", source)
+	}
+	return results, filenames, nil
+}
+
+func ingestJunitFiles(filenames []string, includeFailures, dedupeRetries bool) ([]*JUnitResults, error) {
+	results := []*JUnitResults{}
+	suites, err := junit.IngestFiles(filenames)
+	if err != nil {
+		return results, err
 	}
 
 	for _, suite := range suites {
@@ -174,14 +244,9 @@ func ingestJunitDir(testResultsDir string) ([]*JUnitResults, error) {
 		// There is no official schema for the timestamp in the junit xml
 		suite_timestamp := suite.Properties["timestamp"]
 		if suite_timestamp != "" {
-			// This one comes from pytest
-			createdAt, err := time.Parse("2006-01-02T15:04:05.999999", suite_timestamp)
+			createdAt, err := parseJunitTimestamp(suite_timestamp)
 			if err != nil {
-				// This one comes from Ruby minitest
-				createdAt, err = time.Parse("2006-01-02T15:04:05+00:00", suite_timestamp)
-				if err != nil {
-					return results, err
-				}
+				return results, err
 			}
 			timestamp = float64(createdAt.UTC().Unix())
 		} else {
@@ -199,6 +264,14 @@ func ingestJunitDir(testResultsDir string) ([]*JUnitResults, error) {
 			Failures:  suite.Totals.Failed,
 			Timestamp: timestamp,
 		}
+
+		tests := suiteTests(suite)
+		if dedupeRetries {
+			tests = suiteResult.dedupeRetries(tests)
+		}
+		if includeFailures {
+			suiteResult.FailedTests = failedTests(tests)
+		}
 		logger.Debug("parsed <testsuite> result: %+v", suiteResult)
 		results = append(results, suiteResult)
 	}
@@ -206,6 +279,101 @@ func ingestJunitDir(testResultsDir string) ([]*JUnitResults, error) {
 	return results, nil
 }
 
+// parseJunitTimestamp parses a test suite timestamp in any of the junitTimestampLayouts
+func parseJunitTimestamp(timestamp string) (time.Time, error) {
+	for _, layout := range junitTimestampLayouts {
+		createdAt, err := time.Parse(layout, timestamp)
+		if err == nil {
+			return createdAt, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("failed to parse the test suite timestamp '%s'. It is expected to be in ISO-8601 format", timestamp)
+}
+
+// suiteTests returns the tests of a suite and of its nested suites
+func suiteTests(suite junit.Suite) []junit.Test {
+	tests := suite.Tests
+	for _, nested := range suite.Suites {
+		tests = append(tests, suiteTests(nested)...)
+	}
+	return tests
+}
+
+// dedupeRetries counts each test that appears several times in the suite (because it was retried) once,
+// updates the suite totals, and returns the final result of each test.
+// A retried test passes if any of its runs passes, in which case it is reported as flaky.
+// Otherwise, its last run is its final result.
+func (r *JUnitResults) dedupeRetries(tests []junit.Test) []junit.Test {
+	runs := map[string][]junit.Test{}
+	keys := []string{}
+	for _, test := range tests {
+		key := test.Classname + "." + test.Name
+		if _, ok := runs[key]; !ok {
+			keys = append(keys, key)
+		}
+		runs[key] = append(runs[key], test)
+	}
+
+	r.Total, r.Failures, r.Errors, r.Skipped, r.Flaky = 0, 0, 0, 0, 0
+	final := []junit.Test{}
+	for _, key := range keys {
+		testRuns := runs[key]
+		result := testRuns[len(testRuns)-1]
+		passed, failed := false, false
+		for _, run := range testRuns {
+			switch run.Status {
+			case junit.StatusPassed:
+				passed = true
+				result = run
+			case junit.StatusFailed, junit.StatusError:
+				failed = true
+			}
+		}
+		if passed && failed {
+			r.Flaky++
+			r.FlakyTests = append(r.FlakyTests, key)
+		}
+
+		r.Total++
+		switch result.Status {
+		case junit.StatusFailed:
+			r.Failures++
+		case junit.StatusError:
+			r.Errors++
+		case junit.StatusSkipped:
+			r.Skipped++
+		}
+		final = append(final, result)
+	}
+	return final
+}
+
+// failedTests returns the name and message of the failing and erroring tests
+func failedTests(tests []junit.Test) []*JUnitFailedTest {
+	failed := []*JUnitFailedTest{}
+	for _, test := range tests {
+		if test.Status != junit.StatusFailed && test.Status != junit.StatusError {
+			continue
+		}
+		message := test.Message
+		if message == "" {
+			if testError, ok := test.Error.(junit.Error); ok {
+				message = strings.TrimSpace(testError.Body)
+			}
+		}
+		if len(message) > junitFailureMessageMaxLength {
+			message = message[:junitFailureMessageMaxLength] + "..."
+		}
+		failed = append(failed, &JUnitFailedTest{
+			Name:      test.Name,
+			Classname: test.Classname,
+			Status:    string(test.Status),
+			Message:   message,
+		})
+	}
+	return failed
+}
+
 func getJunitFilenames(directory string) ([]string, error) {
 	var filenames []string
 
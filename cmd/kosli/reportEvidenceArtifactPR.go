@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -16,13 +17,84 @@ func newReportEvidenceArtifactPRCmd(out io.Writer) *cobra.Command {
 		Long:    reportEvidenceArtifactPRDesc,
 	}
 
-	// Add subcommands
-	cmd.AddCommand(
-		newReportEvidenceArtifactPRBitbucketCmd(out),
-		newReportEvidenceArtifactPRGithubCmd(out),
-		newReportEvidenceArtifactPRGitlabCmd(out),
-		newReportEvidenceArtifactPRAzureCmd(out),
-	)
+	// Add a subcommand for each git provider
+	for _, provider := range gitProviders {
+		cmd.AddCommand(newReportEvidenceArtifactPRProviderCmd(out, provider))
+	}
+
+	return cmd
+}
+
+func newReportEvidenceArtifactPRProviderCmd(out io.Writer, provider gitProvider) *cobra.Command {
+	o := new(pullRequestArtifactOptions)
+	o.provider = provider
+	o.fingerprintOptions = new(fingerprintOptions)
+	shortDesc := fmt.Sprintf("Report %s %s evidence for an artifact in a Kosli flow.  ", withArticle(provider.DisplayName()), provider.Label())
+	exampleWithFlags := func(providerFlags string) string {
+		return fmt.Sprintf(`kosli report evidence artifact pullrequest %s yourDockerImageName \
+	--artifact-type docker \
+	--build-url https://exampleci.com \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+%s	--commit yourArtifactGitCommit \
+	--org yourOrgName \
+	--api-token yourAPIToken`, provider.Name(), providerFlags)
+	}
+	example := exampleWithFlags(exampleProviderFlags(provider))
+	onPremExample := ""
+	if flags := exampleOnPremProviderFlags(provider); flags != "" {
+		onPremExample = fmt.Sprintf(`
+# report a %s evidence (from an on-prem %s) to kosli for a docker image
+%s
+`, provider.Label(), provider.DisplayName(), exampleWithFlags(flags))
+	}
+	cmd := &cobra.Command{
+		Use:     provider.Name() + " [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Aliases: provider.Aliases(),
+		Short:   shortDesc,
+		Long: shortDesc + fmt.Sprintf(`
+It checks if a %[1]s exists for the artifact (based on its git commit) and reports the %[1]s evidence to the artifact in Kosli.  
+`, provider.Label()) + fingerprintDesc,
+		Example: fmt.Sprintf(`
+# report a %[1]s evidence to kosli for a docker image
+%[2]s
+%[3]s	
+# fail if a %[1]s does not exist for your artifact
+%[2]s \
+	--assert
+`, provider.Label(), example, onPremExample),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = provider.ValidateFlags(cmd)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(out, args)
+		},
+	}
+
+	ci := WhichCI()
+	o.newRetriever = provider.AddFlags(cmd, ci)
+	addArtifactPRFlags(cmd, o, ci)
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append(provider.RequiredFlags(), "commit", "flow", "build-url", "name"))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
 
 	return cmd
 }
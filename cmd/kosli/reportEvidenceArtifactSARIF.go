@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/sarif"
+	"github.com/spf13/cobra"
+)
+
+type EvidenceSARIFPayload struct {
+	GenericEvidencePayload
+	SARIFSummary *sarif.Summary `json:"sarif_summary"`
+	Violations   []string       `json:"violations"`
+}
+
+type sarifPolicyOptions struct {
+	failOn      string
+	maxWarnings int
+}
+
+type reportEvidenceArtifactSARIFOptions struct {
+	fingerprintOptions *fingerprintOptions
+	policyOptions      sarifPolicyOptions
+	flowName           string
+	sarifFilePath      string
+	userDataFilePath   string
+	payload            EvidenceSARIFPayload
+}
+
+const reportEvidenceArtifactSARIFShortDesc = `Report SARIF static analysis evidence for an artifact in a Kosli flow.  `
+
+const reportEvidenceArtifactSARIFLongDesc = reportEvidenceArtifactSARIFShortDesc + `
+The --scan-results SARIF 2.1.0 file is parsed and uploaded to Kosli's evidence vault.
+SARIF is produced by many static analysis tools such as CodeQL, Semgrep, Trivy, Checkov and gosec.
+The findings are summarized per tool, rule and level.
+The evidence is reported as non-compliant if there are results at or above the --fail-on level,
+or more warning-level results than --max-warnings. Otherwise the evidence is reported as compliant.
+` + fingerprintDesc
+
+const reportEvidenceArtifactSARIFExample = `
+# report SARIF evidence about a file artifact:
+kosli report evidence artifact sarif FILE.tgz \
+	--artifact-type file \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results yourResults.sarif
+
+# report SARIF evidence about an artifact using an available Sha256 digest
+# and allow up to 10 warning-level results:
+kosli report evidence artifact sarif \
+	--fingerprint yourSha256 \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results yourResults.sarif \
+	--fail-on error \
+	--max-warnings 10
+`
+
+func newReportEvidenceArtifactSARIFCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceArtifactSARIFOptions)
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "sarif [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   reportEvidenceArtifactSARIFShortDesc,
+		Long:    reportEvidenceArtifactSARIFLongDesc,
+		Example: reportEvidenceArtifactSARIFExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = sarif.ValidateLevel(o.policyOptions.failOn)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	cmd.Flags().StringVarP(&o.sarifFilePath, "scan-results", "R", "", sarifResultsFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addSARIFPolicyFlags(cmd, &o.policyOptions)
+
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"flow", "build-url", "name", "scan-results"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceArtifactSARIFOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/generic", global.Host, global.Org, o.flowName)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = o.policyOptions.evaluate(&o.payload, o.sarifFilePath)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.sarifFilePath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("sarif evidence is reported to artifact: %s", o.payload.ArtifactFingerprint)
+	}
+	return err
+}
+
+func addSARIFPolicyFlags(cmd *cobra.Command, o *sarifPolicyOptions) {
+	cmd.Flags().StringVar(&o.failOn, "fail-on", sarif.LevelError, sarifFailOnFlag)
+	cmd.Flags().IntVar(&o.maxWarnings, "max-warnings", -1, sarifMaxWarningsFlag)
+}
+
+// evaluate parses the SARIF file and sets the summary and the compliance of the payload
+func (o *sarifPolicyOptions) evaluate(payload *EvidenceSARIFPayload, sarifFilePath string) error {
+	summary, err := sarif.ParseFile(sarifFilePath)
+	if err != nil {
+		return err
+	}
+	payload.SARIFSummary = summary
+	payload.Compliant, payload.Violations = summary.Evaluate(sarif.Policy{
+		FailOn:      o.failOn,
+		MaxWarnings: o.maxWarnings,
+	})
+
+	tools := []string{}
+	for _, tool := range summary.Tools {
+		tools = append(tools, tool.Name)
+	}
+	payload.Description = fmt.Sprintf("SARIF results from [%s]: %d error(s), %d warning(s), %d note(s)",
+		strings.Join(tools, ", "), summary.ErrorCount, summary.WarningCount, summary.NoteCount)
+	for _, violation := range payload.Violations {
+		logger.Warning("sarif evidence is non-compliant: %s", violation)
+	}
+	return nil
+}
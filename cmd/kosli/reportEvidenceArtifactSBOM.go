@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/sbom"
+	"github.com/spf13/cobra"
+)
+
+type EvidenceSBOMPayload struct {
+	GenericEvidencePayload
+	SBOMSummary            *sbom.Summary `json:"sbom_summary"`
+	SubjectMatchesArtifact *bool         `json:"subject_matches_artifact,omitempty"`
+}
+
+type reportEvidenceArtifactSBOMOptions struct {
+	fingerprintOptions *fingerprintOptions
+	flowName           string
+	sbomFilePath       string
+	userDataFilePath   string
+	payload            EvidenceSBOMPayload
+}
+
+const reportEvidenceArtifactSBOMShortDesc = `Report SBOM evidence for an artifact in a Kosli flow.  `
+
+const reportEvidenceArtifactSBOMLongDesc = reportEvidenceArtifactSBOMShortDesc + `
+The --sbom-file is validated, summarized and uploaded to Kosli's evidence vault.
+Supported formats are CycloneDX (JSON and XML) and SPDX (JSON and tag-value).
+The summary contains the number of components, their licenses and purls, and the digest of the SBOM subject.
+If the SBOM subject digest matches the artifact fingerprint the evidence is reported as compliant. Otherwise the evidence is reported as non-compliant.
+` + fingerprintDesc
+
+const reportEvidenceArtifactSBOMExample = `
+# report a CycloneDX SBOM evidence about a docker image:
+kosli report evidence artifact sbom yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--sbom-file yourSBOM.cdx.json
+
+# report an SPDX SBOM evidence about an artifact using an available Sha256 digest:
+kosli report evidence artifact sbom \
+	--fingerprint yourSha256 \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--sbom-file yourSBOM.spdx.json
+`
+
+func newReportEvidenceArtifactSBOMCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceArtifactSBOMOptions)
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "sbom [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   reportEvidenceArtifactSBOMShortDesc,
+		Long:    reportEvidenceArtifactSBOMLongDesc,
+		Example: reportEvidenceArtifactSBOMExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	cmd.Flags().StringVar(&o.sbomFilePath, "sbom-file", "", sbomFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"flow", "build-url", "name", "sbom-file"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceArtifactSBOMOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/generic", global.Host, global.Org, o.flowName)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	o.payload.SBOMSummary, err = sbom.ParseFile(o.sbomFilePath)
+	if err != nil {
+		return err
+	}
+
+	matches := o.payload.SBOMSummary.MatchesDigest(o.payload.ArtifactFingerprint)
+	o.payload.SubjectMatchesArtifact = &matches
+	o.payload.Compliant = matches
+	o.payload.Description = sbomEvidenceDescription(o.payload.SBOMSummary)
+	if !matches {
+		logger.Warning("the SBOM subject digest '%s' does not match the artifact fingerprint: %s",
+			o.payload.SBOMSummary.SubjectDigest, o.payload.ArtifactFingerprint)
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.sbomFilePath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("sbom evidence is reported to artifact: %s", o.payload.ArtifactFingerprint)
+	}
+	return err
+}
+
+// sbomEvidenceDescription returns a one-line description of an SBOM summary
+func sbomEvidenceDescription(summary *sbom.Summary) string {
+	return fmt.Sprintf("%s SBOM (spec version %s) with %d components and %d licenses",
+		summary.Format, summary.SpecVersion, summary.ComponentCount, len(summary.Licenses))
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceArtifactSignaturesOptions struct {
+	signatureOptions
+	fingerprintOptions *fingerprintOptions
+	flowName           string
+	commit             string
+	payload            SignatureEvidencePayload
+}
+
+const reportEvidenceArtifactSignaturesShortDesc = `Report commit signature evidence for an artifact in a Kosli flow.`
+
+const reportEvidenceArtifactSignaturesLongDesc = reportEvidenceArtifactSignaturesShortDesc + `  
+Verifies the GPG or SSH signatures of all the commits that went into the artifact, since the git commit
+of the previous artifact in the flow, against trusted keys, locally in the git repository.  
+GPG signatures are verified against the armored public keys in ^--gpg-keyring^, and SSH signatures
+against the keys in the SSH allowed signers file of ^--allowed-signers^ (the file used by git's ^gpg.ssh.allowedSignersFile^).  
+The evidence records the signer identity and key of each commit, lists the unsigned and untrusted commits,
+and is compliant if all commits are signed by a trusted key. Unsigned and untrusted commits are logged
+as warnings, or fail the command with ^--assert^.
+` + fingerprintDesc
+
+const reportEvidenceArtifactSignaturesExample = `
+# report commit signature evidence for an artifact:
+kosli report evidence artifact signatures yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--commit yourArtifactGitCommit \
+	--allowed-signers /path/to/allowed_signers \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken
+
+# fail if any commit of the artifact is not signed with a trusted GPG or SSH key:
+kosli report evidence artifact signatures yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--commit yourArtifactGitCommit \
+	--gpg-keyring /path/to/trusted-keys.asc \
+	--allowed-signers /path/to/allowed_signers \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken \
+	--assert
+`
+
+func newReportEvidenceArtifactSignaturesCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceArtifactSignaturesOptions)
+	o.fingerprintOptions = new(fingerprintOptions)
+	cmd := &cobra.Command{
+		Use:     "signatures [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short:   reportEvidenceArtifactSignaturesShortDesc,
+		Long:    reportEvidenceArtifactSignaturesLongDesc,
+		Example: reportEvidenceArtifactSignaturesExample,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = validateSignatureFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVar(&o.commit, "commit", DefaultValue(ci, "git-commit"), commitSignaturesFlag)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	addSignatureFlags(cmd, &o.signatureOptions)
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "flow", "build-url", "name"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceArtifactSignaturesOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/signatures", global.Host, global.Org, o.flowName)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	gv, err := gitview.New(o.srcRepoRoot)
+	if err != nil {
+		return err
+	}
+
+	o.commit, err = gv.ResolveRevision(o.commit)
+	if err != nil {
+		return err
+	}
+
+	commits, err := artifactChangeRange(gv, o.flowName, o.payload.ArtifactFingerprint, o.commit)
+	if err != nil {
+		return err
+	}
+	commitSHAs := []string{}
+	for _, commit := range commits {
+		commitSHAs = append(commitSHAs, commit.Sha1)
+	}
+	logger.Debug("verifying the signatures of %d commit(s)", len(commitSHAs))
+
+	err = o.verifySignatures(gv, commitSHAs, &o.payload)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, o.evidencePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("commit signature evidence is reported to artifact: %s", o.payload.ArtifactFingerprint)
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/vulnscan"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceArtifactVulnScanOptions struct {
+	scanner            *vulnerabilityScanner
+	fingerprintOptions *fingerprintOptions
+	policyOptions      vulnerabilityPolicyOptions
+	flowName           string
+	scanResultsPath    string
+	userDataFilePath   string
+	payload            EvidenceVulnerabilityScanPayload
+}
+
+func newReportEvidenceArtifactVulnScanCmd(out io.Writer, scanner *vulnerabilityScanner) *cobra.Command {
+	o := new(reportEvidenceArtifactVulnScanOptions)
+	o.scanner = scanner
+	o.fingerprintOptions = new(fingerprintOptions)
+	shortDesc := fmt.Sprintf("Report %s vulnerability scan evidence for an artifact in a Kosli flow.  ", scanner.displayName)
+	cmd := &cobra.Command{
+		Use:   scanner.name + " [IMAGE-NAME | FILE-PATH | DIR-PATH]",
+		Short: shortDesc,
+		Long: shortDesc + fmt.Sprintf(`
+The --scan-results %s JSON report is parsed and uploaded to Kosli's evidence vault.
+The vulnerabilities are counted by severity, and by whether a fix is available.
+If no vulnerabilities at or above --fail-on-severity are detected the evidence is reported as compliant.
+Otherwise the evidence is reported as non-compliant. By default, any vulnerability makes the evidence non-compliant.
+`, scanner.displayName) + fingerprintDesc,
+		Example: fmt.Sprintf(`
+# report %[1]s vulnerability scan evidence about a docker image:
+kosli report evidence artifact %[2]s yourDockerImageName \
+	--artifact-type docker \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results your%[1]sJSONReport
+
+# report %[1]s vulnerability scan evidence about an artifact using an available Sha256 digest,
+# and only fail on fixable high or critical vulnerabilities:
+kosli report evidence artifact %[2]s \
+	--fingerprint yourSha256 \
+	--name yourEvidenceName \
+	--flow yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results your%[1]sJSONReport \
+	--fail-on-severity high \
+	--ignore-unfixed
+`, scanner.displayName, scanner.name),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			if o.policyOptions.failOnSeverity != "" {
+				err = vulnscan.ValidateSeverity(o.policyOptions.failOnSeverity)
+				if err != nil {
+					return ErrorBeforePrintingUsage(cmd, err.Error())
+				}
+			}
+
+			err = ValidateArtifactArg(args, o.fingerprintOptions.artifactType, o.payload.ArtifactFingerprint, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return ValidateRegistryFlags(cmd, o.fingerprintOptions)
+
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addArtifactEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.flowName, "flow", "f", "", flowNameFlag)
+	cmd.Flags().StringVarP(&o.scanResultsPath, "scan-results", "R", "", scanner.resultsFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addVulnerabilityPolicyFlags(cmd, &o.policyOptions)
+
+	addFingerprintFlags(cmd, o.fingerprintOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"flow", "build-url", "name", "scan-results"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceArtifactVulnScanOptions) run(args []string) error {
+	var err error
+	if o.payload.ArtifactFingerprint == "" {
+		o.payload.ArtifactFingerprint, err = GetSha256Digest(args[0], o.fingerprintOptions, logger)
+		if err != nil {
+			return err
+		}
+	}
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/artifact/%s/generic", global.Host, global.Org, o.flowName)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	summary, err := o.scanner.parse(o.scanResultsPath)
+	if err != nil {
+		return err
+	}
+	warnOnImageDigestMismatch(summary, o.payload.ArtifactFingerprint)
+	o.policyOptions.evaluate(&o.payload, summary)
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.scanResultsPath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("%s scan evidence is reported to artifact: %s", o.scanner.name, o.payload.ArtifactFingerprint)
+	}
+	return err
+}
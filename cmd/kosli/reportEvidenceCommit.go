@@ -21,8 +21,19 @@ func newReportEvidenceCommitCmd(out io.Writer) *cobra.Command {
 		newReportEvidenceCommitGenericCmd(out),
 		newReportEvidenceCommitJunitCmd(out),
 		newReportEvidenceCommitSnykCmd(out),
+		newReportEvidenceCommitSBOMCmd(out),
+		newReportEvidenceCommitSARIFCmd(out),
+		newReportEvidenceCommitVulnScanCmd(out, trivyScanner),
+		newReportEvidenceCommitVulnScanCmd(out, grypeScanner),
+		newReportEvidenceCommitCoverageCmd(out),
 		newReportEvidenceCommitJiraCmd(out),
+		newReportEvidenceCommitSignaturesCmd(out),
+		newReportEvidenceCommitBranchProtectionCmd(out),
 	)
+	// Add a subcommand for each issue tracker
+	for _, tracker := range issueTrackers {
+		cmd.AddCommand(newReportEvidenceCommitIssuesCmd(out, tracker))
+	}
 
 	return cmd
 }
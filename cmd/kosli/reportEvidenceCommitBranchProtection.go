@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+const reportEvidenceCommitBranchProtectionDesc = `All Kosli commands to report branch protection evidence.`
+
+func newReportEvidenceCommitBranchProtectionCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "branch-protection",
+		Aliases: []string{"bp"},
+		Short:   reportEvidenceCommitBranchProtectionDesc,
+		Long:    reportEvidenceCommitBranchProtectionDesc,
+	}
+
+	// Add a subcommand for each git provider that can read branch protection
+	for _, provider := range gitProviders {
+		if provider.BranchProtectionHelp() != "" {
+			cmd.AddCommand(newReportEvidenceCommitBranchProtectionProviderCmd(out, provider))
+		}
+	}
+
+	return cmd
+}
+
+func newReportEvidenceCommitBranchProtectionProviderCmd(out io.Writer, provider gitProvider) *cobra.Command {
+	o := new(branchProtectionCommitOptions)
+	o.payload.GitProvider = provider.Name()
+	shortDesc := fmt.Sprintf("Report %s branch protection evidence for a git commit in Kosli flows.  ", provider.DisplayName())
+	var newRetriever func() types.PRRetriever
+	cmd := &cobra.Command{
+		Use:     provider.Name(),
+		Aliases: provider.Aliases(),
+		Short:   shortDesc,
+		Long: shortDesc + `
+It gets the protection rules of --branch and the status of the checks of the commit,
+and reports them as evidence to the commit in Kosli.
+The evidence is reported as compliant if the branch is protected, requires pull request approvals
+and status checks, does not allow force pushes, and all the required checks passed on the commit.
+Otherwise the evidence is reported as non-compliant, with the rules that were not satisfied.
+The evidence records the protection of the branch when the command runs, not when the commit was merged.
+` + provider.BranchProtectionHelp(),
+		Example: fmt.Sprintf(`
+# report branch protection evidence for a commit merged to main
+kosli report evidence commit branch-protection %s \
+	--commit yourGitCommitSha1 \
+	--branch main \
+%s	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken
+`, provider.Name(), exampleProviderFlags(provider)),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = provider.ValidateFlags(cmd)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.retriever = newRetriever().(types.BranchProtectionRetriever)
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	newRetriever = provider.AddFlags(cmd, ci)
+	addCommitBranchProtectionFlags(cmd, o, ci)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append(provider.RequiredFlags(), "commit", "branch", "build-url", "name"))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
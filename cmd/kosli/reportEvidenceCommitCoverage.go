@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitCoverageOptions struct {
+	thresholdOptions  coverageThresholdOptions
+	coverageFilePaths []string
+	userDataFilePath  string
+	payload           EvidenceCoveragePayload
+}
+
+const reportEvidenceCommitCoverageShortDesc = `Report code coverage evidence for a commit in Kosli flows.  `
+
+const reportEvidenceCommitCoverageLongDesc = reportEvidenceCommitCoverageShortDesc + `
+The --coverage-files are parsed and uploaded to Kosli's evidence vault.
+Cobertura XML, LCOV, JaCoCo XML and Go coverprofile reports are supported.
+Line and branch coverage are computed in total and per package. When several reports are provided, they are combined.
+If the coverage is at least --min-coverage (and --min-branch-coverage) the evidence is reported as compliant.
+Otherwise the evidence is reported as non-compliant.
+`
+
+const reportEvidenceCommitCoverageExample = `
+# report code coverage evidence for a commit related to one Kosli flow:
+kosli report evidence commit coverage \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--coverage-files coverage.out
+
+# report code coverage evidence for a commit related to multiple Kosli flows,
+# requiring at least 80% line coverage and 60% branch coverage:
+kosli report evidence commit coverage \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--coverage-files jacoco.xml \
+	--min-coverage 80 \
+	--min-branch-coverage 60
+`
+
+func newReportEvidenceCommitCoverageCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceCommitCoverageOptions)
+	cmd := &cobra.Command{
+		Use:     "coverage",
+		Short:   reportEvidenceCommitCoverageShortDesc,
+		Long:    reportEvidenceCommitCoverageLongDesc,
+		Example: reportEvidenceCommitCoverageExample,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringSliceVar(&o.coverageFilePaths, "coverage-files", []string{}, coverageFilesFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addCoverageThresholdFlags(cmd, &o.thresholdOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "build-url", "name", "coverage-files"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceCommitCoverageOptions) run(args []string) error {
+	var err error
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/generic", global.Host, global.Org)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = o.thresholdOptions.evaluate(&o.payload, o.coverageFilePaths)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, o.coverageFilePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("coverage evidence is reported to commit: %s", o.payload.CommitSHA)
+	}
+	return err
+}
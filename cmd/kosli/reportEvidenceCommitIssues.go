@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitIssuesOptions struct {
+	issueOptions
+	payload TypedEvidencePayload
+}
+
+func newReportEvidenceCommitIssuesCmd(out io.Writer, tracker issueTracker) *cobra.Command {
+	o := new(reportEvidenceCommitIssuesOptions)
+	o.tracker = tracker
+	shortDesc := fmt.Sprintf("Report %s evidence for a commit in Kosli flows.", tracker.DisplayName())
+	example := fmt.Sprintf(`kosli report evidence commit %s \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+%s	--flows yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName`, tracker.Name(), exampleTrackerFlags(tracker))
+	cmd := &cobra.Command{
+		Use:   tracker.Name(),
+		Short: shortDesc,
+		Long: shortDesc + fmt.Sprintf(`  
+Parses the given commit's message or current branch name for %[1]s issue references of the form:  
+'%[2]s'.  
+Use --issue-key-patterns to match issue references of another form.  
+With --git-provider, the titles and descriptions of the pull requests of the commit are also parsed.
+The branch name is only parsed when no issue references are found elsewhere.
+
+The found issue references will be checked against %[1]s to confirm their existence.
+The evidence is reported in all cases, and its compliance status depends on referencing
+existing %[1]s issues.  
+The issue status, type and fix versions can be checked with --require-issues, --allowed-statuses
+and --require-fix-version-for-types. Issues that do not comply are logged as warnings, or fail
+the command with --assert.
+`, tracker.DisplayName(), tracker.KeyDesc()),
+		Example: fmt.Sprintf(`
+# report %[1]s evidence for a commit related to one Kosli flow:
+%[2]s
+
+# fail if the commit does not reference a %[1]s issue which is done:
+%[2]s \
+	--require-issues \
+	--allowed-statuses Done \
+	--assert
+`, tracker.DisplayName(), example),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.validate(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	o.addFlags(cmd)
+	return cmd
+}
+
+func (o *reportEvidenceCommitIssuesOptions) addFlags(cmd *cobra.Command) {
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload, ci)
+	addIssueFlags(cmd, &o.issueOptions, ci)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append([]string{"commit", "build-url", "name"}, o.tracker.RequiredFlags()...))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+}
+
+func (o *reportEvidenceCommitIssuesOptions) validate(cmd *cobra.Command) error {
+	err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+	if err != nil {
+		return ErrorBeforePrintingUsage(cmd, err.Error())
+	}
+	return o.tracker.ValidateFlags(cmd)
+}
+
+func (o *reportEvidenceCommitIssuesOptions) run(args []string) error {
+	var err error
+
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/%s", global.Host, global.Org, o.evidenceType())
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	gv, err := gitview.New(o.srcRepoRoot)
+	if err != nil {
+		return err
+	}
+
+	o.payload.CommitSHA, err = gv.ResolveRevision(o.payload.CommitSHA)
+	if err != nil {
+		return err
+	}
+
+	finder, err := o.newIssueKeyFinder()
+	if err != nil {
+		return err
+	}
+	commitInfo, err := gv.GetCommitInfoFromCommitSHA(o.payload.CommitSHA)
+	if err != nil {
+		return err
+	}
+	issueIDs, err := finder.find(commitInfo, true)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("the following %s references are found: %v", o.tracker.DisplayName(), issueIDs)
+
+	issues, issueLog, err := o.getIssues(issueIDs)
+	if err != nil {
+		return err
+	}
+
+	_, violations := o.rules.Evaluate(issues)
+	err = o.checkIssueViolations("commit "+o.payload.CommitSHA, violations)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.newIssueEvidencePayload(o.payload, issues, violations), o.evidencePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("%s evidence is reported to commit: %s", o.tracker.DisplayName(), o.payload.CommitSHA)
+		logger.Info("  Issues references reported: %s", issueLog)
+	}
+	return err
+}
@@ -11,7 +11,7 @@ import (
 )
 
 type reportEvidenceCommitJunitOptions struct {
-	testResultsDir   string
+	resultsOptions   junitResultsOptions
 	userDataFilePath string
 	payload          EvidenceJUnitPayload
 }
@@ -19,9 +19,9 @@ type reportEvidenceCommitJunitOptions struct {
 const reportEvidenceCommitJunitShortDesc = `Report JUnit test evidence for a commit in Kosli flows.  `
 
 const reportEvidenceCommitJunitLongDesc = reportEvidenceCommitJunitShortDesc + `  
-All .xml files from --results-dir are parsed and uploaded to Kosli's evidence vault.  
-If there are no failing tests and no errors the evidence is reported as compliant. Otherwise the evidence is reported as non-compliant.
-`
+All .xml files from --results-dir (or the files matching --results-files) are parsed and uploaded to Kosli's evidence vault.  
+If there are no failing tests and no errors the evidence is reported as compliant. Otherwise the evidence is reported as non-compliant.  
+` + junitResultsDesc
 
 const reportEvidenceCommitJunitExample = `
 # report JUnit test evidence for a commit related to one Kosli flow:
@@ -58,6 +58,11 @@ func newReportEvidenceCommitJunitCmd(out io.Writer) *cobra.Command {
 			if err != nil {
 				return ErrorBeforePrintingUsage(cmd, err.Error())
 			}
+
+			err = MuXRequiredFlags(cmd, []string{"results-dir", "results-files"}, false)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -67,8 +72,8 @@ func newReportEvidenceCommitJunitCmd(out io.Writer) *cobra.Command {
 
 	ci := WhichCI()
 	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
-	cmd.Flags().StringVarP(&o.testResultsDir, "results-dir", "R", ".", resultsDirFlag)
 	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addJunitResultsFlags(cmd, &o.resultsOptions)
 	addDryRunFlag(cmd)
 
 	err := RequireFlags(cmd, []string{"commit", "build-url", "name"})
@@ -87,13 +92,8 @@ func (o *reportEvidenceCommitJunitOptions) run(args []string) error {
 		return err
 	}
 
-	o.payload.JUnitResults, err = ingestJunitDir(o.testResultsDir)
-	if err != nil {
-		return err
-	}
-
-	// prepare the files to upload as evidence. We are only interested in the actual Junit XMl files
-	junitFilenames, err := getJunitFilenames(o.testResultsDir)
+	var junitFilenames []string
+	o.payload.JUnitResults, junitFilenames, err = o.resultsOptions.ingest()
 	if err != nil {
 		return err
 	}
@@ -59,6 +59,26 @@ func (suite *CommitEvidenceJUnitCommandTestSuite) TestCommitEvidenceJUnitCommand
 			wantError: true,
 			golden:    "Error: no tests found in testdata/folder1 directory\n",
 		},
+		{
+			name: "report JUnit test evidence works with --results-files glob patterns, --include-failures and --dedupe-retries",
+			cmd: `report evidence commit junit --commit af28ccdeffdfa67f5c5a88be209e94cc4742de3c --name junit-result --flows ` + suite.flowNames + `
+			          --build-url example.com --results-files "testdata/junit/*.xml" --include-failures --dedupe-retries` + suite.defaultKosliArguments,
+			golden: "junit test evidence is reported to commit: af28ccdeffdfa67f5c5a88be209e94cc4742de3c\n",
+		},
+		{
+			name: "report JUnit test evidence with --results-files that do not match any file",
+			cmd: `report evidence commit junit --commit af28ccdeffdfa67f5c5a88be209e94cc4742de3c --name junit-result --flows ` + suite.flowNames + `
+			          --build-url example.com --results-files testdata/junit/*.json` + suite.defaultKosliArguments,
+			wantError: true,
+			golden:    "Error: no files match the pattern testdata/junit/*.json\n",
+		},
+		{
+			name: "report JUnit test evidence with both --results-dir and --results-files",
+			cmd: `report evidence commit junit --commit af28ccdeffdfa67f5c5a88be209e94cc4742de3c --name junit-result --flows ` + suite.flowNames + `
+			          --build-url example.com --results-dir testdata --results-files testdata/report.xml` + suite.defaultKosliArguments,
+			wantError: true,
+			golden:    "Error: only one of --results-dir, --results-files is allowed\n",
+		},
 		{
 			name: "report JUnit test evidence with missing name flag",
 			cmd: `report evidence commit junit --commit af28ccdeffdfa67f5c5a88be209e94cc4742de3c --flows ` + suite.flowNames + `
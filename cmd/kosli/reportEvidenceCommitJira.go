@@ -1,29 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"io"
-	"net/http"
-	"os"
-	"strings"
 
-	"github.com/kosli-dev/cli/internal/gitview"
-	"github.com/kosli-dev/cli/internal/jira"
-	"github.com/kosli-dev/cli/internal/requests"
 	"github.com/spf13/cobra"
 )
 
-type reportEvidenceCommitJiraOptions struct {
-	userDataFilePath string
-	evidencePaths    []string
-	baseURL          string
-	username         string
-	apiToken         string
-	pat              string
-	srcRepoRoot      string
-	payload          JiraEvidencePayload
-}
-
 const reportEvidenceCommitJiraShortDesc = `Report Jira evidence for a commit in Kosli flows.`
 
 const reportEvidenceCommitJiraLongDesc = reportEvidenceCommitJiraShortDesc + `  
@@ -31,10 +13,17 @@ Parses the given commit's message or current branch name for Jira issue referenc
 form:  
 'at least 2 characters long, starting with an uppercase letter project key followed by
 dash and one or more digits'. 
+Use --project-keys to only match the issues of some Jira projects, and --issue-key-patterns
+to match issue references of another form.  
+With --git-provider, the titles and descriptions of the pull requests of the commit are also parsed.
+The branch name is only parsed when no issue references are found elsewhere.
 
 The found issue references will be checked against Jira to confirm their existence.
 The evidence is reported in all cases, and its compliance status depends on referencing
 existing Jira issues.  
+The issue status, type and fix versions can be checked with --require-issues, --allowed-statuses
+and --require-fix-version-for-types. Issues that do not comply are logged as warnings, or fail
+the command with --assert.  
 If you have wrong Jira credentials or wrong Jira-base-url it will be reported as non existing Jira issue.
 This is because Jira returns same 404 error code in all cases.
 `
@@ -75,10 +64,32 @@ kosli report evidence commit jira \
 	--api-token yourAPIToken \
 	--org yourOrgName \
 	--user-data /path/to/json/file.json
+
+# fail if the commit does not reference an EX issue which is in progress or done, 
+# looking in the titles and descriptions of its Github pull requests too:
+kosli report evidence commit jira \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--jira-base-url https://kosli.atlassian.net \
+	--jira-username user@domain.com \
+	--jira-api-token yourJiraAPIToken \
+	--project-keys EX \
+	--require-issues \
+	--allowed-statuses "In Progress,Done" \
+	--git-provider github \
+	--github-token yourGithubToken \
+	--github-org yourGithubOrg \
+	--repository yourGithubGitRepository \
+	--flows yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName \
+	--assert
 `
 
 func newReportEvidenceCommitJiraCmd(out io.Writer) *cobra.Command {
-	o := new(reportEvidenceCommitJiraOptions)
+	o := new(reportEvidenceCommitIssuesOptions)
+	o.tracker = jiraIssueTracker
 	cmd := &cobra.Command{
 		Use:     "jira",
 		Short:   reportEvidenceCommitJiraShortDesc,
@@ -86,120 +97,13 @@ func newReportEvidenceCommitJiraCmd(out io.Writer) *cobra.Command {
 		Example: reportEvidenceCommitJiraExample,
 		Args:    cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
-			if err != nil {
-				return ErrorBeforePrintingUsage(cmd, err.Error())
-			}
-
-			err = MuXRequiredFlags(cmd, []string{"jira-pat", "jira-api-token"}, true)
-			if err != nil {
-				return err
-			}
-
-			err = MuXRequiredFlags(cmd, []string{"jira-pat", "jira-username"}, true)
-			if err != nil {
-				return err
-			}
-
-			return nil
+			return o.validate(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.run(args)
 		},
 	}
 
-	ci := WhichCI()
-	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
-	cmd.Flags().StringVar(&o.baseURL, "jira-base-url", "", jiraBaseUrlFlag)
-	cmd.Flags().StringVar(&o.username, "jira-username", "", jiraUsernameFlag)
-	cmd.Flags().StringVar(&o.apiToken, "jira-api-token", "", jiraAPITokenFlag)
-	cmd.Flags().StringVar(&o.pat, "jira-pat", "", jiraPATFlag)
-	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
-	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
-	cmd.Flags().StringSliceVarP(&o.evidencePaths, "evidence-paths", "e", []string{}, evidencePathsFlag)
-	addDryRunFlag(cmd)
-
-	err := RequireFlags(cmd, []string{"commit", "build-url", "name", "jira-base-url"})
-	if err != nil {
-		logger.Error("failed to configure required flags: %v", err)
-	}
-
+	o.addFlags(cmd)
 	return cmd
 }
-
-func (o *reportEvidenceCommitJiraOptions) run(args []string) error {
-	var err error
-
-	o.baseURL = strings.TrimSuffix(o.baseURL, "/")
-
-	jc := jira.NewJiraConfig(o.baseURL, o.username, o.apiToken, o.pat)
-
-	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/jira", global.Host, global.Org)
-	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
-	if err != nil {
-		return err
-	}
-
-	gv, err := gitview.New(o.srcRepoRoot)
-	if err != nil {
-		return err
-	}
-
-	o.payload.CommitSHA, err = gv.ResolveRevision(o.payload.CommitSHA)
-	if err != nil {
-		return err
-	}
-
-	o.payload.JiraResults = []*jira.JiraIssueInfo{}
-
-	// Jira issue keys consist of [project-key]-[sequential-number]
-	// project key must be at least 2 characters long and start with an uppercase letter
-	// more info: https://support.atlassian.com/jira-software-cloud/docs/what-is-an-issue/#Workingwithissues-Projectandissuekeys
-	jiraIssueKeyPattern := `[A-Z][A-Z0-9]{1,9}-[0-9]+`
-
-	issueIDs, err := gv.MatchPatternInCommitMessageORBranchName(jiraIssueKeyPattern, o.payload.CommitSHA)
-	if err != nil {
-		return err
-	}
-
-	logger.Debug("the following Jira references are found in commit message or branch name: %v", issueIDs)
-
-	issueLog := ""
-	for _, issueID := range issueIDs {
-		result, err := jc.GetJiraIssueInfo(issueID)
-		if err != nil {
-			return err
-		}
-		o.payload.JiraResults = append(o.payload.JiraResults, result)
-		issueExistLog := "issue not found"
-		if result.IssueExists {
-			issueExistLog = "issue found"
-		}
-		issueLog += fmt.Sprintf("\n\t%s: %s", result.IssueID, issueExistLog)
-	}
-
-	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, o.evidencePaths)
-	// if we created a tar package, remove it after uploading it
-	if cleanupNeeded {
-		defer os.Remove(evidencePath)
-	}
-
-	if err != nil {
-		return err
-	}
-
-	reqParams := &requests.RequestParams{
-		Method:   http.MethodPost,
-		URL:      url,
-		Form:     form,
-		DryRun:   global.DryRun,
-		Password: global.ApiToken,
-	}
-
-	_, err = kosliClient.Do(reqParams)
-	if err == nil && !global.DryRun {
-		logger.Info("Jira evidence is reported to commit: %s", o.payload.CommitSHA)
-		logger.Info("  Issues references reported: %s", issueLog)
-	}
-	return err
-}
@@ -151,6 +151,57 @@ func (suite *CommitEvidenceJiraCommandTestSuite) TestCommitEvidenceJiraCommandCm
 					--build-url example.com %s`, suite.tmpDir, suite.defaultKosliArguments),
 			golden: "Error: at least one of --jira-pat, --jira-username is required\n",
 		},
+		{
+			name: "report Jira commit evidence only reports the issues of --project-keys",
+			cmd: fmt.Sprintf(`report evidence commit jira --name jira-validation
+				--jira-base-url https://kosli-test.atlassian.net  --jira-username tore@kosli.com
+				--project-keys EX
+				--repo-root %s
+				--build-url example.com %s`, suite.tmpDir, suite.defaultKosliArguments),
+			goldenRegex: "Jira evidence is reported to commit: [0-9a-f]{40}\n.*Issues references reported:.*\n.*EX-1: issue found\n$",
+			additionalConfig: jiraTestsAdditionalConfig{
+				commitMessage: "EX-1 SAMI-1 test commit",
+			},
+		},
+		{
+			wantError: true,
+			name:      "report Jira commit evidence with --assert fails when an issue does not exist",
+			cmd: fmt.Sprintf(`report evidence commit jira --name jira-validation
+				--jira-base-url https://kosli-test.atlassian.net  --jira-username tore@kosli.com
+				--assert
+				--repo-root %s
+				--build-url example.com %s`, suite.tmpDir, suite.defaultKosliArguments),
+			goldenRegex: "Error: Jira issue references of commit [0-9a-f]{40} do not comply:\nSAMI-1: issue not found\n",
+			additionalConfig: jiraTestsAdditionalConfig{
+				commitMessage: "EX-1 SAMI-1 test commit",
+			},
+		},
+		{
+			wantError: true,
+			name:      "report Jira commit evidence with --assert and --require-issues fails when there are no issue references",
+			cmd: fmt.Sprintf(`report evidence commit jira --name jira-validation
+				--jira-base-url https://kosli-test.atlassian.net  --jira-username tore@kosli.com
+				--assert --require-issues
+				--repo-root %s
+				--build-url example.com %s`, suite.tmpDir, suite.defaultKosliArguments),
+			goldenRegex: "Error: Jira issue references of commit [0-9a-f]{40} do not comply:\nno issue references found\n",
+			additionalConfig: jiraTestsAdditionalConfig{
+				commitMessage: "test commit without references",
+			},
+		},
+		{
+			wantError: true,
+			name:      "report Jira commit evidence with an unknown --git-provider fails",
+			cmd: fmt.Sprintf(`report evidence commit jira --name jira-validation
+				--jira-base-url https://kosli-test.atlassian.net  --jira-username tore@kosli.com
+				--git-provider svn
+				--repo-root %s
+				--build-url example.com %s`, suite.tmpDir, suite.defaultKosliArguments),
+			golden: "Error: svn is not a valid git provider. Valid git providers are: [bitbucket github gitlab azure gitea]\n",
+			additionalConfig: jiraTestsAdditionalConfig{
+				commitMessage: "EX-1 test commit",
+			},
+		},
 		{
 			wantError: true,
 			name:      "report Jira commit evidence with missing --commit fails",
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -16,13 +17,76 @@ func newReportEvidenceCommitPRCmd(out io.Writer) *cobra.Command {
 		Long:    reportEvidenceCommitPRDesc,
 	}
 
-	// Add subcommands
-	cmd.AddCommand(
-		newReportEvidenceCommitPRBitbucketCmd(out),
-		newReportEvidenceCommitPRGithubCmd(out),
-		newReportEvidenceCommitPRGitlabCmd(out),
-		newReportEvidenceCommitPRAzureCmd(out),
-	)
+	// Add a subcommand for each git provider
+	for _, provider := range gitProviders {
+		cmd.AddCommand(newReportEvidenceCommitPRProviderCmd(out, provider))
+	}
+
+	return cmd
+}
+
+func newReportEvidenceCommitPRProviderCmd(out io.Writer, provider gitProvider) *cobra.Command {
+	o := new(pullRequestCommitOptions)
+	o.provider = provider
+	shortDesc := fmt.Sprintf("Report %s %s evidence for a git commit in Kosli flows.  ", provider.DisplayName(), provider.Label())
+	exampleWithFlags := func(providerFlags string) string {
+		return fmt.Sprintf(`kosli report evidence commit pullrequest %s \
+	--commit yourGitCommitSha1 \
+%s	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--org yourOrgName \
+	--api-token yourAPIToken`, provider.Name(), providerFlags)
+	}
+	example := exampleWithFlags(exampleProviderFlags(provider))
+	onPremExample := ""
+	if flags := exampleOnPremProviderFlags(provider); flags != "" {
+		onPremExample = fmt.Sprintf(`
+# report a %s commit evidence (from an on-prem %s) to Kosli
+%s
+`, provider.Label(), provider.DisplayName(), exampleWithFlags(flags))
+	}
+	cmd := &cobra.Command{
+		Use:     provider.Name(),
+		Aliases: provider.Aliases(),
+		Short:   shortDesc,
+		Long: shortDesc + fmt.Sprintf(`
+It checks if a %[1]s exists for a commit and report the %[1]s evidence to the commit in Kosli. 
+`, provider.Label()),
+		Example: fmt.Sprintf(`
+# report a %[1]s commit evidence to Kosli
+%[2]s
+%[3]s	
+# fail if a %[1]s does not exist for your commit
+%[2]s \
+	--assert
+`, provider.Label(), example, onPremExample),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = provider.ValidateFlags(cmd)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	o.newRetriever = provider.AddFlags(cmd, ci)
+	addCommitPRFlags(cmd, o, ci)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, append(provider.RequiredFlags(), "commit", "build-url", "name"))
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
 
 	return cmd
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/sarif"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitSARIFOptions struct {
+	policyOptions    sarifPolicyOptions
+	sarifFilePath    string
+	userDataFilePath string
+	payload          EvidenceSARIFPayload
+}
+
+const reportEvidenceCommitSARIFShortDesc = `Report SARIF static analysis evidence for a commit in Kosli flows.  `
+
+const reportEvidenceCommitSARIFLongDesc = reportEvidenceCommitSARIFShortDesc + `
+The --scan-results SARIF 2.1.0 file is parsed and uploaded to Kosli's evidence vault.
+SARIF is produced by many static analysis tools such as CodeQL, Semgrep, Trivy, Checkov and gosec.
+The findings are summarized per tool, rule and level.
+The evidence is reported as non-compliant if there are results at or above the --fail-on level,
+or more warning-level results than --max-warnings. Otherwise the evidence is reported as compliant.
+`
+
+const reportEvidenceCommitSARIFExample = `
+# report SARIF evidence for a commit related to one Kosli flow:
+kosli report evidence commit sarif \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results yourResults.sarif
+
+# report SARIF evidence for a commit related to multiple Kosli flows
+# and fail on any warning-level result:
+kosli report evidence commit sarif \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results yourResults.sarif \
+	--fail-on warning
+`
+
+func newReportEvidenceCommitSARIFCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceCommitSARIFOptions)
+	cmd := &cobra.Command{
+		Use:     "sarif",
+		Short:   reportEvidenceCommitSARIFShortDesc,
+		Long:    reportEvidenceCommitSARIFLongDesc,
+		Example: reportEvidenceCommitSARIFExample,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			err = sarif.ValidateLevel(o.policyOptions.failOn)
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.sarifFilePath, "scan-results", "R", "", sarifResultsFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addSARIFPolicyFlags(cmd, &o.policyOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "build-url", "name", "scan-results"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceCommitSARIFOptions) run(args []string) error {
+	var err error
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/generic", global.Host, global.Org)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = o.policyOptions.evaluate(&o.payload, o.sarifFilePath)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.sarifFilePath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("sarif evidence is reported to commit: %s", o.payload.CommitSHA)
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/sbom"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitSBOMOptions struct {
+	sbomFilePath     string
+	userDataFilePath string
+	payload          EvidenceSBOMPayload
+}
+
+const reportEvidenceCommitSBOMShortDesc = `Report SBOM evidence for a commit in Kosli flows.  `
+
+const reportEvidenceCommitSBOMLongDesc = reportEvidenceCommitSBOMShortDesc + `
+The --sbom-file is validated, summarized and uploaded to Kosli's evidence vault.
+Supported formats are CycloneDX (JSON and XML) and SPDX (JSON and tag-value).
+A valid SBOM is reported as compliant.
+`
+
+const reportEvidenceCommitSBOMExample = `
+# report SBOM evidence for a commit related to one Kosli flow:
+kosli report evidence commit sbom \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--sbom-file yourSBOM.cdx.json
+
+# report SBOM evidence for a commit related to multiple Kosli flows:
+kosli report evidence commit sbom \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--sbom-file yourSBOM.spdx
+`
+
+func newReportEvidenceCommitSBOMCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceCommitSBOMOptions)
+	cmd := &cobra.Command{
+		Use:     "sbom",
+		Short:   reportEvidenceCommitSBOMShortDesc,
+		Long:    reportEvidenceCommitSBOMLongDesc,
+		Example: reportEvidenceCommitSBOMExample,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVar(&o.sbomFilePath, "sbom-file", "", sbomFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "build-url", "name", "sbom-file"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceCommitSBOMOptions) run(args []string) error {
+	var err error
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/generic", global.Host, global.Org)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	o.payload.SBOMSummary, err = sbom.ParseFile(o.sbomFilePath)
+	if err != nil {
+		return err
+	}
+	// there is no artifact to match the SBOM subject against
+	o.payload.Compliant = true
+	o.payload.Description = sbomEvidenceDescription(o.payload.SBOMSummary)
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.sbomFilePath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("sbom evidence is reported to commit: %s", o.payload.CommitSHA)
+	}
+	return err
+}
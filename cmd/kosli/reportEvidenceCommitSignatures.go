@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitSignaturesOptions struct {
+	signatureOptions
+	payload SignatureEvidencePayload
+}
+
+const reportEvidenceCommitSignaturesShortDesc = `Report commit signature evidence for a commit in Kosli flows.`
+
+const reportEvidenceCommitSignaturesLongDesc = reportEvidenceCommitSignaturesShortDesc + `  
+Verifies the GPG or SSH signature of the commit against trusted keys, locally in the git repository.  
+GPG signatures are verified against the armored public keys in ^--gpg-keyring^, and SSH signatures
+against the keys in the SSH allowed signers file of ^--allowed-signers^ (the file used by git's ^gpg.ssh.allowedSignersFile^).  
+The evidence records the signer identity and key of the commit, and is compliant if the commit
+is signed by a trusted key. Unsigned and untrusted commits are logged as warnings, or fail
+the command with ^--assert^.
+`
+
+const reportEvidenceCommitSignaturesExample = `
+# report commit signature evidence for a commit signed with GPG:
+kosli report evidence commit signatures \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--gpg-keyring /path/to/trusted-keys.asc \
+	--flows yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName
+
+# fail if the commit is not signed with a trusted GPG or SSH key:
+kosli report evidence commit signatures \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--gpg-keyring /path/to/trusted-keys.asc \
+	--allowed-signers /path/to/allowed_signers \
+	--flows yourFlowName \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName \
+	--assert
+`
+
+func newReportEvidenceCommitSignaturesCmd(out io.Writer) *cobra.Command {
+	o := new(reportEvidenceCommitSignaturesOptions)
+	cmd := &cobra.Command{
+		Use:     "signatures",
+		Short:   reportEvidenceCommitSignaturesShortDesc,
+		Long:    reportEvidenceCommitSignaturesLongDesc,
+		Example: reportEvidenceCommitSignaturesExample,
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+			return validateSignatureFlags(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	addSignatureFlags(cmd, &o.signatureOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "build-url", "name"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceCommitSignaturesOptions) run(args []string) error {
+	var err error
+
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/signatures", global.Host, global.Org)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	gv, err := gitview.New(o.srcRepoRoot)
+	if err != nil {
+		return err
+	}
+
+	o.payload.CommitSHA, err = gv.ResolveRevision(o.payload.CommitSHA)
+	if err != nil {
+		return err
+	}
+
+	err = o.verifySignatures(gv, []string{o.payload.CommitSHA}, &o.payload)
+	if err != nil {
+		return err
+	}
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, o.evidencePaths)
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("commit signature evidence is reported to commit: %s", o.payload.CommitSHA)
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/vulnscan"
+	"github.com/spf13/cobra"
+)
+
+type reportEvidenceCommitVulnScanOptions struct {
+	scanner          *vulnerabilityScanner
+	policyOptions    vulnerabilityPolicyOptions
+	scanResultsPath  string
+	userDataFilePath string
+	payload          EvidenceVulnerabilityScanPayload
+}
+
+func newReportEvidenceCommitVulnScanCmd(out io.Writer, scanner *vulnerabilityScanner) *cobra.Command {
+	o := new(reportEvidenceCommitVulnScanOptions)
+	o.scanner = scanner
+	shortDesc := fmt.Sprintf("Report %s vulnerability scan evidence for a commit in Kosli flows.  ", scanner.displayName)
+	cmd := &cobra.Command{
+		Use:   scanner.name,
+		Short: shortDesc,
+		Long: shortDesc + fmt.Sprintf(`
+The --scan-results %s JSON report is parsed and uploaded to Kosli's evidence vault.
+The vulnerabilities are counted by severity, and by whether a fix is available.
+If no vulnerabilities at or above --fail-on-severity are detected the evidence is reported as compliant.
+Otherwise the evidence is reported as non-compliant. By default, any vulnerability makes the evidence non-compliant.
+`, scanner.displayName),
+		Example: fmt.Sprintf(`
+# report %[1]s vulnerability scan evidence for a commit related to one Kosli flow:
+kosli report evidence commit %[2]s \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results your%[1]sJSONReport
+
+# report %[1]s vulnerability scan evidence for a commit related to multiple Kosli flows,
+# and only fail on critical vulnerabilities:
+kosli report evidence commit %[2]s \
+	--commit yourGitCommitSha1 \
+	--name yourEvidenceName \
+	--flows yourFlowName1,yourFlowName2 \
+	--build-url https://exampleci.com \
+	--api-token yourAPIToken \
+	--org yourOrgName	\
+	--scan-results your%[1]sJSONReport \
+	--fail-on-severity critical
+`, scanner.displayName, scanner.name),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := RequireGlobalFlags(global, []string{"Org", "ApiToken"})
+			if err != nil {
+				return ErrorBeforePrintingUsage(cmd, err.Error())
+			}
+
+			if o.policyOptions.failOnSeverity != "" {
+				err = vulnscan.ValidateSeverity(o.policyOptions.failOnSeverity)
+				if err != nil {
+					return ErrorBeforePrintingUsage(cmd, err.Error())
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+
+	ci := WhichCI()
+	addCommitEvidenceFlags(cmd, &o.payload.TypedEvidencePayload, ci)
+	cmd.Flags().StringVarP(&o.scanResultsPath, "scan-results", "R", "", scanner.resultsFileFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	addVulnerabilityPolicyFlags(cmd, &o.policyOptions)
+	addDryRunFlag(cmd)
+
+	err := RequireFlags(cmd, []string{"commit", "build-url", "name", "scan-results"})
+	if err != nil {
+		logger.Error("failed to configure required flags: %v", err)
+	}
+
+	return cmd
+}
+
+func (o *reportEvidenceCommitVulnScanOptions) run(args []string) error {
+	var err error
+	url := fmt.Sprintf("%s/api/v2/evidence/%s/commit/generic", global.Host, global.Org)
+	o.payload.UserData, err = LoadJsonData(o.userDataFilePath)
+	if err != nil {
+		return err
+	}
+
+	summary, err := o.scanner.parse(o.scanResultsPath)
+	if err != nil {
+		return err
+	}
+	o.policyOptions.evaluate(&o.payload, summary)
+
+	form, cleanupNeeded, evidencePath, err := newEvidenceForm(o.payload, []string{o.scanResultsPath})
+	// if we created a tar package, remove it after uploading it
+	if cleanupNeeded {
+		defer os.Remove(evidencePath)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reqParams := &requests.RequestParams{
+		Method:   http.MethodPost,
+		URL:      url,
+		Form:     form,
+		DryRun:   global.DryRun,
+		Password: global.ApiToken,
+	}
+	_, err = kosliClient.Do(reqParams)
+	if err == nil && !global.DryRun {
+		logger.Info("%s scan evidence is reported to commit: %s", o.scanner.name, o.payload.CommitSHA)
+	}
+	return err
+}
@@ -8,7 +8,7 @@ import (
 
 const requestApprovalShortDesc = `Request an approval of a deployment of an artifact in Kosli.  `
 const requestApprovalLongDesc = requestApprovalShortDesc + `
-The request should be reviewed in the Kosli UI.  
+The request should be reviewed in the Kosli UI, or with 'kosli approve'.  
 ` + fingerprintDesc
 
 const requestApprovalExample = `
@@ -67,6 +67,11 @@ func newRequestApprovalCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&o.oldestSrcCommit, "oldest-commit", "", oldestCommitFlag)
 	cmd.Flags().StringVar(&o.newestSrcCommit, "newest-commit", "HEAD", newestCommitFlag)
 	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	cmd.Flags().BoolVar(&o.rangeOptions.FirstParent, "first-parent", false, firstParentFlag)
+	cmd.Flags().StringSliceVar(&o.rangeOptions.Paths, "src-paths", []string{}, srcPathsFlag)
+	cmd.Flags().BoolVar(&o.rangeOptions.FileStats, "file-stats", false, fileStatsFlag)
+	cmd.Flags().IntVar(&o.autoFetchDepth, "auto-fetch-depth", 0, approvalAutoFetchDepthFlag)
+	o.newRetriever = addGitProviderFlags(cmd, WhichCI(), gitProviderApprovalFlag)
 	addFingerprintFlags(cmd, o.fingerprintOptions)
 	addDryRunFlag(cmd)
 
@@ -31,8 +31,11 @@ const (
 	envPrefix = "KOSLI"
 
 	// the following constants are used in the docs/help
-	fingerprintDesc = "The artifact SHA256 fingerprint is calculated (based on --artifact-type flag) or alternatively it can be provided directly (with --fingerprint flag)."
-	awsAuthDesc     = `
+	fingerprintDesc  = "The artifact SHA256 fingerprint is calculated (based on --artifact-type flag) or alternatively it can be provided directly (with --fingerprint flag)."
+	junitResultsDesc = `Test suite timestamps are accepted in any common ISO-8601 format.  
+Use --include-failures to include the failing tests in the evidence, and --dedupe-retries to count retried tests once.  
+`
+	awsAuthDesc = `
 
 To authenticate to AWS, you can either:  
   1) provide the AWS static credentials via flags or by exporting the equivalent KOSLI env vars (e.g. KOSLI_AWS_KEY_ID)  
@@ -88,6 +91,48 @@ The service principal needs to have the following permissions:
 	jiraUsernameFlag           = "Jira username (for Jira Cloud)"
 	jiraAPITokenFlag           = "Jira API token (for Jira Cloud)"
 	jiraPATFlag                = "Jira personal access token (for self-hosted Jira)"
+	issueProjectKeysFlag       = "[optional] The project keys to match %s issue references for, e.g. EX,OPS. Only applies to issue keys of the form PROJECT-123. All project keys are matched if not set."
+	issueKeyPatternsFlag       = "[defaulted] The regular expressions used to find %s issue keys. If a pattern has a capture group, the first group is the issue key. Defaults to '%s'."
+	issueRequireIssuesFlag     = "[optional] Whether at least one %s issue reference must be found."
+	issueAllowedStatusesFlag   = "[optional] The statuses the referenced %s issues must be in, e.g. 'In Progress,Done'. Any status is allowed if not set."
+	issueFixVersionTypesFlag   = "[optional] The %s issue types that must have a fix version, e.g. Bug."
+	commitIssuesEvidenceFlag   = "Git commit of the artifact. Issues are looked up in all commits since the git commit of the previous artifact in the flow. (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
+	assertIssuesFlag           = "[optional] Exit with non-zero code if the %s issue references do not comply with the rules, or reference issues that do not exist."
+	linearAPIKeyFlag           = "Linear personal API key."
+	linearAPIURLFlag           = "[optional] The Linear GraphQL API url."
+	gpgKeyringFlag             = "[optional] The path to a file with the armored GPG public keys that are trusted to sign commits."
+	allowedSignersFlag         = "[optional] The path to an SSH allowed signers file (see ssh-keygen(1)) with the SSH keys that are trusted to sign commits. The namespaces, valid-after and valid-before options of its entries are honored, with the validity checked at the committer date like git does."
+	commitSignaturesFlag       = "Git commit of the artifact. The signatures of all commits since the git commit of the previous artifact in the flow are verified. (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
+	assertSignaturesFlag       = "[optional] Exit with non-zero code if a commit is not signed by a trusted key."
+	gitProviderFlag            = "[optional] The git provider to look up the pull requests of the commit in, one of [bitbucket, github, gitlab, azure, gitea]. The titles and descriptions of the pull requests are scanned for issue references. Requires the flags of the git provider."
+	gitProviderCompareFlag     = "[optional] The git provider to get the changelog from when the git repository is a shallow clone without the history since the previous artifact, one of [github, gitlab, azure]. Requires the flags of the git provider."
+	autoFetchDepthFlag         = "[optional] The depth to fetch the git history of a shallow clone to from the 'origin' remote when it does not contain the git commit of the previous artifact. 0 disables fetching."
+	firstParentFlag            = "[optional] Only follow the first parent of merge commits, so that the commits of merged branches are not listed, only their merge commits."
+	srcPathsFlag               = "[optional] The comma-separated list of glob patterns of the source files of the artifact, relative to the repo root. Only the commits that change matching files are included. A pattern matches a file, or a directory and all its files, and '**' matches any number of directories."
+	fileStatsFlag              = "[optional] Report the files changed by each commit, with their numbers of inserted and deleted lines, in the list of commits. This can be slow for large changes."
+	approvalAutoFetchDepthFlag = "[optional] The depth to fetch the git history of a shallow clone to from the 'origin' remote when it does not contain the oldest commit. 0 disables fetching."
+	gitProviderApprovalFlag    = "[optional] The git provider to get the commits from when the git repository is a shallow clone without the history since the oldest commit, one of [github, gitlab, azure]. Requires the flags of the git provider."
+	changelogFetchDepthFlag    = "[optional] The depth to fetch the git history of a shallow clone to from the 'origin' remote when it does not contain the git commit of FROM. 0 disables fetching."
+	gitProviderChangelogFlag   = "[optional] The git provider to look up the pull requests of the released commits in, one of [bitbucket, github, gitlab, azure, gitea]. Requires the flags of the git provider."
+	changelogOutputFlag        = "[defaulted] The format of the release notes. Valid formats are: [markdown, json]."
+	approverFlag               = "[defaulted] The approver of the approval. Defaults to the user who triggered the CI job (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
+	approverTokenFlag          = "[optional] An identity token of the approver, e.g. an OIDC ID token of the CI job. The raw token is sent to Kosli, which verifies it and takes the approver from it."
+	approvalURLFlag            = "[defaulted] The url of the page where the approval was given (defaulted to the build url in some CIs: https://docs.kosli.com/ci-defaults )."
+	approveCommentFlag         = "[optional] The comment of the approval or rejection. You are prompted for it if neither --approve nor --reject is set."
+	approveFlag                = "[optional] Approve the pending approval without being prompted."
+	rejectFlag                 = "[optional] Reject the pending approval without being prompted."
+	waitFlag                   = "[optional] Wait for the assertion to hold, checking it again until it does, fails, or --timeout expires. Exits with code 2 if the timeout expires."
+	waitTimeoutFlag            = "[defaulted] The longest time to wait for the assertion to hold with --wait, e.g. 10m or 1h."
+	pollIntervalFlag           = "[defaulted] The time to wait before checking the assertion again with --wait. It doubles after each check, up to 1m or the poll interval if it is longer."
+	policyFileFlag             = "The path to a YAML policy file with the CEL rules to evaluate."
+	requireComplianceFlag      = "[optional] Check that the artifacts running in the environment are compliant."
+	requireProvenanceFlag      = "[optional] Check that the artifacts running in the environment were reported to a Kosli flow."
+	maxAgeDaysFlag             = "[optional] Check that the artifacts were created in their Kosli flow no more than this number of days ago."
+	maxRunningDaysFlag         = "[optional] Check that the artifacts have not been running in the environment for more than this number of days."
+	replicasFlag               = "[optional] Check that the artifacts run this number of replicas in the environment."
+	subsetOfFlag               = "[optional] Check that the artifacts running in the environment are also running in the environment snapshot of this expression, e.g. staging."
+	throughFlag                = "The comma-separated list of environments that the artifact must have run in, e.g. dev,staging."
+	minSoakFlag                = "[optional] The minimum time the artifact must have been running without interruption in each of the --through environments, e.g. 2h."
 	envDescriptionFlag         = "[optional] The environment description."
 	flowDescriptionFlag        = "[optional] The Kosli flow description."
 	workflowDescriptionFlag    = "[optional] The Kosli Workflow description."
@@ -105,11 +150,17 @@ The service principal needs to have the following permissions:
 	evidenceTypeFlag           = "The type of evidence being reported."
 	bbUsernameFlag             = "Bitbucket username."
 	bbPasswordFlag             = "Bitbucket App password. See https://developer.atlassian.com/cloud/bitbucket/rest/intro/#authentication for more details."
-	bbWorkspaceFlag            = "Bitbucket workspace ID."
+	bbWorkspaceFlag            = "Bitbucket workspace ID. On Bitbucket Data Center, the project key."
+	bbAccessTokenFlag          = "[optional] Bitbucket access token, used instead of --bitbucket-username and --bitbucket-password. On Bitbucket Data Center, an HTTP access token or personal access token."
+	bbBaseURLFlag              = "[optional] The base URL of a Bitbucket Data Center server, e.g. https://bitbucket.example.com. Bitbucket Cloud is used if not set."
 	commitPREvidenceFlag       = "Git commit for which to find pull request evidence. (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
 	commitEvidenceFlag         = "Git commit for which to verify and given evidence. (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
 	repositoryFlag             = "Git repository. (defaulted in some CIs: https://docs.kosli.com/ci-defaults )."
 	assertPREvidenceFlag       = "[optional] Exit with non-zero code if no pull requests found for the given commit."
+	requireFourEyesFlag        = "[optional] Check that pull requests satisfy the four-eyes rule: approved by someone who is neither the author nor a committer, with no commits after the last approval. Violations fail the command when used with --assert and are logged as warnings otherwise."
+	prNumberFlag               = "[optional] The number of the %[1]s to use, instead of finding the %[1]ss of the git commit. When not set and no %[1]ss are associated with the commit, the commit is looked up in the merge commits of recently merged %[1]ss, and then in the merged %[1]ss referenced in the commit message."
+	assertFourEyesFlag         = "[optional] Also exit with non-zero code if a pull request does not satisfy the four-eyes rule: approved by someone who is neither the author nor a committer, with no commits after the last approval."
+	branchProtectionBranchFlag = "The branch the commit was merged to, whose protection rules are reported."
 	assertStatusFlag           = "[optional] Exit with non-zero code if Kosli server is not responding."
 	azureTokenFlag             = "Azure Personal Access token."
 	azureProjectFlag           = "Azure project.(defaulted if you are running in Azure Devops pipelines: https://docs.kosli.com/ci-defaults )."
@@ -125,12 +176,29 @@ The service principal needs to have the following permissions:
 	githubBaseURLFlag          = "[optional] GitHub base URL (only needed for GitHub Enterprise installations)."
 	gitlabTokenFlag            = "Gitlab token."
 	gitlabOrgFlag              = "Gitlab organization. (defaulted if you are running in Gitlab Pipelines: https://docs.kosli.com/ci-defaults )."
+	giteaTokenFlag             = "Gitea token."
+	giteaOrgFlag               = "Gitea organization or user that owns the repository."
+	giteaBaseURLFlag           = "Gitea base URL, e.g. https://gitea.example.com (Forgejo instances are supported too)."
 	gitlabBaseURLFlag          = "[optional] Gitlab base URL (only needed for on-prem Gitlab installations)."
 	registryProviderFlag       = "[conditional] The docker registry provider or url. Only required if you want to read docker image SHA256 digest from a remote docker registry."
 	registryUsernameFlag       = "[conditional] The docker registry username. Only required if you want to read docker image SHA256 digest from a remote docker registry."
 	registryPasswordFlag       = "[conditional] The docker registry password or access token. Only required if you want to read docker image SHA256 digest from a remote docker registry."
 	resultsDirFlag             = "[defaulted] The path to a directory with JUnit test results. The directory will be uploaded to Kosli's evidence vault."
+	resultsFilesFlag           = "[optional] The comma separated list of JUnit test results files, or glob patterns matching them (e.g. 'build/**/TEST-*.xml'). Cannot be used together with --results-dir. The files will be uploaded to Kosli's evidence vault."
+	includeFailuresFlag        = "[optional] Include the names and messages of failing tests in the evidence."
+	dedupeRetriesFlag          = "[optional] Count tests that are reported several times in a test suite (because they were retried) once. A retried test passes if any of its runs passes, and is reported as flaky."
 	snykJsonResultsFileFlag    = "The path to Snyk scan results JSON file from 'snyk test' and 'snyk container test'. The Snyk results will be uploaded to Kosli's evidence vault."
+	sbomFileFlag               = "The path to a CycloneDX (JSON or XML) or SPDX (JSON or tag-value) SBOM file. The SBOM will be uploaded to Kosli's evidence vault."
+	sarifResultsFileFlag       = "The path to a SARIF 2.1.0 results file. The SARIF results will be uploaded to Kosli's evidence vault."
+	sarifFailOnFlag            = "[defaulted] The least severe SARIF result level that makes the evidence non-compliant. One of: [error, warning, note, none]. 'none' disables the level check."
+	sarifMaxWarningsFlag       = "[defaulted] The maximum number of warning-level SARIF results allowed for the evidence to be compliant. A negative value disables the check."
+	trivyResultsFileFlag       = "The path to a Trivy JSON report from 'trivy image --format json' or 'trivy fs --format json'. The report will be uploaded to Kosli's evidence vault."
+	grypeResultsFileFlag       = "The path to a Grype JSON report from 'grype -o json'. The report will be uploaded to Kosli's evidence vault."
+	failOnSeverityFlag         = "[optional] The least severe vulnerability severity that makes the evidence non-compliant. One of: [critical, high, medium, low, negligible, unknown]. Defaults to any vulnerability."
+	ignoreUnfixedFlag          = "[optional] Ignore vulnerabilities that do not have a fix available when deciding compliance."
+	coverageFilesFlag          = "The comma separated list of coverage report files. Cobertura XML, LCOV, JaCoCo XML and Go coverprofile formats are supported and detected automatically. The reports will be uploaded to Kosli's evidence vault."
+	minCoverageFlag            = "[optional] The minimum line coverage percentage (0-100) for the evidence to be compliant. For Go coverprofiles, statement coverage is used."
+	minBranchCoverageFlag      = "[optional] The minimum branch coverage percentage (0-100) for the evidence to be compliant."
 	ecsClusterFlag             = "The name of the ECS cluster."
 	ecsServiceFlag             = "[optional] The name of the ECS service."
 	kubeconfigFlag             = "[defaulted] The kubeconfig path for the target cluster."
@@ -223,10 +291,13 @@ func newRootCmd(out io.Writer, args []string) (*cobra.Command, error) {
 		newVersionCmd(out),
 		newFingerprintCmd(out),
 		newAssertCmd(out),
+		newEvaluateCmd(out),
 		newStatusCmd(out),
 		newExpectCmd(out),
 		newSearchCmd(out),
 		newCompletionCmd(out),
+		newChangelogCmd(out),
+		newApproveCmd(out),
 		// Hidden documentation generator command: 'kosli docs'
 		newDocsCmd(out),
 
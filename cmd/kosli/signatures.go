@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/spf13/cobra"
+)
+
+// signatureOptions are the options shared by the signature evidence commands
+type signatureOptions struct {
+	userDataFilePath   string
+	evidencePaths      []string
+	srcRepoRoot        string
+	keyRingFile        string
+	allowedSignersFile string
+	assert             bool
+}
+
+func addSignatureFlags(cmd *cobra.Command, o *signatureOptions) {
+	cmd.Flags().StringVar(&o.keyRingFile, "gpg-keyring", "", gpgKeyringFlag)
+	cmd.Flags().StringVar(&o.allowedSignersFile, "allowed-signers", "", allowedSignersFlag)
+	cmd.Flags().StringVar(&o.srcRepoRoot, "repo-root", ".", repoRootFlag)
+	cmd.Flags().StringVarP(&o.userDataFilePath, "user-data", "u", "", evidenceUserDataFlag)
+	cmd.Flags().StringSliceVarP(&o.evidencePaths, "evidence-paths", "e", []string{}, evidencePathsFlag)
+	cmd.Flags().BoolVar(&o.assert, "assert", false, assertSignaturesFlag)
+}
+
+// validateSignatureFlags returns an error if there are no trusted keys to verify signatures with
+func validateSignatureFlags(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("gpg-keyring") && !cmd.Flags().Changed("allowed-signers") {
+		return fmt.Errorf("at least one of --gpg-keyring, --allowed-signers is required")
+	}
+	return nil
+}
+
+func (o *signatureOptions) newSignatureVerifier() (*gitview.SignatureVerifier, error) {
+	keyRing, err := readOptionalFile(o.keyRingFile)
+	if err != nil {
+		return nil, err
+	}
+	allowedSigners, err := readOptionalFile(o.allowedSignersFile)
+	if err != nil {
+		return nil, err
+	}
+	return gitview.NewSignatureVerifier(keyRing, allowedSigners)
+}
+
+// readOptionalFile returns the content of a file, or "" if no path is given
+func readOptionalFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return string(content), nil
+}
+
+// verifySignatures verifies the signatures of the commits into the payload,
+// and returns an error with the unsigned and untrusted commits when asserting
+func (o *signatureOptions) verifySignatures(gv *gitview.GitView, commitSHAs []string, payload *SignatureEvidencePayload) error {
+	verifier, err := o.newSignatureVerifier()
+	if err != nil {
+		return err
+	}
+
+	payload.Signatures = []*gitview.CommitSignature{}
+	payload.UnsignedCommits = []string{}
+	payload.UntrustedCommits = []string{}
+	violations := []string{}
+	for _, commitSHA := range commitSHAs {
+		signature, err := gv.VerifyCommitSignature(commitSHA, verifier)
+		if err != nil {
+			return err
+		}
+		payload.Signatures = append(payload.Signatures, signature)
+		if !signature.Signed {
+			payload.UnsignedCommits = append(payload.UnsignedCommits, signature.CommitSHA)
+		} else if !signature.Verified {
+			payload.UntrustedCommits = append(payload.UntrustedCommits, signature.CommitSHA)
+		}
+		if !signature.Verified {
+			violations = append(violations, fmt.Sprintf("%s: %s", signature.CommitSHA, signature.Reason))
+		}
+	}
+	payload.Compliant = len(violations) == 0
+
+	if len(violations) > 0 {
+		if o.assert {
+			return fmt.Errorf("%d commit(s) are not signed by a trusted key:\n%s", len(violations), strings.Join(violations, "\n"))
+		}
+		for _, violation := range violations {
+			logger.Warning("%s", violation)
+		}
+	}
+	return nil
+}
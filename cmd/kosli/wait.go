@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// timeoutExitCode is the exit code of the assert commands that time out waiting for their assertion to hold.
+// Assertions that do not hold exit with 1.
+const timeoutExitCode = 2
+
+// maxPollInterval is the longest time to wait between two checks of an assertion,
+// unless the poll interval is longer
+const maxPollInterval = time.Minute
+
+// waitSleep and clockNow are replaced in tests
+var (
+	waitSleep = time.Sleep
+	clockNow  = time.Now
+)
+
+// ExitError is an error that makes kosli exit with the given code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// pendingError is returned by an assertion that does not hold yet, but may hold later,
+// e.g. when an approval has not been reviewed yet
+type pendingError struct {
+	err error
+}
+
+func (e *pendingError) Error() string {
+	return e.err.Error()
+}
+
+// waitOptions control how the assert commands wait for their assertion to hold
+type waitOptions struct {
+	wait         bool
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// addWaitFlags adds the flags to wait for an assertion to hold
+func addWaitFlags(cmd *cobra.Command, o *waitOptions) {
+	cmd.Flags().BoolVar(&o.wait, "wait", false, waitFlag)
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 30*time.Minute, waitTimeoutFlag)
+	cmd.Flags().DurationVar(&o.pollInterval, "poll-interval", 10*time.Second, pollIntervalFlag)
+}
+
+// validate returns an error if the timeout or the poll interval are not positive
+func (o *waitOptions) validate() error {
+	if o.timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	if o.pollInterval <= 0 {
+		return fmt.Errorf("--poll-interval must be positive")
+	}
+	return nil
+}
+
+// poll checks the assertion, and, with --wait, checks it again while it is pending,
+// backing off from the poll interval up to maxPollInterval, until it holds, fails or the timeout expires.
+// The error of a timeout makes kosli exit with timeoutExitCode.
+func (o *waitOptions) poll(assert func() error) error {
+	deadline := clockNow().Add(o.timeout)
+	interval := o.pollInterval
+	for {
+		err := assert()
+		var pending *pendingError
+		if !errors.As(err, &pending) {
+			return err
+		}
+		if !o.wait {
+			return pending.err
+		}
+
+		remaining := deadline.Sub(clockNow())
+		if remaining <= 0 {
+			return &ExitError{
+				Code: timeoutExitCode,
+				Err:  fmt.Errorf("timed out after %s: %v", o.timeout, pending.err),
+			}
+		}
+		if interval > remaining {
+			interval = remaining
+		}
+		logger.Info("%v\nChecking again in %s", pending.err, interval.Round(time.Second))
+		waitSleep(interval)
+		interval = nextPollInterval(interval, o.pollInterval)
+	}
+}
+
+// nextPollInterval doubles the poll interval, up to maxPollInterval or the initial interval if it is longer
+func nextPollInterval(interval, initial time.Duration) time.Duration {
+	limit := maxPollInterval
+	if initial > limit {
+		limit = initial
+	}
+	interval *= 2
+	if interval > limit {
+		return limit
+	}
+	return interval
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock replaces waitSleep and clockNow with a clock that only advances when sleeping
+func fakeClock(t *testing.T) *[]time.Duration {
+	sleeps := []time.Duration{}
+	current := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	waitSleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+		current = current.Add(d)
+	}
+	clockNow = func() time.Time { return current }
+	t.Cleanup(func() {
+		waitSleep = time.Sleep
+		clockNow = time.Now
+	})
+	return &sleeps
+}
+
+func TestPoll(t *testing.T) {
+	notApproved := errors.New("artifact is not approved")
+	for _, tt := range []struct {
+		name           string
+		options        waitOptions
+		results        []error
+		expectedSleeps []time.Duration
+		expectedErr    string
+		expectedCode   int
+	}{
+		{
+			name:    "a pending assertion fails without --wait",
+			options: waitOptions{timeout: time.Minute, pollInterval: time.Second},
+			results: []error{&pendingError{notApproved}},
+			// the pending error is unwrapped so that it exits with 1
+			expectedErr:    "artifact is not approved",
+			expectedSleeps: []time.Duration{},
+		},
+		{
+			name:           "a pending assertion is checked again with backoff until it holds",
+			options:        waitOptions{wait: true, timeout: time.Hour, pollInterval: 10 * time.Second},
+			results:        []error{&pendingError{notApproved}, &pendingError{notApproved}, &pendingError{notApproved}, &pendingError{notApproved}, nil},
+			expectedSleeps: []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute},
+		},
+		{
+			name:           "a failed assertion is not checked again",
+			options:        waitOptions{wait: true, timeout: time.Hour, pollInterval: 10 * time.Second},
+			results:        []error{&pendingError{notApproved}, fmt.Errorf("artifact is rejected")},
+			expectedSleeps: []time.Duration{10 * time.Second},
+			expectedErr:    "artifact is rejected",
+		},
+		{
+			name:           "a pending assertion times out with the timeout exit code",
+			options:        waitOptions{wait: true, timeout: 25 * time.Second, pollInterval: 10 * time.Second},
+			results:        []error{&pendingError{notApproved}, &pendingError{notApproved}, &pendingError{notApproved}},
+			expectedSleeps: []time.Duration{10 * time.Second, 15 * time.Second},
+			expectedErr:    "timed out after 25s: artifact is not approved",
+			expectedCode:   timeoutExitCode,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sleeps := fakeClock(t)
+			checks := 0
+			err := tt.options.poll(func() error {
+				result := tt.results[checks]
+				checks++
+				return result
+			})
+			require.Equal(t, len(tt.results), checks)
+			require.Equal(t, tt.expectedSleeps, *sleeps)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+			var exitErr *ExitError
+			require.Equal(t, tt.expectedCode != 0, errors.As(err, &exitErr))
+			if tt.expectedCode != 0 {
+				require.Equal(t, tt.expectedCode, exitErr.Code)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package azure
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
@@ -78,14 +79,29 @@ func (c *AzureConfig) newPRAzureEvidence(pr git.GitPullRequest) (*types.PREviden
 	}
 	evidence := &types.PREvidence{
 		URL:         url,
+		Title:       stringValue(pr.Title),
+		Body:        stringValue(pr.Description),
 		MergeCommit: *(pr.LastMergeCommit.CommitId),
 		State:       string(*pr.Status),
+		Merged:      *pr.Status == git.PullRequestStatusValues.Completed,
+	}
+	if pr.CreatedBy != nil {
+		evidence.Author = types.PRUser{Login: stringValue(pr.CreatedBy.UniqueName), Name: stringValue(pr.CreatedBy.DisplayName)}
 	}
 	approvers, err := c.GetPullRequestApprovers(*pr.PullRequestId)
 	if err != nil {
 		return evidence, err
 	}
 	evidence.Approvers = approvers
+	evidence.Reviews, err = c.GetPullRequestReviews(*pr.PullRequestId)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.Commits, err = c.GetPullRequestCommits(*pr.PullRequestId)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.AnalyzeFourEyes()
 	return evidence, nil
 }
 
@@ -123,6 +139,74 @@ func (c *AzureConfig) PullRequestsForCommit(commit string) ([]git.GitPullRequest
 	return []git.GitPullRequest{}, nil
 }
 
+// PREvidenceForNumber returns the evidence of a pull request by its ID
+func (c *AzureConfig) PREvidenceForNumber(number int) (*types.PREvidence, error) {
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := client.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &c.Repository,
+		PullRequestId: &number,
+		Project:       &c.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.newPRAzureEvidence(*pr)
+}
+
+// PREvidenceForMergeCommit returns the evidence of the completed pull requests whose merge commit is commit.
+// Only the 100 most recent completed pull requests are searched.
+func (c *AzureConfig) PREvidenceForMergeCommit(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	top := 100
+	prs, err := client.GetPullRequests(ctx, git.GetPullRequestsArgs{
+		RepositoryId:   &c.Repository,
+		Project:        &c.Project,
+		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Completed},
+		Top:            &top,
+	})
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range *prs {
+		if pr.LastMergeCommit == nil || stringValue(pr.LastMergeCommit.CommitId) != commit {
+			continue
+		}
+		evidence, err := c.newPRAzureEvidence(pr)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// CommitMessage returns the message of a commit
+func (c *AzureConfig) CommitMessage(commit string) (string, error) {
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return "", err
+	}
+	gitCommit, err := client.GetCommit(ctx, git.GetCommitArgs{
+		CommitId:     &commit,
+		RepositoryId: &c.Repository,
+		Project:      &c.Project,
+	})
+	if err != nil {
+		return "", err
+	}
+	return stringValue(gitCommit.Comment), nil
+}
+
 // GetPullRequestApprovers returns a list of approvers for a given pull request
 func (c *AzureConfig) GetPullRequestApprovers(number int) ([]string, error) {
 	approvers := []string{}
@@ -148,3 +232,116 @@ func (c *AzureConfig) GetPullRequestApprovers(number int) ([]string, error) {
 	}
 	return approvers, nil
 }
+
+// GetPullRequestReviews returns the approvals of a given pull request.
+// The time of each approval is taken from the latest 'VoteUpdate' thread of the approver.
+func (c *AzureConfig) GetPullRequestReviews(number int) ([]types.PRReview, error) {
+	reviews := []types.PRReview{}
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return reviews, err
+	}
+
+	reviewers, err := client.GetPullRequestReviewers(ctx, git.GetPullRequestReviewersArgs{
+		RepositoryId:  &c.Repository,
+		PullRequestId: &number,
+		Project:       &c.Project,
+	})
+	if err != nil {
+		return reviews, err
+	}
+	threads, err := client.GetThreads(ctx, git.GetThreadsArgs{
+		RepositoryId:  &c.Repository,
+		PullRequestId: &number,
+		Project:       &c.Project,
+	})
+	if err != nil {
+		return reviews, err
+	}
+
+	approvedAt := make(map[string]int64)
+	for _, thread := range *threads {
+		if threadProperty(thread, "CodeReviewThreadType") != "VoteUpdate" ||
+			threadProperty(thread, "CodeReviewVoteResult") != "10" ||
+			thread.Identities == nil || thread.PublishedDate == nil {
+			continue
+		}
+		voter, ok := (*thread.Identities)[threadProperty(thread, "CodeReviewVotedByIdentity")]
+		if !ok || voter.Id == nil {
+			continue
+		}
+		if published := thread.PublishedDate.Time.Unix(); published > approvedAt[*voter.Id] {
+			approvedAt[*voter.Id] = published
+		}
+	}
+
+	for _, r := range *reviewers {
+		if r.Vote != nil && *r.Vote == 10 {
+			reviews = append(reviews, types.PRReview{
+				Reviewer:  types.PRUser{Login: stringValue(r.UniqueName), Name: stringValue(r.DisplayName)},
+				Timestamp: approvedAt[stringValue(r.Id)],
+			})
+		}
+	}
+	return reviews, nil
+}
+
+// GetPullRequestCommits returns the commits of a given pull request
+func (c *AzureConfig) GetPullRequestCommits(number int) ([]types.PRCommit, error) {
+	prCommits := []types.PRCommit{}
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return prCommits, err
+	}
+
+	top := 100
+	args := git.GetPullRequestCommitsArgs{
+		RepositoryId:  &c.Repository,
+		PullRequestId: &number,
+		Project:       &c.Project,
+		Top:           &top,
+	}
+	for {
+		commits, err := client.GetPullRequestCommits(ctx, args)
+		if err != nil {
+			return prCommits, err
+		}
+		for _, commit := range commits.Value {
+			prCommit := types.PRCommit{SHA: stringValue(commit.CommitId)}
+			if commit.Author != nil {
+				prCommit.Author = types.PRUser{Login: stringValue(commit.Author.Email), Name: stringValue(commit.Author.Name)}
+			}
+			if commit.Committer != nil && commit.Committer.Date != nil {
+				prCommit.Timestamp = commit.Committer.Date.Time.Unix()
+			}
+			prCommits = append(prCommits, prCommit)
+		}
+		if commits.ContinuationToken == "" {
+			return prCommits, nil
+		}
+		args.ContinuationToken = &commits.ContinuationToken
+	}
+}
+
+// threadProperty returns the value of a property of a pull request thread,
+// which is stored as {"$type": ..., "$value": ...}
+func threadProperty(thread git.GitPullRequestCommentThread, name string) string {
+	properties, ok := thread.Properties.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	property, ok := properties[name].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%v", property["$value"])
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -22,6 +22,13 @@ func (suite *AzureTestSuite) TestNewAzureClientFromToken() {
 	require.Nil(suite.T(), client)
 }
 
+func (suite *AzureTestSuite) TestGetIssueInfoWithInvalidWorkItem() {
+	config := NewAzureConfig("some_fake_token", "https://dev.azure.com/kosli_xxxxx", "project", "")
+	issue, err := config.GetIssueInfo("AB#one")
+	require.ErrorContains(suite.T(), err, "invalid Azure Boards work item: AB#one")
+	require.False(suite.T(), issue.IssueExists)
+}
+
 func (suite *AzureTestSuite) TestPREvidenceForCommit() {
 	type result struct {
 		wantError   bool
@@ -217,3 +224,34 @@ func (suite *AzureTestSuite) TestExtractRepoName() {
 func TestAzureTestSuite(t *testing.T) {
 	suite.Run(t, new(AzureTestSuite))
 }
+
+func TestChangedPaths(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		change interface{}
+		want   []string
+	}{
+		{
+			name:   "an edited file",
+			change: map[string]interface{}{"item": map[string]interface{}{"path": "/src/main.go", "gitObjectType": "blob"}},
+			want:   []string{"src/main.go"},
+		},
+		{
+			name: "a renamed file",
+			change: map[string]interface{}{
+				"item":             map[string]interface{}{"path": "/src/new.go", "gitObjectType": "blob"},
+				"sourceServerItem": "/src/old.go",
+			},
+			want: []string{"src/new.go", "src/old.go"},
+		},
+		{
+			name:   "a folder",
+			change: map[string]interface{}{"item": map[string]interface{}{"path": "/src", "isFolder": true, "gitObjectType": "tree"}},
+			want:   []string{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, changedPaths(tt.change))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// BoardsIssueKeyPattern matches Azure Boards work item mentions, e.g. AB#123
+// More info: https://learn.microsoft.com/en-us/azure/devops/boards/github/link-to-from-github
+const BoardsIssueKeyPattern = `AB#[0-9]+`
+
+// IssueKeyPattern returns the pattern of Azure Boards work item mentions
+func (c *AzureConfig) IssueKeyPattern() string {
+	return BoardsIssueKeyPattern
+}
+
+// GetIssueInfo retrieves Azure Boards work item information
+// if the work item is not found, we still return an IssueInfo object with IssueExists set to false
+func (c *AzureConfig) GetIssueInfo(issueID string) (*types.IssueInfo, error) {
+	result := &types.IssueInfo{
+		IssueID:     issueID,
+		IssueExists: false,
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(issueID), "AB#"))
+	if err != nil {
+		return result, fmt.Errorf("invalid Azure Boards work item: %s", issueID)
+	}
+	result.IssueURL, err = url.JoinPath(c.OrgURL, c.Project, "_workitems", "edit", strconv.Itoa(id))
+	if err != nil {
+		return result, err
+	}
+
+	ctx := context.Background()
+	connection := azuredevops.NewPatConnection(c.OrgURL, c.Token)
+	client, err := workitemtracking.NewClient(ctx, connection)
+	if err != nil {
+		return result, err
+	}
+	args := workitemtracking.GetWorkItemArgs{Id: &id}
+	if c.Project != "" {
+		args.Project = &c.Project
+	}
+	workItem, err := client.GetWorkItem(ctx, args)
+	if err != nil {
+		if isNotFound(err) {
+			return result, nil
+		}
+		return result, err
+	}
+
+	result.IssueExists = true
+	if workItem.Fields != nil {
+		fields := *workItem.Fields
+		result.Summary = workItemField(fields, "System.Title")
+		result.Status = workItemField(fields, "System.State")
+		result.IssueType = workItemField(fields, "System.WorkItemType")
+		if assignee, ok := fields["System.AssignedTo"].(map[string]interface{}); ok {
+			if name, ok := assignee["displayName"].(string); ok {
+				result.Assignee = name
+			}
+		}
+	}
+	return result, nil
+}
+
+// workItemField returns a string field of a work item, or "" if it is not set
+func workItemField(fields map[string]interface{}, name string) string {
+	if value, ok := fields[name].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// isNotFound returns true if err is an Azure DevOps API error with status 404.
+// The client returns these errors both as values and as pointers.
+func isNotFound(err error) bool {
+	var statusCode *int
+	var wrappedErr azuredevops.WrappedError
+	var wrappedErrPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedErr) {
+		statusCode = wrappedErr.StatusCode
+	} else if errors.As(err, &wrappedErrPtr) {
+		statusCode = wrappedErrPtr.StatusCode
+	}
+	return statusCode != nil && *statusCode == http.StatusNotFound
+}
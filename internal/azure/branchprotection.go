@@ -0,0 +1,194 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+)
+
+// Azure DevOps branch policy types, by display name
+const (
+	minimumReviewersPolicy  = "Minimum number of reviewers"
+	requiredReviewersPolicy = "Required reviewers"
+	buildPolicy             = "Build"
+	statusPolicy            = "Status"
+)
+
+// BranchProtectionForCommit returns the branch policies of a branch and the statuses
+// and builds of a commit. A branch is protected if it has at least one enabled blocking policy.
+// Force pushes are controlled by repository permissions, which are not collected.
+func (c *AzureConfig) BranchProtectionForCommit(branch, commit string) (*types.BranchProtection, error) {
+	protection := &types.BranchProtection{Branch: branch, Commit: commit, RequiredChecks: []string{}, Checks: []types.CheckStatus{}}
+	ctx := context.Background()
+	connection := azuredevops.NewPatConnection(c.OrgURL, c.Token)
+	gitClient, err := git.NewClient(ctx, connection)
+	if err != nil {
+		return protection, err
+	}
+	policyClient, err := policy.NewClient(ctx, connection)
+	if err != nil {
+		return protection, err
+	}
+	buildClient, err := build.NewClient(ctx, connection)
+	if err != nil {
+		return protection, err
+	}
+
+	repository, err := gitClient.GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &c.Repository, Project: &c.Project})
+	if err != nil {
+		return protection, err
+	}
+	repositoryID := repository.Id.String()
+
+	policies, err := policyClient.GetPolicyConfigurations(ctx, policy.GetPolicyConfigurationsArgs{Project: &c.Project})
+	if err != nil {
+		return protection, err
+	}
+	buildDefinitions := []int{}
+	for _, configuration := range policies.Value {
+		settings, ok := configuration.Settings.(map[string]interface{})
+		if !ok || !isEnabledBlocking(configuration) || !policyAppliesTo(settings, repositoryID, branch) {
+			continue
+		}
+		protection.Protected = true
+		switch policyTypeName(configuration) {
+		case minimumReviewersPolicy:
+			if count := intSetting(settings, "minimumApproverCount"); count > protection.RequiredApprovals {
+				protection.RequiredApprovals = count
+			}
+			if reset, ok := settings["resetOnSourcePush"].(bool); ok && reset {
+				protection.DismissStaleReviews = true
+			}
+		case requiredReviewersPolicy:
+			protection.RequireCodeOwnerReviews = true
+		case buildPolicy:
+			buildDefinitions = append(buildDefinitions, intSetting(settings, "buildDefinitionId"))
+		case statusPolicy:
+			protection.RequiredChecks = append(protection.RequiredChecks, statusName(settings["statusGenre"], settings["statusName"]))
+		}
+	}
+
+	statuses, err := gitClient.GetStatuses(ctx, git.GetStatusesArgs{CommitId: &commit, RepositoryId: &repositoryID, Project: &c.Project})
+	if err != nil {
+		return protection, err
+	}
+	for _, status := range *statuses {
+		if status.Context == nil {
+			continue
+		}
+		protection.Checks = append(protection.Checks, types.CheckStatus{
+			Name:  statusName(stringValue(status.Context.Genre), stringValue(status.Context.Name)),
+			State: types.NormalizeCheckState(string(*status.State)),
+		})
+	}
+
+	for _, definition := range buildDefinitions {
+		name, check, err := c.commitBuild(ctx, buildClient, definition, repositoryID, commit)
+		if err != nil {
+			return protection, err
+		}
+		protection.RequiredChecks = append(protection.RequiredChecks, name)
+		if check != nil {
+			protection.Checks = append(protection.Checks, *check)
+		}
+	}
+	return protection, nil
+}
+
+// commitBuild returns the name of a build definition and the state of its latest build of the commit,
+// or a nil check if the commit was not built by the definition
+func (c *AzureConfig) commitBuild(ctx context.Context, client build.Client, definition int, repositoryID, commit string) (string, *types.CheckStatus, error) {
+	name := fmt.Sprintf("build definition %d", definition)
+	top := 100
+	repositoryType := "TfsGit"
+	builds, err := client.GetBuilds(ctx, build.GetBuildsArgs{
+		Project:        &c.Project,
+		Definitions:    &[]int{definition},
+		RepositoryId:   &repositoryID,
+		RepositoryType: &repositoryType,
+		QueryOrder:     &build.BuildQueryOrderValues.QueueTimeDescending,
+		Top:            &top,
+	})
+	if err != nil {
+		return name, nil, err
+	}
+	for _, b := range builds.Value {
+		if b.Definition != nil && b.Definition.Name != nil {
+			name = *b.Definition.Name
+		}
+		if stringValue(b.SourceVersion) != commit {
+			continue
+		}
+		state := types.CheckPending
+		if b.Status != nil && *b.Status == build.BuildStatusValues.Completed && b.Result != nil {
+			state = types.NormalizeCheckState(string(*b.Result))
+			if *b.Result == build.BuildResultValues.PartiallySucceeded {
+				state = types.CheckFailure
+			}
+		}
+		return name, &types.CheckStatus{Name: name, State: state}, nil
+	}
+	return name, nil, nil
+}
+
+func isEnabledBlocking(configuration policy.PolicyConfiguration) bool {
+	return configuration.IsEnabled != nil && *configuration.IsEnabled &&
+		configuration.IsBlocking != nil && *configuration.IsBlocking &&
+		(configuration.IsDeleted == nil || !*configuration.IsDeleted)
+}
+
+func policyTypeName(configuration policy.PolicyConfiguration) string {
+	if configuration.Type == nil {
+		return ""
+	}
+	return stringValue(configuration.Type.DisplayName)
+}
+
+// policyAppliesTo returns true if one of the scopes of a policy matches the repository and branch.
+// A scope without a repository applies to all repositories.
+func policyAppliesTo(settings map[string]interface{}, repositoryID, branch string) bool {
+	scopes, ok := settings["scope"].([]interface{})
+	if !ok {
+		return false
+	}
+	ref := "refs/heads/" + branch
+	for _, s := range scopes {
+		scope, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if scopeRepository, ok := scope["repositoryId"].(string); ok && !strings.EqualFold(scopeRepository, repositoryID) {
+			continue
+		}
+		refName, _ := scope["refName"].(string)
+		matchKind, _ := scope["matchKind"].(string)
+		if strings.EqualFold(matchKind, "prefix") && strings.HasPrefix(ref, refName) {
+			return true
+		}
+		if refName == ref || refName == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func intSetting(settings map[string]interface{}, key string) int {
+	if value, ok := settings[key].(float64); ok {
+		return int(value)
+	}
+	return 0
+}
+
+// statusName returns the 'genre/name' of a status
+func statusName(genre, name interface{}) string {
+	if g, ok := genre.(string); ok && g != "" {
+		return fmt.Sprintf("%s/%v", g, name)
+	}
+	return fmt.Sprintf("%v", name)
+}
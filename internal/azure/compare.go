@@ -0,0 +1,120 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+// compareCommitsLimit is the maximum number of commits CompareCommits gets from Azure
+const compareCommitsLimit = 1000
+
+// CompareCommits returns the commits reachable from head and not from base, newest first.
+// The returned bool is true when there are more commits than compareCommitsLimit.
+func (c *AzureConfig) CompareCommits(base, head string) ([]*gitview.CommitInfo, bool, error) {
+	commits := []*gitview.CommitInfo{}
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return commits, false, err
+	}
+
+	// one more commit than the limit is requested to know if the list is truncated
+	top := compareCommitsLimit + 1
+	refs, err := client.GetCommitsBatch(ctx, git.GetCommitsBatchArgs{
+		RepositoryId: &c.Repository,
+		Project:      &c.Project,
+		SearchCriteria: &git.GitQueryCommitsCriteria{
+			ItemVersion:    &git.GitVersionDescriptor{Version: &head, VersionType: &git.GitVersionTypeValues.Commit},
+			CompareVersion: &git.GitVersionDescriptor{Version: &base, VersionType: &git.GitVersionTypeValues.Commit},
+			Top:            &top,
+		},
+	})
+	if err != nil {
+		return commits, false, err
+	}
+
+	for _, ref := range *refs {
+		if len(commits) == compareCommitsLimit {
+			return commits, true, nil
+		}
+		commit := &gitview.CommitInfo{
+			Sha1:    stringValue(ref.CommitId),
+			Message: strings.TrimSpace(stringValue(ref.Comment)),
+			Parents: []string{},
+		}
+		if ref.Parents != nil {
+			commit.Parents = *ref.Parents
+			commit.IsMerge = len(commit.Parents) > 1
+		}
+		if ref.Author != nil {
+			commit.Author = fmt.Sprintf("%s <%s>", stringValue(ref.Author.Name), stringValue(ref.Author.Email))
+			if ref.Author.Date != nil {
+				commit.Timestamp = ref.Author.Date.Time.Unix()
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, false, nil
+}
+
+// changesPageSize is the number of changes CommitChangedFiles gets from Azure at a time
+const changesPageSize = 100
+
+// CommitChangedFiles returns the paths of the files a commit changed compared to its first parent,
+// including the previous paths of renamed files
+func (c *AzureConfig) CommitChangedFiles(sha string) ([]string, error) {
+	files := []string{}
+	ctx := context.Background()
+	client, err := NewAzureClientFromToken(ctx, c.Token, c.OrgURL)
+	if err != nil {
+		return files, err
+	}
+
+	top := changesPageSize
+	for skip := 0; ; skip += top {
+		skip := skip
+		changes, err := client.GetChanges(ctx, git.GetChangesArgs{
+			CommitId:     &sha,
+			RepositoryId: &c.Repository,
+			Project:      &c.Project,
+			Top:          &top,
+			Skip:         &skip,
+		})
+		if err != nil {
+			return files, err
+		}
+		if changes.Changes == nil {
+			return files, nil
+		}
+		for _, change := range *changes.Changes {
+			files = append(files, changedPaths(change)...)
+		}
+		if len(*changes.Changes) < top {
+			return files, nil
+		}
+	}
+}
+
+// changedPaths returns the file paths of a change of a commit, without their leading slash.
+// Azure returns the changes as untyped objects, and lists the changed folders too.
+func changedPaths(change interface{}) []string {
+	paths := []string{}
+	fields, ok := change.(map[string]interface{})
+	if !ok {
+		return paths
+	}
+	item, ok := fields["item"].(map[string]interface{})
+	if !ok || item["isFolder"] == true || item["gitObjectType"] == "tree" {
+		return paths
+	}
+	for _, path := range []interface{}{item["path"], fields["sourceServerItem"]} {
+		if path, ok := path.(string); ok && path != "" {
+			paths = append(paths, strings.TrimPrefix(path, "/"))
+		}
+	}
+	return paths
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/kosli-dev/cli/internal/logger"
 	"github.com/kosli-dev/cli/internal/requests"
@@ -13,35 +15,52 @@ import (
 type Config struct {
 	Username    string
 	Password    string
+	AccessToken string
+	// BaseURL is the URL of a Bitbucket Data Center server. Bitbucket Cloud is used when it is empty.
+	BaseURL string
+	// Workspace is the project key on Bitbucket Data Center
 	Workspace   string
 	Repository  string
 	Logger      *logger.Logger
 	KosliClient *requests.Client
-	Assert      bool
 }
 
 func (c *Config) PREvidenceForCommit(commit string) ([]*types.PREvidence, error) {
-	return c.getPullRequestsFromBitbucketApi(commit, c.Assert)
+	if c.BaseURL != "" {
+		return c.getPullRequestsFromDataCenter(commit)
+	}
+	return c.getPullRequestsFromBitbucketApi(commit)
 }
 
-func (c *Config) getPullRequestsFromBitbucketApi(commit string, assert bool) ([]*types.PREvidence, error) {
+// newRequestParams returns the params of a GET request to the Bitbucket API,
+// authenticated with the access token if there is one, or else with the username and password
+func (c *Config) newRequestParams(url string) *requests.RequestParams {
+	reqParams := &requests.RequestParams{
+		Method: http.MethodGet,
+		URL:    url,
+	}
+	if c.AccessToken != "" {
+		reqParams.Token = c.AccessToken
+	} else {
+		reqParams.Username = c.Username
+		reqParams.Password = c.Password
+	}
+	return reqParams
+}
+
+func (c *Config) getPullRequestsFromBitbucketApi(commit string) ([]*types.PREvidence, error) {
 	pullRequestsEvidence := []*types.PREvidence{}
 
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/commit/%s/pullrequests", c.Workspace, c.Repository, commit)
+	url := fmt.Sprintf("%s/commit/%s/pullrequests", c.repositoryURL(), commit)
 	c.Logger.Debug("getting pull requests from " + url)
 
-	reqParams := &requests.RequestParams{
-		Method:   http.MethodGet,
-		URL:      url,
-		Username: c.Username,
-		Password: c.Password,
-	}
+	reqParams := c.newRequestParams(url)
 	response, err := c.KosliClient.Do(reqParams)
 	if err != nil {
 		return pullRequestsEvidence, err
 	}
 	if response.Resp.StatusCode == 200 {
-		pullRequestsEvidence, err = c.parseBitbucketResponse(commit, response, assert)
+		pullRequestsEvidence, err = c.parseBitbucketResponse(commit, response)
 		if err != nil {
 			return pullRequestsEvidence, err
 		}
@@ -56,7 +75,7 @@ func (c *Config) getPullRequestsFromBitbucketApi(commit string, assert bool) ([]
 	return pullRequestsEvidence, nil
 }
 
-func (c *Config) parseBitbucketResponse(commit string, response *requests.HTTPResponse, assert bool) ([]*types.PREvidence, error) {
+func (c *Config) parseBitbucketResponse(commit string, response *requests.HTTPResponse) ([]*types.PREvidence, error) {
 	pullRequestsEvidence := []*types.PREvidence{}
 	var responseData map[string]interface{}
 	err := json.Unmarshal([]byte(response.Body), &responseData)
@@ -78,24 +97,83 @@ func (c *Config) parseBitbucketResponse(commit string, response *requests.HTTPRe
 		}
 		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
 	}
-	if len(pullRequestsEvidence) == 0 {
-		if assert {
-			return pullRequestsEvidence, fmt.Errorf("no pull requests found for the given commit: %s", commit)
+	return pullRequestsEvidence, nil
+}
+
+// repositoryURL returns the Bitbucket Cloud API URL of the repository
+func (c *Config) repositoryURL() string {
+	return fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s", c.Workspace, c.Repository)
+}
+
+// PREvidenceForNumber returns the evidence of a pull request by its ID
+func (c *Config) PREvidenceForNumber(number int) (*types.PREvidence, error) {
+	if c.BaseURL != "" {
+		return c.getPullRequestFromDataCenter(number)
+	}
+	prApiUrl := fmt.Sprintf("%s/pullrequests/%d", c.repositoryURL(), number)
+	prHtmlLink := fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", c.Workspace, c.Repository, number)
+	return c.getPullRequestDetailsFromBitbucket(prApiUrl, prHtmlLink, "")
+}
+
+type bitbucketPullRequestsPage struct {
+	Values []struct {
+		MergeCommit *struct {
+			Hash string `json:"hash"`
+		} `json:"merge_commit"`
+		Links struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	} `json:"values"`
+}
+
+// PREvidenceForMergeCommit returns the evidence of the merged pull requests whose merge commit is commit.
+// Only the 50 most recently updated merged pull requests are searched.
+func (c *Config) PREvidenceForMergeCommit(commit string) ([]*types.PREvidence, error) {
+	if c.BaseURL != "" {
+		return c.getMergedPullRequestsFromDataCenter(commit)
+	}
+	pullRequestsEvidence := []*types.PREvidence{}
+	var page bitbucketPullRequestsPage
+	err := c.getJSON(c.repositoryURL()+"/pullrequests?state=MERGED&sort=-updated_on&pagelen=50", &page)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range page.Values {
+		// Bitbucket only returns the short hash of merge commits
+		if pr.MergeCommit == nil || pr.MergeCommit.Hash == "" || !strings.HasPrefix(commit, pr.MergeCommit.Hash) {
+			continue
+		}
+		evidence, err := c.getPullRequestDetailsFromBitbucket(pr.Links.Self.Href, pr.Links.HTML.Href, commit)
+		if err != nil {
+			return pullRequestsEvidence, err
 		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
 	}
 	return pullRequestsEvidence, nil
 }
 
+// CommitMessage returns the message of a commit
+func (c *Config) CommitMessage(commit string) (string, error) {
+	if c.BaseURL != "" {
+		return c.getCommitMessageFromDataCenter(commit)
+	}
+	var bitbucketCommit struct {
+		Message string `json:"message"`
+	}
+	err := c.getJSON(fmt.Sprintf("%s/commit/%s", c.repositoryURL(), commit), &bitbucketCommit)
+	return bitbucketCommit.Message, err
+}
+
 func (c *Config) getPullRequestDetailsFromBitbucket(prApiUrl, prHtmlLink, commit string) (*types.PREvidence, error) {
 	c.Logger.Debug("getting pull request details for " + prApiUrl)
 	evidence := &types.PREvidence{}
 
-	reqParams := &requests.RequestParams{
-		Method:   http.MethodGet,
-		URL:      prApiUrl,
-		Username: c.Username,
-		Password: c.Password,
-	}
+	reqParams := c.newRequestParams(prApiUrl)
 	response, err := c.KosliClient.Do(reqParams)
 	if err != nil {
 		return evidence, err
@@ -109,7 +187,13 @@ func (c *Config) getPullRequestDetailsFromBitbucket(prApiUrl, prHtmlLink, commit
 
 		evidence.URL = prHtmlLink
 		evidence.MergeCommit = commit
+		if mergeCommit, ok := responseData["merge_commit"].(map[string]interface{}); ok && commit == "" {
+			evidence.MergeCommit, _ = mergeCommit["hash"].(string)
+		}
 		evidence.State = responseData["state"].(string)
+		evidence.Merged = evidence.State == "MERGED"
+		evidence.Title, _ = responseData["title"].(string)
+		evidence.Body, _ = responseData["description"].(string)
 		participants := responseData["participants"].([]interface{})
 		approvers := []string{}
 
@@ -125,44 +209,128 @@ func (c *Config) getPullRequestDetailsFromBitbucket(prApiUrl, prHtmlLink, commit
 			c.Logger.Debug("no approvers found")
 		}
 		evidence.Approvers = approvers
-		// prID := int(responseData["id"].(float64))
-		// evidence.LastCommit, evidence.LastCommitter, err = getBitbucketPRLastCommit(workspace, repository, username, password, prID)
-		// if err != nil {
-		// 	return evidence, err
-		// }
-		// if utils.Contains(approvers, evidence.LastCommitter) {
-		// 	evidence.SelfApproved = true
-		// }
+		evidence.Author = bitbucketUser(responseData["author"])
+		// participated_on changes with any later activity of a participant, e.g. a comment,
+		// so the approval times are taken from the activity of the pull request
+		approvedAt, err := c.getApprovalTimesFromBitbucket(prApiUrl + "/activity")
+		if err != nil {
+			return evidence, err
+		}
+		for _, participantInterface := range participants {
+			p := participantInterface.(map[string]interface{})
+			if p["approved"].(bool) {
+				reviewer := bitbucketUser(p["user"])
+				evidence.Reviews = append(evidence.Reviews, types.PRReview{Reviewer: reviewer, Timestamp: approvedAt[reviewer.Login]})
+			}
+		}
+		evidence.Commits, err = c.getPullRequestCommitsFromBitbucket(prApiUrl + "/commits")
+		if err != nil {
+			return evidence, err
+		}
+		evidence.AnalyzeFourEyes()
 	} else {
 		return evidence, fmt.Errorf("failed to get PR details, got HTTP status %d. Please review repository permissions", response.Resp.StatusCode)
 	}
 	return evidence, nil
 }
 
-// func getBitbucketPRLastCommit(workspace, repository, username, password string, prID int) (string, string, error) {
-// 	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/commits", workspace, repository, prID)
-// 	log.Debug("Getting pull requests commits from" + url)
-// 	response, err := requests.SendPayload([]byte{}, url, username, password,
-// 		global.MaxAPIRetries, false, http.MethodGet, log)
-// 	if err != nil {
-// 		return "", "", err
-// 	}
-
-// 	if response.Resp.StatusCode == 200 {
-// 		var responseData map[string]interface{}
-// 		err := json.Unmarshal([]byte(response.Body), &responseData)
-// 		if err != nil {
-// 			return "", "", err
-// 		}
-// 		prCommits := responseData["values"].([]interface{})
-
-// 		// the first commit is the merge commit
-// 		// TODO: is it safe to always to get the second commit?
-// 		lastCommit := prCommits[1].(map[string]interface{})
-// 		lastAuthor := lastCommit["author"].(map[string]interface{})
-// 		return lastCommit["hash"].(string), lastAuthor["user"].(map[string]interface{})["display_name"].(string), nil
-
-// 	} else {
-// 		return "", "", fmt.Errorf("failed to get PR commits, got HTTP status %d", response.Resp.StatusCode)
-// 	}
-// }
+type bitbucketActivityPage struct {
+	Values []struct {
+		Approval *struct {
+			Date string                 `json:"date"`
+			User map[string]interface{} `json:"user"`
+		} `json:"approval"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// getApprovalTimesFromBitbucket returns the time of the latest approval of each user in the activity
+// of a pull request, by account id, following pagination
+func (c *Config) getApprovalTimesFromBitbucket(url string) (map[string]int64, error) {
+	approvedAt := make(map[string]int64)
+	for url != "" {
+		var page bitbucketActivityPage
+		err := c.getJSON(url, &page)
+		if err != nil {
+			return approvedAt, err
+		}
+		for _, activity := range page.Values {
+			if activity.Approval == nil {
+				continue
+			}
+			user := bitbucketUser(activity.Approval.User).Login
+			if date := parseBitbucketTime(activity.Approval.Date); date > approvedAt[user] {
+				approvedAt[user] = date
+			}
+		}
+		url = page.Next
+	}
+	return approvedAt, nil
+}
+
+type bitbucketCommitsPage struct {
+	Values []struct {
+		Hash   string `json:"hash"`
+		Date   string `json:"date"`
+		Author struct {
+			Raw  string                 `json:"raw"`
+			User map[string]interface{} `json:"user"`
+		} `json:"author"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// getPullRequestCommitsFromBitbucket returns the commits of a pull request, following pagination
+func (c *Config) getPullRequestCommitsFromBitbucket(url string) ([]types.PRCommit, error) {
+	commits := []types.PRCommit{}
+	for url != "" {
+		c.Logger.Debug("getting pull request commits from " + url)
+		reqParams := c.newRequestParams(url)
+		response, err := c.KosliClient.Do(reqParams)
+		if err != nil {
+			return commits, err
+		}
+		if response.Resp.StatusCode != 200 {
+			return commits, fmt.Errorf("failed to get PR commits, got HTTP status %d", response.Resp.StatusCode)
+		}
+		var page bitbucketCommitsPage
+		err = json.Unmarshal([]byte(response.Body), &page)
+		if err != nil {
+			return commits, err
+		}
+		for _, commit := range page.Values {
+			author := bitbucketUser(commit.Author.User)
+			if author.Name == "" {
+				// commits from users without a Bitbucket account only have a raw 'Name <email>' author
+				author.Name = strings.TrimSpace(strings.Split(commit.Author.Raw, "<")[0])
+			}
+			commits = append(commits, types.PRCommit{
+				SHA:       commit.Hash,
+				Author:    author,
+				Timestamp: parseBitbucketTime(commit.Date),
+			})
+		}
+		url = page.Next
+	}
+	return commits, nil
+}
+
+// bitbucketUser converts a Bitbucket user object to a PRUser identified by its account id
+func bitbucketUser(userInterface interface{}) types.PRUser {
+	user, ok := userInterface.(map[string]interface{})
+	if !ok {
+		return types.PRUser{}
+	}
+	accountID, _ := user["account_id"].(string)
+	displayName, _ := user["display_name"].(string)
+	return types.PRUser{Login: accountID, Name: displayName}
+}
+
+// parseBitbucketTime returns a Bitbucket timestamp in unix seconds, or 0 if it cannot be parsed
+func parseBitbucketTime(value string) int64 {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,35 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/logger"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetApprovalTimesFromBitbucket(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values": [
+				{"approval": {"date": "2023-05-01T10:00:00+00:00", "user": {"account_id": "bob"}}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values": [
+			{"comment": {"created_on": "2023-05-01T14:00:00+00:00", "user": {"account_id": "bob"}}},
+			{"update": {"date": "2023-05-01T12:00:00+00:00", "author": {"account_id": "alice"}}},
+			{"approval": {"date": "2023-05-01T11:00:00+00:00", "user": {"account_id": "bob"}}}],
+			"next": "` + server.URL + `/activity?page=2"}`))
+	}))
+	defer server.Close()
+	log := logger.NewLogger(io.Discard, io.Discard, false)
+	config := &Config{AccessToken: "secret", Logger: log, KosliClient: requests.NewKosliClient(1, false, log)}
+
+	approvedAt, err := config.getApprovalTimesFromBitbucket(server.URL + "/activity")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"bob": 1682938800}, approvedAt)
+}
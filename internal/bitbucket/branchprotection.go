@@ -0,0 +1,119 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+type branchRestrictionsPage struct {
+	Values []struct {
+		Kind            string `json:"kind"`
+		Value           *int   `json:"value"`
+		Pattern         string `json:"pattern"`
+		BranchMatchKind string `json:"branch_match_kind"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+type commitStatusesPage struct {
+	Values []struct {
+		Key   string `json:"key"`
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// BranchProtectionForCommit returns the branch restrictions of a branch and the build statuses of a commit.
+// A branch is protected if at least one branch restriction applies to it.
+func (c *Config) BranchProtectionForCommit(branch, commit string) (*types.BranchProtection, error) {
+	protection := &types.BranchProtection{Branch: branch, Commit: commit, RequiredChecks: []string{}, Checks: []types.CheckStatus{}}
+	if c.BaseURL != "" {
+		return protection, fmt.Errorf("branch protection evidence is not supported for Bitbucket Data Center")
+	}
+
+	preventForcePushes := false
+	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/branch-restrictions", c.Workspace, c.Repository)
+	for url != "" {
+		var page branchRestrictionsPage
+		err := c.getJSON(url, &page)
+		if err != nil {
+			return protection, err
+		}
+		for _, restriction := range page.Values {
+			if !restrictionAppliesTo(restriction.BranchMatchKind, restriction.Pattern, branch) {
+				continue
+			}
+			protection.Protected = true
+			value := 0
+			if restriction.Value != nil {
+				value = *restriction.Value
+			}
+			switch restriction.Kind {
+			case "force":
+				preventForcePushes = true
+			case "require_approvals_to_merge":
+				if value > protection.RequiredApprovals {
+					protection.RequiredApprovals = value
+				}
+			case "require_default_reviewer_approvals_to_merge":
+				protection.RequireCodeOwnerReviews = true
+			case "reset_pullrequest_approvals_on_change":
+				protection.DismissStaleReviews = true
+			case "require_passing_builds_to_merge":
+				protection.RequirePassingChecks = true
+			}
+		}
+		url = page.Next
+	}
+	if protection.Protected {
+		allowForcePushes := !preventForcePushes
+		protection.AllowForcePushes = &allowForcePushes
+	}
+
+	url = fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/commit/%s/statuses", c.Workspace, c.Repository, commit)
+	for url != "" {
+		var page commitStatusesPage
+		err := c.getJSON(url, &page)
+		if err != nil {
+			return protection, err
+		}
+		for _, status := range page.Values {
+			name := status.Name
+			if name == "" {
+				name = status.Key
+			}
+			protection.Checks = append(protection.Checks, types.CheckStatus{Name: name, State: types.NormalizeCheckState(status.State)})
+		}
+		url = page.Next
+	}
+	return protection, nil
+}
+
+// restrictionAppliesTo returns true if a glob branch restriction matches the branch.
+// Restrictions on branch types of the branching model are not matched.
+func restrictionAppliesTo(matchKind, pattern, branch string) bool {
+	if matchKind != "glob" {
+		return false
+	}
+	matched, err := path.Match(pattern, branch)
+	return err == nil && matched
+}
+
+// getJSON gets a Bitbucket API url and decodes the JSON response into v
+func (c *Config) getJSON(url string, v interface{}) error {
+	c.Logger.Debug("getting " + url)
+	reqParams := c.newRequestParams(url)
+	response, err := c.KosliClient.Do(reqParams)
+	if err != nil {
+		return err
+	}
+	if response.Resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s, got HTTP status %d. Please review repository permissions", url, response.Resp.StatusCode)
+	}
+	return json.Unmarshal([]byte(response.Body), v)
+}
@@ -0,0 +1,225 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+// dataCenterPage is a page of a paged Bitbucket Data Center API response
+type dataCenterPage struct {
+	Values        json.RawMessage `json:"values"`
+	IsLastPage    bool            `json:"isLastPage"`
+	NextPageStart int             `json:"nextPageStart"`
+}
+
+type dataCenterUser struct {
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type dataCenterPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Author      struct {
+		User dataCenterUser `json:"user"`
+	} `json:"author"`
+	Reviewers []struct {
+		User     dataCenterUser `json:"user"`
+		Approved bool           `json:"approved"`
+	} `json:"reviewers"`
+	Links struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+	Properties struct {
+		MergeCommit *struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+type dataCenterCommit struct {
+	ID     string         `json:"id"`
+	Author dataCenterUser `json:"author"`
+	// CommitterTimestamp is when the commit was last rewritten, e.g. by a rebase or an amend,
+	// which the author timestamp does not change
+	CommitterTimestamp int64 `json:"committerTimestamp"`
+}
+
+type dataCenterActivity struct {
+	Action      string         `json:"action"`
+	CreatedDate int64          `json:"createdDate"`
+	User        dataCenterUser `json:"user"`
+}
+
+// dataCenterRepoURL returns the REST API URL of the repository on the Bitbucket Data Center server
+func (c *Config) dataCenterRepoURL() string {
+	return fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s", strings.TrimSuffix(c.BaseURL, "/"),
+		url.PathEscape(c.Workspace), url.PathEscape(c.Repository))
+}
+
+// getPullRequestsFromDataCenter returns the pull requests containing a commit on Bitbucket Data Center
+func (c *Config) getPullRequestsFromDataCenter(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	pullRequests := []dataCenterPullRequest{}
+	err := c.getDataCenterPages(fmt.Sprintf("%s/commits/%s/pull-requests", c.dataCenterRepoURL(), commit), func(values json.RawMessage) error {
+		page := []dataCenterPullRequest{}
+		err := json.Unmarshal(values, &page)
+		pullRequests = append(pullRequests, page...)
+		return err
+	})
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+
+	for _, pr := range pullRequests {
+		evidence, err := c.dataCenterPullRequestEvidence(pr, commit)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// getPullRequestFromDataCenter returns the evidence of a pull request by its ID on Bitbucket Data Center
+func (c *Config) getPullRequestFromDataCenter(number int) (*types.PREvidence, error) {
+	var pr dataCenterPullRequest
+	err := c.getJSON(fmt.Sprintf("%s/pull-requests/%d", c.dataCenterRepoURL(), number), &pr)
+	if err != nil {
+		return nil, err
+	}
+	return c.dataCenterPullRequestEvidence(pr, "")
+}
+
+// getMergedPullRequestsFromDataCenter returns the evidence of the merged pull requests whose merge commit is commit.
+// Only the 100 most recently updated merged pull requests are searched.
+func (c *Config) getMergedPullRequestsFromDataCenter(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	var page struct {
+		Values []dataCenterPullRequest `json:"values"`
+	}
+	err := c.getJSON(c.dataCenterRepoURL()+"/pull-requests?state=MERGED&order=NEWEST&limit=100", &page)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range page.Values {
+		if pr.Properties.MergeCommit == nil || pr.Properties.MergeCommit.ID != commit {
+			continue
+		}
+		evidence, err := c.dataCenterPullRequestEvidence(pr, commit)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// getCommitMessageFromDataCenter returns the message of a commit on Bitbucket Data Center
+func (c *Config) getCommitMessageFromDataCenter(commit string) (string, error) {
+	var dataCenterCommit struct {
+		Message string `json:"message"`
+	}
+	err := c.getJSON(fmt.Sprintf("%s/commits/%s", c.dataCenterRepoURL(), commit), &dataCenterCommit)
+	return dataCenterCommit.Message, err
+}
+
+func (c *Config) dataCenterPullRequestEvidence(pr dataCenterPullRequest, commit string) (*types.PREvidence, error) {
+	prURL := fmt.Sprintf("%s/pull-requests/%d", c.dataCenterRepoURL(), pr.ID)
+	evidence := &types.PREvidence{
+		MergeCommit: commit,
+		Title:       pr.Title,
+		Body:        pr.Description,
+		State:       pr.State,
+		Merged:      pr.State == "MERGED",
+		Approvers:   []string{},
+		Author:      dataCenterPRUser(pr.Author.User),
+	}
+	if len(pr.Links.Self) > 0 {
+		evidence.URL = pr.Links.Self[0].Href
+	}
+	if pr.Properties.MergeCommit != nil && pr.Properties.MergeCommit.ID != "" {
+		evidence.MergeCommit = pr.Properties.MergeCommit.ID
+	}
+
+	// activities are returned newest first, so the first approval of a user is the latest one
+	approvedAt := make(map[string]int64)
+	err := c.getDataCenterPages(prURL+"/activities", func(values json.RawMessage) error {
+		activities := []dataCenterActivity{}
+		err := json.Unmarshal(values, &activities)
+		for _, activity := range activities {
+			if _, ok := approvedAt[activity.User.Name]; !ok && activity.Action == "APPROVED" {
+				approvedAt[activity.User.Name] = activity.CreatedDate / 1000
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return evidence, err
+	}
+	for _, reviewer := range pr.Reviewers {
+		if reviewer.Approved {
+			evidence.Approvers = append(evidence.Approvers, reviewer.User.DisplayName)
+			evidence.Reviews = append(evidence.Reviews, types.PRReview{
+				Reviewer:  dataCenterPRUser(reviewer.User),
+				Timestamp: approvedAt[reviewer.User.Name],
+			})
+		}
+	}
+	if len(evidence.Approvers) == 0 {
+		c.Logger.Debug("no approvers found")
+	}
+
+	err = c.getDataCenterPages(prURL+"/commits", func(values json.RawMessage) error {
+		commits := []dataCenterCommit{}
+		err := json.Unmarshal(values, &commits)
+		for _, commit := range commits {
+			evidence.Commits = append(evidence.Commits, types.PRCommit{
+				SHA:       commit.ID,
+				Author:    dataCenterPRUser(commit.Author),
+				Timestamp: commit.CommitterTimestamp / 1000,
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return evidence, err
+	}
+	evidence.AnalyzeFourEyes()
+	return evidence, nil
+}
+
+// getDataCenterPages gets all the pages of a paged Bitbucket Data Center API URL,
+// and calls handle with the values of each page
+func (c *Config) getDataCenterPages(apiURL string, handle func(values json.RawMessage) error) error {
+	start := 0
+	for {
+		var page dataCenterPage
+		err := c.getJSON(fmt.Sprintf("%s?start=%d&limit=100", apiURL, start), &page)
+		if err != nil {
+			return err
+		}
+		err = handle(page.Values)
+		if err != nil {
+			return err
+		}
+		if page.IsLastPage || page.NextPageStart <= start {
+			return nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+// dataCenterPRUser converts a Bitbucket Data Center user to a PRUser identified by its user name
+func dataCenterPRUser(user dataCenterUser) types.PRUser {
+	return types.PRUser{Login: user.Name, Name: user.DisplayName}
+}
@@ -0,0 +1,137 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/logger"
+	"github.com/kosli-dev/cli/internal/requests"
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DataCenterTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	config *Config
+}
+
+const (
+	dataCenterCommitWithPR   = "5e7a1b2c3d4e5f60718293a4b5c6d7e8f9012345"
+	dataCenterSquashedCommit = "0718293a4b5c6d7e8f90123455e7a1b2c3d4e5f6"
+)
+
+// SetupTest starts a stand-in for the Bitbucket Data Center API with one merged pull request for dataCenterCommitWithPR
+func (suite *DataCenterTestSuite) SetupTest() {
+	repoPath := "/rest/api/1.0/projects/KOS/repos/cli"
+	pr := `{
+			"id": 12, "state": "MERGED",
+			"author": {"user": {"name": "alice", "displayName": "Alice Smith"}},
+			"reviewers": [
+				{"user": {"name": "bob", "displayName": "Bob Jones"}, "approved": true},
+				{"user": {"name": "carol", "displayName": "Carol White"}, "approved": false}],
+			"links": {"self": [{"href": "https://bitbucket.example.com/projects/KOS/repos/cli/pull-requests/12"}]},
+			"properties": {"mergeCommit": {"id": "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"}}}`
+	responses := map[string]string{
+		repoPath + "/commits/" + dataCenterCommitWithPR + "/pull-requests": `{"isLastPage": true, "values": [` + pr + `]}`,
+		repoPath + "/pull-requests/12":                                     pr,
+		repoPath + "/commits/" + dataCenterSquashedCommit:                  `{"id": "` + dataCenterSquashedCommit + `", "message": "Pull request #12: Add feature"}`,
+		repoPath + "/pull-requests/12/activities": `{"isLastPage": true, "values": [
+			{"action": "MERGED", "createdDate": 1682946000000, "user": {"name": "alice"}},
+			{"action": "APPROVED", "createdDate": 1682942400000, "user": {"name": "bob"}},
+			{"action": "APPROVED", "createdDate": 1682938800000, "user": {"name": "bob"}}]}`,
+		repoPath + "/pull-requests/12/commits": `{"isLastPage": true, "values": [
+			{"id": "c1", "author": {"name": "alice", "displayName": "Alice Smith"}, "authorTimestamp": 1682931600000, "committerTimestamp": 1682935200000}]}`,
+		repoPath + "/pull-requests/13": strings.ReplaceAll(pr, `"id": 12`, `"id": 13`),
+		repoPath + "/pull-requests/13/activities": `{"isLastPage": true, "values": [
+			{"action": "APPROVED", "createdDate": 1682942400000, "user": {"name": "bob"}}]}`,
+		repoPath + "/pull-requests/13/commits": `{"isLastPage": true, "values": [
+			{"id": "c2", "author": {"name": "alice", "displayName": "Alice Smith"}, "authorTimestamp": 1682935200000, "committerTimestamp": 1682946000000}]}`,
+	}
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": [{"message": "not found"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	log := logger.NewLogger(io.Discard, io.Discard, false)
+	suite.config = &Config{
+		AccessToken: "secret",
+		BaseURL:     suite.server.URL + "/",
+		Workspace:   "KOS",
+		Repository:  "cli",
+		Logger:      log,
+		KosliClient: requests.NewKosliClient(1, false, log),
+	}
+}
+
+func (suite *DataCenterTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *DataCenterTestSuite) TestPREvidenceForCommit() {
+	evidence, err := suite.config.PREvidenceForCommit(dataCenterCommitWithPR)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evidence, 1)
+
+	pr := evidence[0]
+	require.Equal(suite.T(), "https://bitbucket.example.com/projects/KOS/repos/cli/pull-requests/12", pr.URL)
+	require.Equal(suite.T(), "MERGED", pr.State)
+	require.Equal(suite.T(), "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", pr.MergeCommit)
+	require.Equal(suite.T(), []string{"Bob Jones"}, pr.Approvers)
+	require.Equal(suite.T(), "alice", pr.Author.Login)
+	require.Len(suite.T(), pr.Reviews, 1)
+	require.Equal(suite.T(), int64(1682942400), pr.Reviews[0].Timestamp)
+	require.Equal(suite.T(), "c1", pr.LastCommit)
+	require.True(suite.T(), pr.FourEyes.Passed)
+}
+
+func (suite *DataCenterTestSuite) TestPREvidenceForNumberWithACommitRebasedAfterTheApproval() {
+	evidence, err := suite.config.PREvidenceForNumber(13)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []types.PRCommit{{SHA: "c2", Author: types.PRUser{Login: "alice", Name: "Alice Smith"}, Timestamp: 1682946000}}, evidence.Commits)
+	require.False(suite.T(), evidence.FourEyes.Passed)
+}
+
+func (suite *DataCenterTestSuite) TestPREvidenceForNumberFromCommitMessage() {
+	message, err := suite.config.CommitMessage(dataCenterSquashedCommit)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []int{12}, types.PRNumbersFromCommitMessage(message))
+
+	evidence, err := suite.config.PREvidenceForNumber(12)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", evidence.MergeCommit)
+	require.Equal(suite.T(), []string{"Bob Jones"}, evidence.Approvers)
+}
+
+func (suite *DataCenterTestSuite) TestPREvidenceForCommitWithoutPRs() {
+	suite.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"isLastPage": true, "values": []}`))
+	})
+	evidence, err := suite.config.PREvidenceForCommit(dataCenterCommitWithPR)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), evidence)
+}
+
+func (suite *DataCenterTestSuite) TestPREvidenceForCommitFailsForUnknownRepository() {
+	suite.config.Repository = "unknown"
+	_, err := suite.config.PREvidenceForCommit(dataCenterCommitWithPR)
+	require.ErrorContains(suite.T(), err, "not found")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestDataCenterTestSuite(t *testing.T) {
+	suite.Run(t, new(DataCenterTestSuite))
+}
@@ -0,0 +1,67 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var conditionCoveragePattern = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+type coberturaReport struct {
+	Packages []struct {
+		Name    string `xml:"name,attr"`
+		Classes []struct {
+			Filename string          `xml:"filename,attr"`
+			Lines    []coberturaLine `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int64  `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"`
+}
+
+// parseCobertura adds the lines of a Cobertura XML report to the report
+func (r *report) parseCobertura(content []byte) error {
+	var cobertura coberturaReport
+	if err := xml.Unmarshal(content, &cobertura); err != nil {
+		return err
+	}
+	for _, pkg := range cobertura.Packages {
+		for _, class := range pkg.Classes {
+			for _, l := range class.Lines {
+				covered, valid, err := coberturaBranches(l)
+				if err != nil {
+					return err
+				}
+				r.addLine(pkg.Name, fmt.Sprintf("%s:%d", class.Filename, l.Number), line{
+					statements:      1,
+					covered:         l.Hits > 0,
+					branchesCovered: covered,
+					branchesValid:   valid,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// coberturaBranches returns the covered and valid branches of a line from its
+// condition-coverage attribute, e.g. condition-coverage="50% (1/2)"
+func coberturaBranches(l coberturaLine) (int, int, error) {
+	if !l.Branch || l.ConditionCoverage == "" {
+		return 0, 0, nil
+	}
+	match := conditionCoveragePattern.FindStringSubmatch(l.ConditionCoverage)
+	if match == nil {
+		return 0, 0, fmt.Errorf("invalid condition-coverage '%s' on line %d", l.ConditionCoverage, l.Number)
+	}
+	covered, _ := strconv.Atoi(match[1])
+	valid, _ := strconv.Atoi(match[2])
+	return covered, valid, nil
+}
@@ -0,0 +1,246 @@
+package coverage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Supported coverage report formats
+const (
+	Cobertura   = "cobertura"
+	LCOV        = "lcov"
+	JaCoCo      = "jacoco"
+	GoCoverProf = "go-coverprofile"
+)
+
+// Summary is the line and branch coverage of one or more coverage reports
+type Summary struct {
+	Formats []string `json:"formats"`
+	Counts
+	Packages []PackageSummary `json:"packages"`
+}
+
+// PackageSummary is the line and branch coverage of a package
+type PackageSummary struct {
+	Name string `json:"name"`
+	Counts
+}
+
+// Counts are the covered and valid lines and branches of a report or a package.
+// Go coverprofiles only report statements, which are counted as lines.
+type Counts struct {
+	LinesCovered    int      `json:"lines_covered"`
+	LinesValid      int      `json:"lines_valid"`
+	LineCoverage    float64  `json:"line_coverage"`
+	BranchesCovered int      `json:"branches_covered"`
+	BranchesValid   int      `json:"branches_valid"`
+	BranchCoverage  *float64 `json:"branch_coverage,omitempty"`
+}
+
+// Thresholds decide whether a coverage summary is compliant.
+// A zero threshold is not checked.
+type Thresholds struct {
+	MinLineCoverage   float64
+	MinBranchCoverage float64
+}
+
+// report accumulates the coverage of the packages in coverage reports.
+// Line based reports are merged per line, so that a line covered in any report is covered.
+// JaCoCo reports only contain totals per package, which are added up.
+type report struct {
+	formats  []string
+	lines    map[string]map[string]*line
+	counters map[string]*Counts
+}
+
+// line is the coverage of a line, or of a Go statement block with several statements
+type line struct {
+	statements      int
+	covered         bool
+	branchesCovered int
+	branchesValid   int
+}
+
+func newReport() *report {
+	return &report{
+		lines:    make(map[string]map[string]*line),
+		counters: make(map[string]*Counts),
+	}
+}
+
+// addLine merges the coverage of a line in a package, identified by key, into the report
+func (r *report) addLine(pkg, key string, l line) {
+	if _, ok := r.lines[pkg]; !ok {
+		r.lines[pkg] = make(map[string]*line)
+	}
+	existing, ok := r.lines[pkg][key]
+	if !ok {
+		r.lines[pkg][key] = &l
+		return
+	}
+	existing.covered = existing.covered || l.covered
+	if l.branchesValid > existing.branchesValid {
+		existing.branchesValid = l.branchesValid
+	}
+	if l.branchesCovered > existing.branchesCovered {
+		existing.branchesCovered = l.branchesCovered
+	}
+}
+
+// addCounts adds package totals to the report
+func (r *report) addCounts(pkg string, counts Counts) {
+	if _, ok := r.counters[pkg]; !ok {
+		r.counters[pkg] = &Counts{}
+	}
+	r.counters[pkg].LinesCovered += counts.LinesCovered
+	r.counters[pkg].LinesValid += counts.LinesValid
+	r.counters[pkg].BranchesCovered += counts.BranchesCovered
+	r.counters[pkg].BranchesValid += counts.BranchesValid
+}
+
+func (r *report) addFormat(format string) {
+	for _, f := range r.formats {
+		if f == format {
+			return
+		}
+	}
+	r.formats = append(r.formats, format)
+}
+
+// ParseFiles parses coverage reports and returns the combined coverage summary.
+// The format of each report is detected from its content.
+func ParseFiles(paths []string) (*Summary, error) {
+	r := newReport()
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = r.parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid coverage report: %v", path, err)
+		}
+	}
+	return r.summary(), nil
+}
+
+// parse detects the format of a coverage report and adds its counts to the report
+func (r *report) parse(content []byte) error {
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		r.addFormat(GoCoverProf)
+		return r.parseGoCoverProfile(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		root, err := xmlRootElement(trimmed)
+		if err != nil {
+			return err
+		}
+		switch root {
+		case "coverage":
+			r.addFormat(Cobertura)
+			return r.parseCobertura(trimmed)
+		case "report":
+			r.addFormat(JaCoCo)
+			return r.parseJaCoCo(trimmed)
+		}
+		return fmt.Errorf("unsupported XML root element <%s>, expected a Cobertura or JaCoCo report", root)
+	case lcovPattern.Match(trimmed):
+		r.addFormat(LCOV)
+		return r.parseLCOV(trimmed)
+	}
+	return fmt.Errorf("unknown format, expected one of: [%s, %s, %s, %s]", Cobertura, LCOV, JaCoCo, GoCoverProf)
+}
+
+func (r *report) summary() *Summary {
+	packages := make(map[string]*Counts)
+	for name, counts := range r.counters {
+		packages[name] = counts
+	}
+	for name, lines := range r.lines {
+		if _, ok := packages[name]; !ok {
+			packages[name] = &Counts{}
+		}
+		for _, l := range lines {
+			packages[name].LinesValid += l.statements
+			if l.covered {
+				packages[name].LinesCovered += l.statements
+			}
+			packages[name].BranchesValid += l.branchesValid
+			packages[name].BranchesCovered += l.branchesCovered
+		}
+	}
+
+	summary := &Summary{Formats: r.formats, Packages: []PackageSummary{}}
+	for name, counts := range packages {
+		counts.computeRates()
+		summary.Packages = append(summary.Packages, PackageSummary{Name: name, Counts: *counts})
+		summary.LinesCovered += counts.LinesCovered
+		summary.LinesValid += counts.LinesValid
+		summary.BranchesCovered += counts.BranchesCovered
+		summary.BranchesValid += counts.BranchesValid
+	}
+	summary.computeRates()
+	sort.Slice(summary.Packages, func(i, j int) bool {
+		return summary.Packages[i].Name < summary.Packages[j].Name
+	})
+	return summary
+}
+
+func (c *Counts) computeRates() {
+	c.LineCoverage = percentage(c.LinesCovered, c.LinesValid)
+	if c.BranchesValid > 0 {
+		branchCoverage := percentage(c.BranchesCovered, c.BranchesValid)
+		c.BranchCoverage = &branchCoverage
+	}
+}
+
+// percentage returns covered/valid as a percentage rounded to 2 decimals.
+// An empty report has no coverage.
+func percentage(covered, valid int) float64 {
+	if valid == 0 {
+		return 0
+	}
+	return math.Round(float64(covered)/float64(valid)*10000) / 100
+}
+
+// Evaluate returns whether the summary is compliant with the thresholds,
+// and the reasons why it is not
+func (s *Summary) Evaluate(thresholds Thresholds) (bool, []string) {
+	violations := []string{}
+	if (thresholds.MinLineCoverage > 0 || thresholds.MinBranchCoverage > 0) && s.LinesValid == 0 {
+		return false, []string{"the coverage report(s) have no measurable lines"}
+	}
+	if thresholds.MinLineCoverage > 0 && s.LineCoverage < thresholds.MinLineCoverage {
+		violations = append(violations, fmt.Sprintf("line coverage %.2f%% is below the minimum of %.2f%%",
+			s.LineCoverage, thresholds.MinLineCoverage))
+	}
+	if thresholds.MinBranchCoverage > 0 {
+		if s.BranchCoverage == nil {
+			violations = append(violations, "branch coverage is not available in the coverage report(s)")
+		} else if *s.BranchCoverage < thresholds.MinBranchCoverage {
+			violations = append(violations, fmt.Sprintf("branch coverage %.2f%% is below the minimum of %.2f%%",
+				*s.BranchCoverage, thresholds.MinBranchCoverage))
+		}
+	}
+	return len(violations) == 0, violations
+}
+
+// xmlRootElement returns the name of the root element of an XML document
+func xmlRootElement(content []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package coverage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/utils"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const coberturaXML = `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.75" branch-rate="0.5" version="1.9" timestamp="1687426460">
+  <packages>
+    <package name="app">
+      <classes>
+        <class name="main.py" filename="app/main.py">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="3" branch="true" condition-coverage="50% (1/2)"/>
+            <line number="3" hits="0"/>
+            <line number="4" hits="2"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+const jacocoXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="example">
+  <package name="com/example/app">
+    <class name="com/example/app/Main"/>
+    <counter type="INSTRUCTION" missed="10" covered="90"/>
+    <counter type="BRANCH" missed="1" covered="3"/>
+    <counter type="LINE" missed="2" covered="8"/>
+  </package>
+  <group name="module">
+    <package name="com/example/lib">
+      <counter type="LINE" missed="5" covered="5"/>
+    </package>
+  </group>
+  <counter type="LINE" missed="7" covered="13"/>
+</report>`
+
+const lcovInfo = `TN:
+SF:src/lib/math.js
+DA:1,1
+DA:2,0
+DA:3,5
+BRDA:3,0,0,1
+BRDA:3,0,1,-
+LF:3
+LH:2
+end_of_record
+SF:src/index.js
+DA:1,1
+end_of_record
+`
+
+const goCoverProfile = `mode: set
+github.com/kosli-dev/cli/internal/utils/utils.go:10.40,12.2 2 1
+github.com/kosli-dev/cli/internal/utils/utils.go:14.40,16.2 1 0
+github.com/kosli-dev/cli/internal/version/version.go:5.20,7.2 1 1
+`
+
+// the second test run covers the block that was not covered by the first one
+const goCoverProfileSecondRun = `mode: set
+github.com/kosli-dev/cli/internal/utils/utils.go:14.40,16.2 1 1
+`
+
+func float(f float64) *float64 {
+	return &f
+}
+
+type CoverageTestSuite struct {
+	suite.Suite
+	tmpDir string
+}
+
+func (suite *CoverageTestSuite) SetupTest() {
+	var err error
+	suite.tmpDir, err = os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err, "error creating a temporary test directory")
+}
+
+func (suite *CoverageTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoErrorf(suite.T(), err, "error cleaning up the temporary test directory %s", suite.tmpDir)
+}
+
+func (suite *CoverageTestSuite) TestParseFiles() {
+	for _, t := range []struct {
+		name      string
+		contents  []string
+		wantError bool
+		want      *Summary
+	}{
+		{
+			name:     "Cobertura XML is parsed",
+			contents: []string{coberturaXML},
+			want: &Summary{
+				Formats: []string{Cobertura},
+				Counts:  Counts{LinesCovered: 3, LinesValid: 4, LineCoverage: 75, BranchesCovered: 1, BranchesValid: 2, BranchCoverage: float(50)},
+				Packages: []PackageSummary{
+					{Name: "app", Counts: Counts{LinesCovered: 3, LinesValid: 4, LineCoverage: 75, BranchesCovered: 1, BranchesValid: 2, BranchCoverage: float(50)}},
+				},
+			},
+		},
+		{
+			name:     "JaCoCo XML is parsed, including grouped packages",
+			contents: []string{jacocoXML},
+			want: &Summary{
+				Formats: []string{JaCoCo},
+				Counts:  Counts{LinesCovered: 13, LinesValid: 20, LineCoverage: 65, BranchesCovered: 3, BranchesValid: 4, BranchCoverage: float(75)},
+				Packages: []PackageSummary{
+					{Name: "com.example.app", Counts: Counts{LinesCovered: 8, LinesValid: 10, LineCoverage: 80, BranchesCovered: 3, BranchesValid: 4, BranchCoverage: float(75)}},
+					{Name: "com.example.lib", Counts: Counts{LinesCovered: 5, LinesValid: 10, LineCoverage: 50}},
+				},
+			},
+		},
+		{
+			name:     "LCOV is parsed and files are grouped by directory",
+			contents: []string{lcovInfo},
+			want: &Summary{
+				Formats: []string{LCOV},
+				Counts:  Counts{LinesCovered: 3, LinesValid: 4, LineCoverage: 75, BranchesCovered: 1, BranchesValid: 2, BranchCoverage: float(50)},
+				Packages: []PackageSummary{
+					{Name: "src", Counts: Counts{LinesCovered: 1, LinesValid: 1, LineCoverage: 100}},
+					{Name: "src/lib", Counts: Counts{LinesCovered: 2, LinesValid: 3, LineCoverage: 66.67, BranchesCovered: 1, BranchesValid: 2, BranchCoverage: float(50)}},
+				},
+			},
+		},
+		{
+			name:     "Go coverprofile statements are counted as lines",
+			contents: []string{goCoverProfile},
+			want: &Summary{
+				Formats: []string{GoCoverProf},
+				Counts:  Counts{LinesCovered: 3, LinesValid: 4, LineCoverage: 75},
+				Packages: []PackageSummary{
+					{Name: "github.com/kosli-dev/cli/internal/utils", Counts: Counts{LinesCovered: 2, LinesValid: 3, LineCoverage: 66.67}},
+					{Name: "github.com/kosli-dev/cli/internal/version", Counts: Counts{LinesCovered: 1, LinesValid: 1, LineCoverage: 100}},
+				},
+			},
+		},
+		{
+			name:     "blocks covered in any of several Go coverprofiles are covered",
+			contents: []string{goCoverProfile, goCoverProfileSecondRun},
+			want: &Summary{
+				Formats: []string{GoCoverProf},
+				Counts:  Counts{LinesCovered: 4, LinesValid: 4, LineCoverage: 100},
+				Packages: []PackageSummary{
+					{Name: "github.com/kosli-dev/cli/internal/utils", Counts: Counts{LinesCovered: 3, LinesValid: 3, LineCoverage: 100}},
+					{Name: "github.com/kosli-dev/cli/internal/version", Counts: Counts{LinesCovered: 1, LinesValid: 1, LineCoverage: 100}},
+				},
+			},
+		},
+		{
+			name:      "XML that is not a coverage report fails",
+			contents:  []string{`<testsuites></testsuites>`},
+			wantError: true,
+		},
+		{
+			name:      "an invalid Go coverprofile block fails",
+			contents:  []string{"mode: set\nfoo.go 1"},
+			wantError: true,
+		},
+		{
+			name:      "plain text fails",
+			contents:  []string{"this is not a coverage report"},
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			paths := []string{}
+			for i, content := range t.contents {
+				path := filepath.Join(suite.tmpDir, fmt.Sprintf("coverage-%d", i))
+				err := utils.CreateFileWithContent(path, content)
+				require.NoError(suite.T(), err)
+				paths = append(paths, path)
+			}
+
+			summary, err := ParseFiles(paths)
+			if t.wantError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+				require.Equal(suite.T(), t.want, summary)
+			}
+		})
+	}
+}
+
+func (suite *CoverageTestSuite) TestEvaluate() {
+	summary := &Summary{Counts: Counts{LinesCovered: 3, LinesValid: 4, LineCoverage: 75, BranchCoverage: float(50)}}
+	compliant, violations := summary.Evaluate(Thresholds{})
+	require.True(suite.T(), compliant)
+	require.Empty(suite.T(), violations)
+
+	compliant, _ = summary.Evaluate(Thresholds{MinLineCoverage: 75, MinBranchCoverage: 50})
+	require.True(suite.T(), compliant)
+
+	compliant, violations = summary.Evaluate(Thresholds{MinLineCoverage: 80, MinBranchCoverage: 60})
+	require.False(suite.T(), compliant)
+	require.Equal(suite.T(), []string{
+		"line coverage 75.00% is below the minimum of 80.00%",
+		"branch coverage 50.00% is below the minimum of 60.00%",
+	}, violations)
+
+	compliant, violations = (&Summary{Counts: Counts{LinesCovered: 1, LinesValid: 1, LineCoverage: 100}}).Evaluate(Thresholds{MinBranchCoverage: 10})
+	require.False(suite.T(), compliant)
+	require.Equal(suite.T(), []string{"branch coverage is not available in the coverage report(s)"}, violations)
+
+	compliant, violations = (&Summary{}).Evaluate(Thresholds{MinLineCoverage: 10})
+	require.False(suite.T(), compliant)
+	require.Equal(suite.T(), []string{"the coverage report(s) have no measurable lines"}, violations)
+}
+
+func TestCoverageTestSuite(t *testing.T) {
+	suite.Run(t, new(CoverageTestSuite))
+}
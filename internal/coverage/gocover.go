@@ -0,0 +1,45 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// parseGoCoverProfile adds the statement blocks of a Go coverprofile ('go test -coverprofile') to the report.
+// Blocks are grouped into packages by the import path of their file.
+func (r *report) parseGoCoverProfile(content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		record := strings.TrimSpace(scanner.Text())
+		if record == "" || strings.HasPrefix(record, "mode:") {
+			continue
+		}
+		// <file>:<start line>.<start column>,<end line>.<end column> <number of statements> <count>
+		fields := strings.Fields(record)
+		if len(fields) != 3 {
+			return fmt.Errorf("invalid coverprofile block on line %d: %s", lineNumber, record)
+		}
+		separator := strings.LastIndex(fields[0], ":")
+		if separator < 0 {
+			return fmt.Errorf("invalid coverprofile block on line %d: %s", lineNumber, record)
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid coverprofile block on line %d: %s", lineNumber, record)
+		}
+		count, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid coverprofile block on line %d: %s", lineNumber, record)
+		}
+		file := fields[0][:separator]
+		// the same block is repeated when profiles of several test runs are concatenated
+		r.addLine(path.Dir(file), fields[0], line{statements: statements, covered: count > 0})
+	}
+	return scanner.Err()
+}
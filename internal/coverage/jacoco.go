@@ -0,0 +1,54 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+type jacocoReport struct {
+	Packages []struct {
+		Name     string          `xml:"name,attr"`
+		Counters []jacocoCounter `xml:"counter"`
+	} `xml:"package"`
+	Groups []jacocoReport `xml:"group"`
+}
+
+type jacocoCounter struct {
+	Type    string `xml:"type,attr"`
+	Missed  int    `xml:"missed,attr"`
+	Covered int    `xml:"covered,attr"`
+}
+
+// parseJaCoCo adds the package LINE and BRANCH counters of a JaCoCo XML report to the report
+func (r *report) parseJaCoCo(content []byte) error {
+	var jacoco jacocoReport
+	decoder := xml.NewDecoder(strings.NewReader(string(content)))
+	// JaCoCo reports declare a DOCTYPE that points to an external DTD
+	decoder.Strict = false
+	if err := decoder.Decode(&jacoco); err != nil {
+		return err
+	}
+	r.addJaCoCoPackages(jacoco)
+	return nil
+}
+
+func (r *report) addJaCoCoPackages(jacoco jacocoReport) {
+	for _, pkg := range jacoco.Packages {
+		counts := Counts{}
+		for _, counter := range pkg.Counters {
+			switch counter.Type {
+			case "LINE":
+				counts.LinesCovered = counter.Covered
+				counts.LinesValid = counter.Covered + counter.Missed
+			case "BRANCH":
+				counts.BranchesCovered = counter.Covered
+				counts.BranchesValid = counter.Covered + counter.Missed
+			}
+		}
+		// JaCoCo uses '/' as the package separator
+		r.addCounts(strings.ReplaceAll(pkg.Name, "/", "."), counts)
+	}
+	for _, group := range jacoco.Groups {
+		r.addJaCoCoPackages(group)
+	}
+}
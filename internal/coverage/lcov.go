@@ -0,0 +1,63 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var lcovPattern = regexp.MustCompile(`(?m)^(TN|SF):`)
+
+// parseLCOV adds the DA (line) and BRDA (branch) records of an LCOV tracefile to the report.
+// Files are grouped into packages by directory.
+func (r *report) parseLCOV(content []byte) error {
+	sourceFile := ""
+	branches := make(map[string]*line)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		record := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(record, ":")
+		switch key {
+		case "SF":
+			sourceFile = value
+		case "DA":
+			// DA:<line number>,<execution count>[,<checksum>]
+			fields := strings.Split(value, ",")
+			if len(fields) < 2 || sourceFile == "" {
+				return fmt.Errorf("invalid DA record on line %d: %s", lineNumber, record)
+			}
+			hits, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid DA record on line %d: %s", lineNumber, record)
+			}
+			r.addLine(path.Dir(sourceFile), sourceFile+":"+fields[0], line{statements: 1, covered: hits > 0})
+		case "BRDA":
+			// BRDA:<line number>,<block number>,<branch number>,<taken>
+			fields := strings.Split(value, ",")
+			if len(fields) != 4 || sourceFile == "" {
+				return fmt.Errorf("invalid BRDA record on line %d: %s", lineNumber, record)
+			}
+			lineKey := sourceFile + ":" + fields[0]
+			if _, ok := branches[lineKey]; !ok {
+				branches[lineKey] = &line{}
+			}
+			branches[lineKey].branchesValid++
+			if fields[3] != "-" && fields[3] != "0" {
+				branches[lineKey].branchesCovered++
+			}
+		case "end_of_record":
+			for lineKey, l := range branches {
+				r.addLine(path.Dir(sourceFile), lineKey, *l)
+			}
+			branches = make(map[string]*line)
+			sourceFile = ""
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,317 @@
+package gitea
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+const (
+	// pageLimit is the number of items requested per page of paginated Gitea API endpoints
+	pageLimit = 50
+	// requestTimeout is the timeout of each request to the Gitea API
+	requestTimeout = 30 * time.Second
+)
+
+type GiteaConfig struct {
+	Token      string
+	BaseURL    string
+	Org        string
+	Repository string
+	HTTPClient *http.Client
+}
+
+// PullRequest is a Gitea pull request
+type PullRequest struct {
+	Number         int       `json:"number"`
+	HTMLURL        string    `json:"html_url"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	State          string    `json:"state"`
+	Merged         bool      `json:"merged"`
+	MergeCommitSHA string    `json:"merge_commit_sha"`
+	User           giteaUser `json:"user"`
+}
+
+type review struct {
+	User        giteaUser `json:"user"`
+	State       string    `json:"state"`
+	Dismissed   bool      `json:"dismissed"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+type commit struct {
+	SHA    string     `json:"sha"`
+	Author *giteaUser `json:"author"`
+	Commit struct {
+		Author struct {
+			Name string `json:"name"`
+		} `json:"author"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+type giteaUser struct {
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+}
+
+// notFoundError is returned when the Gitea API responds with 404
+type notFoundError struct {
+	url string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("GET %s: 404 not found", e.url)
+}
+
+// NewGiteaConfig returns a new GiteaConfig
+func NewGiteaConfig(token, baseURL, org, repository string) *GiteaConfig {
+	return &GiteaConfig{
+		Token:      token,
+		BaseURL:    baseURL,
+		Org:        org,
+		Repository: repository,
+		HTTPClient: newHTTPClient(),
+	}
+}
+
+// newHTTPClient returns an HTTP client that times out, and retries on connection and server errors
+func newHTTPClient() *http.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.Logger = nil // this silences logging each individual attempt
+	retryClient.HTTPClient.Timeout = requestTimeout
+	return retryClient.StandardClient()
+}
+
+// repository returns repository name from 'org/repository_name' string
+func (c *GiteaConfig) repository() string {
+	repoNameParts := strings.Split(c.Repository, "/")
+	return repoNameParts[len(repoNameParts)-1]
+}
+
+func (c *GiteaConfig) PREvidenceForCommit(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	prs, err := c.PullRequestsForCommit(commit)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range prs {
+		evidence, err := c.newPRGiteaEvidence(pr)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+func (c *GiteaConfig) newPRGiteaEvidence(pr *PullRequest) (*types.PREvidence, error) {
+	state := pr.State
+	if pr.Merged {
+		state = "merged"
+	}
+	evidence := &types.PREvidence{
+		URL:         pr.HTMLURL,
+		Title:       pr.Title,
+		Body:        pr.Body,
+		MergeCommit: pr.MergeCommitSHA,
+		State:       state,
+		Merged:      pr.Merged,
+		Author:      types.PRUser{Login: pr.User.Login, Name: pr.User.FullName},
+	}
+	var err error
+	evidence.Reviews, err = c.GetPullRequestReviews(pr.Number)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.Approvers = []string{}
+	for _, r := range evidence.Reviews {
+		evidence.Approvers = append(evidence.Approvers, r.Reviewer.Login)
+	}
+	evidence.Commits, err = c.GetPullRequestCommits(pr.Number)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.AnalyzeFourEyes()
+	return evidence, nil
+}
+
+// PullRequestsForCommit returns the pull request that introduced a commit, if any.
+// Gitea associates at most one pull request with a commit.
+func (c *GiteaConfig) PullRequestsForCommit(commit string) ([]*PullRequest, error) {
+	pr := &PullRequest{}
+	err := c.get(fmt.Sprintf("commits/%s/pull", commit), pr)
+	var notFound *notFoundError
+	if errors.As(err, &notFound) {
+		return []*PullRequest{}, nil
+	}
+	if err != nil {
+		return []*PullRequest{}, err
+	}
+	return []*PullRequest{pr}, nil
+}
+
+// PREvidenceForNumber returns the evidence of a pull request by its number
+func (c *GiteaConfig) PREvidenceForNumber(number int) (*types.PREvidence, error) {
+	pr := &PullRequest{}
+	err := c.get(fmt.Sprintf("pulls/%d", number), pr)
+	if err != nil {
+		return nil, err
+	}
+	return c.newPRGiteaEvidence(pr)
+}
+
+// PREvidenceForMergeCommit returns the evidence of the closed pull requests whose merge commit is commit.
+// Only the 50 most recently updated closed pull requests are searched.
+func (c *GiteaConfig) PREvidenceForMergeCommit(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	prs := []*PullRequest{}
+	err := c.getWithQuery("pulls", url.Values{"state": {"closed"}, "sort": {"recentupdate"}, "limit": {"50"}}, &prs)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range prs {
+		if pr.MergeCommitSHA != commit {
+			continue
+		}
+		evidence, err := c.newPRGiteaEvidence(pr)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// CommitMessage returns the message of a commit
+func (c *GiteaConfig) CommitMessage(sha string) (string, error) {
+	gitCommit := &struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	}{}
+	err := c.get(fmt.Sprintf("git/commits/%s", sha), gitCommit)
+	if err != nil {
+		return "", err
+	}
+	return gitCommit.Commit.Message, nil
+}
+
+// GetPullRequestReviews returns the approving reviews of a given pull request that were not dismissed
+func (c *GiteaConfig) GetPullRequestReviews(number int) ([]types.PRReview, error) {
+	approvals := []types.PRReview{}
+	for page := 1; ; page++ {
+		reviews := []review{}
+		err := c.getWithQuery(fmt.Sprintf("pulls/%d/reviews", number), pageQuery(page), &reviews)
+		if err != nil {
+			return approvals, err
+		}
+		for _, r := range reviews {
+			if r.State == "APPROVED" && !r.Dismissed {
+				approvals = append(approvals, types.PRReview{
+					Reviewer:  types.PRUser{Login: r.User.Login, Name: r.User.FullName},
+					Timestamp: unixTime(r.SubmittedAt),
+				})
+			}
+		}
+		if len(reviews) < pageLimit {
+			return approvals, nil
+		}
+	}
+}
+
+// GetPullRequestCommits returns the commits of a given pull request
+func (c *GiteaConfig) GetPullRequestCommits(number int) ([]types.PRCommit, error) {
+	prCommits := []types.PRCommit{}
+	for page := 1; ; page++ {
+		commits := []commit{}
+		err := c.getWithQuery(fmt.Sprintf("pulls/%d/commits", number), pageQuery(page), &commits)
+		if err != nil {
+			return prCommits, err
+		}
+		for _, commit := range commits {
+			author := types.PRUser{Name: commit.Commit.Author.Name}
+			if commit.Author != nil {
+				author.Login = commit.Author.Login
+			}
+			prCommits = append(prCommits, types.PRCommit{
+				SHA:       commit.SHA,
+				Author:    author,
+				Timestamp: unixTime(commit.Commit.Committer.Date),
+			})
+		}
+		if len(commits) < pageLimit {
+			return prCommits, nil
+		}
+	}
+}
+
+// pageQuery returns the query parameters to get a page of a paginated Gitea API endpoint
+func pageQuery(page int) url.Values {
+	return url.Values{"page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(pageLimit)}}
+}
+
+// get calls a Gitea repository API endpoint and decodes the JSON response into v
+func (c *GiteaConfig) get(path string, v interface{}) error {
+	return c.getWithQuery(path, nil, v)
+}
+
+// getWithQuery calls a Gitea repository API endpoint with query parameters and decodes the JSON response into v
+func (c *GiteaConfig) getWithQuery(path string, query url.Values, v interface{}) error {
+	endpoint, err := url.JoinPath(c.BaseURL, "api/v1/repos", c.Org, c.repository(), path)
+	if err != nil {
+		return err
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = newHTTPClient()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return &notFoundError{url: endpoint}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %d %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// unixTime returns t in unix seconds, or 0 if t is not set
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,147 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GiteaTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	config *GiteaConfig
+}
+
+const (
+	commitWithPR = "9f1b8c2e4d7a6b5c3e2f1a0b9c8d7e6f5a4b3c2d"
+	prCommit     = "3c2d9f1b8c2e4d7a6b5c3e2f1a0b9c8d7e6f5a4b"
+)
+
+// SetupTest starts a stand-in for the Gitea API with one pull request for commitWithPR
+func (suite *GiteaTestSuite) SetupTest() {
+	pr := `{
+			"number": 7, "html_url": "https://gitea.example.com/kosli/cli/pulls/7",
+			"state": "closed", "merged": true, "merge_commit_sha": "` + commitWithPR + `",
+			"user": {"login": "alice", "full_name": "Alice Smith"}}`
+	responses := map[string]string{
+		"/api/v1/repos/kosli/cli/commits/" + commitWithPR + "/pull": pr,
+		"/api/v1/repos/kosli/cli/pulls/7":                           pr,
+		"/api/v1/repos/kosli/cli/pulls":                             "[" + pr + "]",
+		"/api/v1/repos/kosli/cli/git/commits/" + prCommit:           `{"commit": {"message": "Add feature (#7)"}}`,
+		"/api/v1/repos/kosli/cli/pulls/7/reviews": `[
+			{"user": {"login": "bob"}, "state": "APPROVED", "submitted_at": "2023-05-01T12:00:00Z"},
+			{"user": {"login": "carol"}, "state": "APPROVED", "dismissed": true, "submitted_at": "2023-05-01T11:00:00Z"},
+			{"user": {"login": "dave"}, "state": "REQUEST_CHANGES", "submitted_at": "2023-05-01T10:00:00Z"}]`,
+		"/api/v1/repos/kosli/cli/pulls/7/commits": `[
+			{"sha": "` + prCommit + `", "author": {"login": "alice"},
+			 "commit": {"author": {"name": "Alice Smith"}, "committer": {"date": "2023-05-01T08:00:00Z"}}},
+			{"sha": "c1", "author": {"login": "alice"},
+			 "commit": {"author": {"name": "Alice Smith"}, "committer": {"date": "2023-05-01T09:00:00Z"}}}]`,
+	}
+	fullPage := make([]string, pageLimit)
+	for i := range fullPage {
+		fullPage[i] = fmt.Sprintf(`{"sha": "c%d", "commit": {"author": {"name": "Alice Smith"}}}`, i)
+	}
+	responses["/api/v1/repos/kosli/cli/pulls/9/commits"] = "[" + strings.Join(fullPage, ",") + "]"
+	responses["/api/v1/repos/kosli/cli/pulls/9/commits?page=2"] = `[{"sha": "last", "commit": {"author": {"name": "Alice Smith"}}}]`
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "token is required"}`))
+			return
+		}
+		key := r.URL.Path
+		if page := r.URL.Query().Get("page"); page != "" && page != "1" {
+			key += "?page=" + page
+		}
+		response, ok := responses[key]
+		if !ok && key != r.URL.Path {
+			// pages after the last one are empty
+			response, ok = "[]", true
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	suite.config = NewGiteaConfig("secret", suite.server.URL, "kosli", "kosli/cli")
+}
+
+func (suite *GiteaTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *GiteaTestSuite) TestPREvidenceForCommit() {
+	evidence, err := suite.config.PREvidenceForCommit(commitWithPR)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evidence, 1)
+	pr := evidence[0]
+	require.Equal(suite.T(), "https://gitea.example.com/kosli/cli/pulls/7", pr.URL)
+	require.Equal(suite.T(), "merged", pr.State)
+	require.Equal(suite.T(), commitWithPR, pr.MergeCommit)
+	require.Equal(suite.T(), []string{"bob"}, pr.Approvers)
+	require.Equal(suite.T(), types.PRUser{Login: "alice", Name: "Alice Smith"}, pr.Author)
+	require.Equal(suite.T(), "c1", pr.LastCommit)
+	require.True(suite.T(), pr.FourEyes.Passed)
+}
+
+func (suite *GiteaTestSuite) TestPREvidenceForCommitWithoutPR() {
+	evidence, err := suite.config.PREvidenceForCommit("0000000000000000000000000000000000000000")
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), evidence)
+}
+
+func (suite *GiteaTestSuite) TestFindPREvidence() {
+	evidence, err := types.FindPREvidence(suite.config, prCommit, 0)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evidence, 1)
+	require.Equal(suite.T(), "https://gitea.example.com/kosli/cli/pulls/7", evidence[0].URL)
+	require.Equal(suite.T(), types.PRResolvedByCommitMessage, evidence[0].ResolvedBy)
+
+	evidence, err = suite.config.PREvidenceForMergeCommit(commitWithPR)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evidence, 1)
+
+	evidence, err = types.FindPREvidence(suite.config, "0000000000000000000000000000000000000000", 7)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), evidence, 1)
+	require.Equal(suite.T(), types.PRResolvedByPRNumber, evidence[0].ResolvedBy)
+}
+
+func (suite *GiteaTestSuite) TestGetPullRequestReviews() {
+	reviews, err := suite.config.GetPullRequestReviews(7)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), reviews, 1)
+	require.Equal(suite.T(), "bob", reviews[0].Reviewer.Login)
+
+	_, err = suite.config.GetPullRequestReviews(8)
+	require.EqualError(suite.T(), err, "GET "+suite.server.URL+"/api/v1/repos/kosli/cli/pulls/8/reviews?limit=50&page=1: 404 not found")
+}
+
+func (suite *GiteaTestSuite) TestGetPullRequestCommitsOfAllPages() {
+	commits, err := suite.config.GetPullRequestCommits(9)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), commits, pageLimit+1)
+	require.Equal(suite.T(), "last", commits[pageLimit].SHA)
+}
+
+func (suite *GiteaTestSuite) TestInvalidToken() {
+	config := NewGiteaConfig("wrong", suite.server.URL, "kosli", "cli")
+	_, err := config.PREvidenceForCommit(commitWithPR)
+	require.EqualError(suite.T(), err, "GET "+suite.server.URL+"/api/v1/repos/kosli/cli/commits/"+commitWithPR+
+		"/pull: 401 {\"message\": \"token is required\"}")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestGiteaTestSuite(t *testing.T) {
+	suite.Run(t, new(GiteaTestSuite))
+}
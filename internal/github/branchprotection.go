@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gh "github.com/google/go-github/v42/github"
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+// BranchProtectionForCommit returns the protection rules of a branch
+// and the statuses and check runs reported on a commit
+func (c *GithubConfig) BranchProtectionForCommit(branch, commit string) (*types.BranchProtection, error) {
+	protection := &types.BranchProtection{Branch: branch, Commit: commit, RequiredChecks: []string{}}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return protection, err
+	}
+
+	rules, _, err := client.Repositories.GetBranchProtection(ctx, c.Org, c.Repository, branch)
+	if err != nil && !errors.Is(err, gh.ErrBranchNotProtected) {
+		return protection, err
+	}
+	if rules != nil {
+		protection.Protected = true
+		if reviews := rules.RequiredPullRequestReviews; reviews != nil {
+			protection.RequiredApprovals = reviews.RequiredApprovingReviewCount
+			protection.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+			protection.DismissStaleReviews = reviews.DismissStaleReviews
+		}
+		if rules.RequiredStatusChecks != nil {
+			protection.RequiredChecks = rules.RequiredStatusChecks.Contexts
+		}
+		allowForcePushes := rules.AllowForcePushes != nil && rules.AllowForcePushes.Enabled
+		protection.AllowForcePushes = &allowForcePushes
+		enforceAdmins := rules.EnforceAdmins != nil && rules.EnforceAdmins.Enabled
+		protection.EnforceAdmins = &enforceAdmins
+	}
+
+	rulesetRules, err := c.branchRulesetRules(ctx, client, branch)
+	if err != nil {
+		return protection, err
+	}
+	err = applyRulesetRules(protection, rulesetRules)
+	if err != nil {
+		return protection, err
+	}
+
+	protection.Checks, err = c.commitChecks(ctx, client, commit)
+	return protection, err
+}
+
+// rulesetRule is a rule of a repository ruleset that applies to a branch
+type rulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+// branchRulesetRules returns the active rules of the repository and organization rulesets
+// that apply to a branch. The rulesets API is not available in the github client version we use.
+func (c *GithubConfig) branchRulesetRules(ctx context.Context, client *gh.Client, branch string) ([]rulesetRule, error) {
+	rules := []rulesetRule{}
+	page := 1
+	for {
+		u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100&page=%d", c.Org, c.Repository, branch, page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return rules, err
+		}
+		var pageRules []rulesetRule
+		resp, err := client.Do(ctx, req, &pageRules)
+		if err != nil {
+			return rules, err
+		}
+		rules = append(rules, pageRules...)
+		if resp.NextPage == 0 {
+			return rules, nil
+		}
+		page = resp.NextPage
+	}
+}
+
+// applyRulesetRules adds the rules of the rulesets that apply to a branch to its protection.
+// When a branch protection rule and rulesets apply to the branch, the most restrictive rules are kept.
+func applyRulesetRules(protection *types.BranchProtection, rules []rulesetRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	protection.Protected = true
+	allowForcePushes := protection.AllowForcePushes == nil || *protection.AllowForcePushes
+	for _, rule := range rules {
+		switch rule.Type {
+		case "pull_request":
+			var parameters struct {
+				RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+				DismissStaleReviewsOnPush    bool `json:"dismiss_stale_reviews_on_push"`
+				RequireCodeOwnerReview       bool `json:"require_code_owner_review"`
+			}
+			if err := json.Unmarshal(rule.Parameters, &parameters); err != nil {
+				return fmt.Errorf("failed to parse the %s ruleset rule: %v", rule.Type, err)
+			}
+			if parameters.RequiredApprovingReviewCount > protection.RequiredApprovals {
+				protection.RequiredApprovals = parameters.RequiredApprovingReviewCount
+			}
+			protection.RequireCodeOwnerReviews = protection.RequireCodeOwnerReviews || parameters.RequireCodeOwnerReview
+			protection.DismissStaleReviews = protection.DismissStaleReviews || parameters.DismissStaleReviewsOnPush
+		case "required_status_checks":
+			var parameters struct {
+				RequiredStatusChecks []struct {
+					Context string `json:"context"`
+				} `json:"required_status_checks"`
+			}
+			if err := json.Unmarshal(rule.Parameters, &parameters); err != nil {
+				return fmt.Errorf("failed to parse the %s ruleset rule: %v", rule.Type, err)
+			}
+			for _, check := range parameters.RequiredStatusChecks {
+				if !contains(protection.RequiredChecks, check.Context) {
+					protection.RequiredChecks = append(protection.RequiredChecks, check.Context)
+				}
+			}
+		case "non_fast_forward":
+			allowForcePushes = false
+		}
+	}
+	protection.AllowForcePushes = &allowForcePushes
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// commitChecks returns the legacy statuses and the check runs of a commit
+func (c *GithubConfig) commitChecks(ctx context.Context, client *gh.Client, commit string) ([]types.CheckStatus, error) {
+	checks := []types.CheckStatus{}
+	combined, _, err := client.Repositories.GetCombinedStatus(ctx, c.Org, c.Repository, commit, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return checks, err
+	}
+	for _, status := range combined.Statuses {
+		checks = append(checks, types.CheckStatus{
+			Name:  status.GetContext(),
+			State: types.NormalizeCheckState(status.GetState()),
+		})
+	}
+
+	checkRuns, _, err := client.Checks.ListCheckRunsForRef(ctx, c.Org, c.Repository, commit,
+		&gh.ListCheckRunsOptions{ListOptions: gh.ListOptions{PerPage: 100}})
+	if err != nil {
+		return checks, err
+	}
+	for _, run := range checkRuns.CheckRuns {
+		state := types.CheckPending
+		if run.GetStatus() == "completed" {
+			state = types.NormalizeCheckState(run.GetConclusion())
+		}
+		checks = append(checks, types.CheckStatus{Name: run.GetName(), State: state})
+	}
+	return checks, nil
+}
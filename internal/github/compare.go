@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gh "github.com/google/go-github/v42/github"
+	"github.com/kosli-dev/cli/internal/gitview"
+)
+
+// CompareCommits returns the commits reachable from head and not from base, newest first.
+// The returned bool is true when Github returned only part of the commits.
+func (c *GithubConfig) CompareCommits(base, head string) ([]*gitview.CommitInfo, bool, error) {
+	commits := []*gitview.CommitInfo{}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return commits, false, err
+	}
+
+	total := 0
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := client.Repositories.CompareCommits(ctx, c.Org, c.Repository, base, head, opts)
+		if err != nil {
+			return commits, false, err
+		}
+		total = comparison.GetTotalCommits()
+		for _, commit := range comparison.Commits {
+			commits = append(commits, asCommitInfo(commit))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// Github lists the commits oldest first
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, len(commits) < total, nil
+}
+
+// asCommitInfo returns a CommitInfo from a Github repository commit
+func asCommitInfo(commit *gh.RepositoryCommit) *gitview.CommitInfo {
+	parents := []string{}
+	for _, parent := range commit.Parents {
+		parents = append(parents, parent.GetSHA())
+	}
+	author := commit.GetCommit().GetAuthor()
+	return &gitview.CommitInfo{
+		Sha1:      commit.GetSHA(),
+		Message:   strings.TrimSpace(commit.GetCommit().GetMessage()),
+		Author:    fmt.Sprintf("%s <%s>", author.GetName(), author.GetEmail()),
+		Timestamp: unixTime(author.GetDate()),
+		Parents:   parents,
+		IsMerge:   len(parents) > 1,
+	}
+}
+
+// CommitChangedFiles returns the paths of the files a commit changed compared to its first parent,
+// including the previous paths of renamed files
+func (c *GithubConfig) CommitChangedFiles(sha string) ([]string, error) {
+	files := []string{}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return files, err
+	}
+
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		commit, resp, err := client.Repositories.GetCommit(ctx, c.Org, c.Repository, sha, opts)
+		if err != nil {
+			return files, err
+		}
+		for _, file := range commit.Files {
+			files = append(files, file.GetFilename())
+			if file.GetPreviousFilename() != "" {
+				files = append(files, file.GetPreviousFilename())
+			}
+		}
+		if resp.NextPage == 0 {
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
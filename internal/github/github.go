@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"strings"
+	"time"
 
 	gh "github.com/google/go-github/v42/github"
 	"github.com/kosli-dev/cli/internal/types"
@@ -78,14 +79,27 @@ func (c *GithubConfig) PREvidenceForCommit(commit string) ([]*types.PREvidence,
 func (c *GithubConfig) newPRGithubEvidence(pr *gh.PullRequest) (*types.PREvidence, error) {
 	evidence := &types.PREvidence{
 		URL:         pr.GetHTMLURL(),
+		Title:       pr.GetTitle(),
+		Body:        pr.GetBody(),
 		MergeCommit: pr.GetMergeCommitSHA(),
 		State:       pr.GetState(),
+		Merged:      pr.MergedAt != nil,
 	}
+	evidence.Author = types.PRUser{Login: pr.GetUser().GetLogin(), Name: pr.GetUser().GetName()}
 	approvers, err := c.GetPullRequestApprovers(pr.GetNumber())
 	if err != nil {
 		return evidence, err
 	}
 	evidence.Approvers = approvers
+	evidence.Reviews, err = c.GetPullRequestReviews(pr.GetNumber())
+	if err != nil {
+		return evidence, err
+	}
+	evidence.Commits, err = c.GetPullRequestCommits(pr.GetNumber())
+	if err != nil {
+		return evidence, err
+	}
+	evidence.AnalyzeFourEyes()
 	return evidence, nil
 }
 
@@ -102,6 +116,65 @@ func (c *GithubConfig) PullRequestsForCommit(commit string) ([]*gh.PullRequest,
 	return pullrequests, err
 }
 
+// PREvidenceForNumber returns the evidence of a pull request by its number
+func (c *GithubConfig) PREvidenceForNumber(number int) (*types.PREvidence, error) {
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	pr, _, err := client.PullRequests.Get(ctx, c.Org, c.Repository, number)
+	if err != nil {
+		return nil, err
+	}
+	return c.newPRGithubEvidence(pr)
+}
+
+// PREvidenceForMergeCommit returns the evidence of the closed pull requests whose merge commit is commit.
+// Only the 100 most recently updated closed pull requests are searched.
+func (c *GithubConfig) PREvidenceForMergeCommit(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	prs, _, err := client.PullRequests.List(ctx, c.Org, c.Repository, &gh.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: gh.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, pr := range prs {
+		if pr.GetMergeCommitSHA() != commit {
+			continue
+		}
+		evidence, err := c.newPRGithubEvidence(pr)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// CommitMessage returns the message of a commit
+func (c *GithubConfig) CommitMessage(commit string) (string, error) {
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	repoCommit, _, err := client.Repositories.GetCommit(ctx, c.Org, c.Repository, commit, nil)
+	if err != nil {
+		return "", err
+	}
+	return repoCommit.GetCommit().GetMessage(), nil
+}
+
 // GetPullRequestApprovers returns a list of approvers for a given pull request
 func (c *GithubConfig) GetPullRequestApprovers(number int) ([]string, error) {
 	approvers := []string{}
@@ -121,3 +194,68 @@ func (c *GithubConfig) GetPullRequestApprovers(number int) ([]string, error) {
 	}
 	return approvers, nil
 }
+
+// GetPullRequestReviews returns the approving reviews of a given pull request
+func (c *GithubConfig) GetPullRequestReviews(number int) ([]types.PRReview, error) {
+	approvals := []types.PRReview{}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return approvals, err
+	}
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, c.Org, c.Repository, number, opts)
+		if err != nil {
+			return approvals, err
+		}
+		for _, r := range reviews {
+			if r.GetState() == "APPROVED" {
+				approvals = append(approvals, types.PRReview{
+					Reviewer:  types.PRUser{Login: r.GetUser().GetLogin(), Name: r.GetUser().GetName()},
+					Timestamp: unixTime(r.GetSubmittedAt()),
+				})
+			}
+		}
+		if resp.NextPage == 0 {
+			return approvals, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetPullRequestCommits returns the commits of a given pull request
+func (c *GithubConfig) GetPullRequestCommits(number int) ([]types.PRCommit, error) {
+	prCommits := []types.PRCommit{}
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return prCommits, err
+	}
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		commits, resp, err := client.PullRequests.ListCommits(ctx, c.Org, c.Repository, number, opts)
+		if err != nil {
+			return prCommits, err
+		}
+		for _, commit := range commits {
+			prCommits = append(prCommits, types.PRCommit{
+				SHA:       commit.GetSHA(),
+				Author:    types.PRUser{Login: commit.GetAuthor().GetLogin(), Name: commit.GetCommit().GetAuthor().GetName()},
+				Timestamp: unixTime(commit.GetCommit().GetCommitter().GetDate()),
+			})
+		}
+		if resp.NextPage == 0 {
+			return prCommits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// unixTime returns t in unix seconds, or 0 if t is not set
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
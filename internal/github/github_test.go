@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/kosli-dev/cli/internal/testHelpers"
+	"github.com/kosli-dev/cli/internal/types"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -243,8 +244,65 @@ func (suite *GithubTestSuite) TestExtractRepoName() {
 	}
 }
 
+func (suite *GithubTestSuite) TestIssuesKeyPattern() {
+	for _, t := range []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{
+			name:    "issue numbers and GH- references are matched",
+			message: "Fix login (#12)\n\nCloses #3, relates to GH-4",
+			want:    []string{"#12", "#3", "GH-4"},
+		},
+		{
+			name:    "references to other repositories and Azure Boards are not matched",
+			message: "See kosli-dev/server#5 and AB#6",
+			want:    []string{},
+		},
+	} {
+		suite.Run(t.name, func() {
+			matcher, err := types.NewIssueKeyMatcher([]string{IssuesKeyPattern}, nil)
+			require.NoError(suite.T(), err)
+			require.Equal(suite.T(), t.want, matcher.FindKeys(t.message))
+		})
+	}
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestGithubTestSuite(t *testing.T) {
 	suite.Run(t, new(GithubTestSuite))
 }
+
+func TestApplyRulesetRules(t *testing.T) {
+	allowed := true
+	rules := []rulesetRule{
+		{Type: "pull_request", Parameters: []byte(`{"required_approving_review_count": 2, "require_code_owner_review": true}`)},
+		{Type: "required_status_checks", Parameters: []byte(`{"required_status_checks": [{"context": "build"}, {"context": "test"}]}`)},
+		{Type: "non_fast_forward"},
+		{Type: "deletion"},
+	}
+
+	unprotected := &types.BranchProtection{Branch: "main", RequiredChecks: []string{}}
+	require.NoError(t, applyRulesetRules(unprotected, rules))
+	require.True(t, unprotected.Protected)
+	require.Equal(t, 2, unprotected.RequiredApprovals)
+	require.True(t, unprotected.RequireCodeOwnerReviews)
+	require.Equal(t, []string{"build", "test"}, unprotected.RequiredChecks)
+	require.False(t, *unprotected.AllowForcePushes)
+
+	protected := &types.BranchProtection{Branch: "main", Protected: true, RequiredApprovals: 3,
+		RequiredChecks: []string{"test"}, AllowForcePushes: &allowed}
+	require.NoError(t, applyRulesetRules(protected, rules))
+	require.Equal(t, 3, protected.RequiredApprovals)
+	require.Equal(t, []string{"test", "build"}, protected.RequiredChecks)
+	require.False(t, *protected.AllowForcePushes)
+
+	noRules := &types.BranchProtection{Branch: "main", RequiredChecks: []string{}}
+	require.NoError(t, applyRulesetRules(noRules, []rulesetRule{}))
+	require.False(t, noRules.Protected)
+	require.Nil(t, noRules.AllowForcePushes)
+
+	require.Error(t, applyRulesetRules(noRules, []rulesetRule{{Type: "pull_request", Parameters: []byte(`[]`)}}))
+}
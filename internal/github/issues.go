@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+// IssuesKeyPattern matches GitHub issue references in the same repository, e.g. #123 or GH-123.
+// References to other repositories, e.g. org/repo#123, are not matched.
+const IssuesKeyPattern = `(?:^|[^\w/])((?:GH-|#)[0-9]+)\b`
+
+// IssueKeyPattern returns the pattern of GitHub issue references
+func (c *GithubConfig) IssueKeyPattern() string {
+	return IssuesKeyPattern
+}
+
+// GetIssueInfo retrieves GitHub issue information
+// if issue is not found, we still return an IssueInfo object with IssueExists set to false.
+// Pull requests are issues in GitHub, they are reported with the issue type 'pull request'.
+func (c *GithubConfig) GetIssueInfo(issueID string) (*types.IssueInfo, error) {
+	result := &types.IssueInfo{
+		IssueID:     issueID,
+		IssueExists: false,
+	}
+	number, err := strconv.Atoi(strings.TrimLeft(strings.TrimPrefix(strings.ToUpper(issueID), "GH-"), "#"))
+	if err != nil {
+		return result, fmt.Errorf("invalid GitHub issue reference: %s", issueID)
+	}
+
+	ctx := context.Background()
+	client, err := NewGithubClientFromToken(ctx, c.Token, c.BaseURL)
+	if err != nil {
+		return result, err
+	}
+	issue, response, err := client.Issues.Get(ctx, c.Org, c.Repository, number)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return result, nil
+		}
+		return result, err
+	}
+
+	result.IssueExists = true
+	result.IssueURL = issue.GetHTMLURL()
+	result.Summary = issue.GetTitle()
+	result.Status = issue.GetState()
+	result.IssueType = "issue"
+	if issue.IsPullRequest() {
+		result.IssueType = types.PullRequestIssueType
+	}
+	if issue.Assignee != nil {
+		result.Assignee = issue.Assignee.GetLogin()
+	}
+	// milestones are used as fix versions
+	if issue.Milestone != nil {
+		result.FixVersions = []string{issue.Milestone.GetTitle()}
+	}
+	return result, nil
+}
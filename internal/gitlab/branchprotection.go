@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/xanzy/go-gitlab"
+)
+
+// BranchProtectionForCommit returns the protection rules of a branch
+// and the statuses of the pipeline jobs of a commit
+func (c *GitlabConfig) BranchProtectionForCommit(branch, commit string) (*types.BranchProtection, error) {
+	protection := &types.BranchProtection{Branch: branch, Commit: commit, RequiredChecks: []string{}}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return protection, err
+	}
+
+	protectedBranches, err := c.matchingProtectedBranches(client, branch)
+	if err != nil {
+		return protection, err
+	}
+	if len(protectedBranches) > 0 {
+		protection.Protected = true
+		// a branch matching several protected branches gets the most restrictive of their rules
+		allowForcePushes := true
+		for _, protectedBranch := range protectedBranches {
+			protection.RequireCodeOwnerReviews = protection.RequireCodeOwnerReviews || protectedBranch.CodeOwnerApprovalRequired
+			allowForcePushes = allowForcePushes && protectedBranch.AllowForcePush
+		}
+		protection.AllowForcePushes = &allowForcePushes
+
+		project, _, err := client.Projects.GetProject(c.ProjectID(), &gitlab.GetProjectOptions{})
+		if err != nil {
+			return protection, err
+		}
+		protection.RequirePassingChecks = project.OnlyAllowMergeIfPipelineSucceeds
+		protection.RequiredApprovals, err = c.requiredApprovals(client, branch, project.ApprovalsBeforeMerge)
+		if err != nil {
+			return protection, err
+		}
+		approvals, _, err := client.Projects.GetApprovalConfiguration(c.ProjectID())
+		if err == nil {
+			protection.DismissStaleReviews = approvals.ResetApprovalsOnPush
+		}
+	}
+
+	protection.Checks, err = c.commitChecks(client, commit)
+	return protection, err
+}
+
+// requiredApprovals returns the highest number of approvals required by the approval rules
+// that apply to the branch. Approval rules are not available in all GitLab tiers,
+// in which case the project's approvals_before_merge is used.
+func (c *GitlabConfig) requiredApprovals(client *gitlab.Client, branch string, approvalsBeforeMerge int) (int, error) {
+	rules, resp, err := client.Projects.GetProjectApprovalRules(c.ProjectID(), &gitlab.GetProjectApprovalRulesListsOptions{})
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return approvalsBeforeMerge, nil
+		}
+		return 0, err
+	}
+	required := approvalsBeforeMerge
+	for _, rule := range rules {
+		if rule.ApprovalsRequired > required && ruleAppliesToBranch(rule, branch) {
+			required = rule.ApprovalsRequired
+		}
+	}
+	return required, nil
+}
+
+// matchingProtectedBranches returns the protected branches of the project whose name,
+// which can contain wildcards (e.g. release/*), matches the branch
+func (c *GitlabConfig) matchingProtectedBranches(client *gitlab.Client, branch string) ([]*gitlab.ProtectedBranch, error) {
+	matching := []*gitlab.ProtectedBranch{}
+	opts := &gitlab.ListProtectedBranchesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for {
+		protectedBranches, resp, err := client.ProtectedBranches.ListProtectedBranches(c.ProjectID(), opts)
+		if err != nil {
+			return matching, err
+		}
+		for _, protectedBranch := range protectedBranches {
+			if branchMatches(protectedBranch.Name, branch) {
+				matching = append(matching, protectedBranch)
+			}
+		}
+		if resp.NextPage == 0 {
+			return matching, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// branchMatches returns true if the branch matches the name of a protected branch,
+// where a * matches any sequence of characters, as it does in GitLab
+func branchMatches(protectedName, branch string) bool {
+	if !strings.Contains(protectedName, "*") {
+		return protectedName == branch
+	}
+	parts := strings.Split(protectedName, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(branch)
+}
+
+func ruleAppliesToBranch(rule *gitlab.ProjectApprovalRule, branch string) bool {
+	if rule.AppliesToAllProtectedBranches || len(rule.ProtectedBranches) == 0 {
+		return true
+	}
+	for _, protectedBranch := range rule.ProtectedBranches {
+		if branchMatches(protectedBranch.Name, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitChecks returns the statuses of a commit. Failed jobs that are allowed to fail are neutral.
+func (c *GitlabConfig) commitChecks(client *gitlab.Client, commit string) ([]types.CheckStatus, error) {
+	checks := []types.CheckStatus{}
+	statuses, _, err := client.Commits.GetCommitStatuses(c.ProjectID(), commit,
+		&gitlab.GetCommitStatusesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}})
+	if err != nil {
+		return checks, err
+	}
+	for _, status := range statuses {
+		state := types.NormalizeCheckState(status.Status)
+		if state == types.CheckFailure && status.AllowFailure {
+			state = types.CheckNeutral
+		}
+		checks = append(checks, types.CheckStatus{Name: status.Name, State: state})
+	}
+	return checks, nil
+}
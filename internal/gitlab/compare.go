@@ -0,0 +1,73 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/gitview"
+	"github.com/xanzy/go-gitlab"
+)
+
+// CompareCommits returns the commits reachable from head and not from base, newest first.
+// The returned bool is true when the comparison timed out in Gitlab, which then returns only part of the commits.
+func (c *GitlabConfig) CompareCommits(base, head string) ([]*gitview.CommitInfo, bool, error) {
+	commits := []*gitview.CommitInfo{}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return commits, false, err
+	}
+	straight := false
+	comparison, _, err := client.Repositories.Compare(c.ProjectID(), &gitlab.CompareOptions{
+		From:     &base,
+		To:       &head,
+		Straight: &straight,
+	})
+	if err != nil {
+		return commits, false, err
+	}
+
+	// Gitlab lists the commits oldest first
+	for i := len(comparison.Commits) - 1; i >= 0; i-- {
+		commit := comparison.Commits[i]
+		commitInfo := &gitview.CommitInfo{
+			Sha1:    commit.ID,
+			Message: strings.TrimSpace(commit.Message),
+			Author:  fmt.Sprintf("%s <%s>", commit.AuthorName, commit.AuthorEmail),
+			Parents: commit.ParentIDs,
+			IsMerge: len(commit.ParentIDs) > 1,
+		}
+		if commit.AuthoredDate != nil {
+			commitInfo.Timestamp = commit.AuthoredDate.Unix()
+		}
+		commits = append(commits, commitInfo)
+	}
+	return commits, comparison.CompareTimeout, nil
+}
+
+// CommitChangedFiles returns the paths of the files a commit changed compared to its first parent,
+// including the previous paths of renamed files
+func (c *GitlabConfig) CommitChangedFiles(sha string) ([]string, error) {
+	files := []string{}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return files, err
+	}
+
+	opts := &gitlab.GetCommitDiffOptions{PerPage: 100}
+	for {
+		diffs, resp, err := client.Commits.GetCommitDiff(c.ProjectID(), sha, opts)
+		if err != nil {
+			return files, err
+		}
+		for _, diff := range diffs {
+			files = append(files, diff.NewPath)
+			if diff.OldPath != diff.NewPath {
+				files = append(files, diff.OldPath)
+			}
+		}
+		if resp.NextPage == 0 {
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -56,15 +56,31 @@ func (c *GitlabConfig) PREvidenceForCommit(commit string) ([]*types.PREvidence,
 
 func (c *GitlabConfig) newPRGitlabEvidence(mr *gitlab.MergeRequest) (*types.PREvidence, error) {
 	evidence := &types.PREvidence{
-		URL:         mr.WebURL,
-		MergeCommit: mr.MergeCommitSHA,
-		State:       mr.State,
+		URL:          mr.WebURL,
+		Title:        mr.Title,
+		Body:         mr.Description,
+		MergeCommit:  mr.MergeCommitSHA,
+		SquashCommit: mr.SquashCommitSHA,
+		State:        mr.State,
+		Merged:       mr.State == "merged",
+	}
+	if mr.Author != nil {
+		evidence.Author = types.PRUser{Login: mr.Author.Username, Name: mr.Author.Name}
 	}
 	approvers, err := c.GetMergeRequestApprovers(mr.IID)
 	if err != nil {
 		return evidence, err
 	}
 	evidence.Approvers = approvers
+	evidence.Reviews, err = c.GetMergeRequestReviews(mr.IID)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.Commits, err = c.GetMergeRequestCommits(mr.IID)
+	if err != nil {
+		return evidence, err
+	}
+	evidence.AnalyzeFourEyes()
 	return evidence, nil
 }
 
@@ -83,6 +99,61 @@ func (c *GitlabConfig) MergeRequestsForCommit(commit string) ([]*gitlab.MergeReq
 	return mrs, nil
 }
 
+// PREvidenceForNumber returns the evidence of an MR by its IID
+func (c *GitlabConfig) PREvidenceForNumber(number int) (*types.PREvidence, error) {
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return nil, err
+	}
+	mr, _, err := client.MergeRequests.GetMergeRequest(c.ProjectID(), number, &gitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return c.newPRGitlabEvidence(mr)
+}
+
+// PREvidenceForMergeCommit returns the evidence of the merged MRs whose merge or squash commit is commit.
+// Only the 100 most recently updated merged MRs are searched.
+func (c *GitlabConfig) PREvidenceForMergeCommit(commit string) ([]*types.PREvidence, error) {
+	pullRequestsEvidence := []*types.PREvidence{}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	mrs, _, err := client.MergeRequests.ListProjectMergeRequests(c.ProjectID(), &gitlab.ListProjectMergeRequestsOptions{
+		State:       gitlab.String("merged"),
+		OrderBy:     gitlab.String("updated_at"),
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return pullRequestsEvidence, err
+	}
+	for _, mr := range mrs {
+		if mr.MergeCommitSHA != commit && mr.SquashCommitSHA != commit {
+			continue
+		}
+		evidence, err := c.newPRGitlabEvidence(mr)
+		if err != nil {
+			return pullRequestsEvidence, err
+		}
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// CommitMessage returns the message of a commit
+func (c *GitlabConfig) CommitMessage(commit string) (string, error) {
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return "", err
+	}
+	gitlabCommit, _, err := client.Commits.GetCommit(c.ProjectID(), commit)
+	if err != nil {
+		return "", err
+	}
+	return gitlabCommit.Message, nil
+}
+
 // GetMergeRequestApprovers returns a list of users (name and username) who approved an MR
 func (c *GitlabConfig) GetMergeRequestApprovers(mrIID int) ([]string, error) {
 	approvers := []string{}
@@ -99,3 +170,70 @@ func (c *GitlabConfig) GetMergeRequestApprovers(mrIID int) ([]string, error) {
 	}
 	return approvers, nil
 }
+
+// GetMergeRequestReviews returns the approvals of an MR.
+// The time of each approval is taken from the latest 'approved this merge request' system note of the approver.
+func (c *GitlabConfig) GetMergeRequestReviews(mrIID int) ([]types.PRReview, error) {
+	reviews := []types.PRReview{}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return reviews, err
+	}
+	approvals, _, err := client.MergeRequestApprovals.GetConfiguration(c.ProjectID(), mrIID)
+	if err != nil {
+		return reviews, err
+	}
+	approvedAt := make(map[string]int64)
+	opts := &gitlab.ListMergeRequestNotesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for {
+		notes, resp, err := client.Notes.ListMergeRequestNotes(c.ProjectID(), mrIID, opts)
+		if err != nil {
+			return reviews, err
+		}
+		for _, note := range notes {
+			if note.System && note.Body == "approved this merge request" && note.CreatedAt != nil {
+				if note.CreatedAt.Unix() > approvedAt[note.Author.Username] {
+					approvedAt[note.Author.Username] = note.CreatedAt.Unix()
+				}
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	for _, approver := range approvals.ApprovedBy {
+		reviews = append(reviews, types.PRReview{
+			Reviewer:  types.PRUser{Login: approver.User.Username, Name: approver.User.Name},
+			Timestamp: approvedAt[approver.User.Username],
+		})
+	}
+	return reviews, nil
+}
+
+// GetMergeRequestCommits returns the commits of an MR
+func (c *GitlabConfig) GetMergeRequestCommits(mrIID int) ([]types.PRCommit, error) {
+	mrCommits := []types.PRCommit{}
+	client, err := c.NewGitlabClientFromToken()
+	if err != nil {
+		return mrCommits, err
+	}
+	opts := &gitlab.GetMergeRequestCommitsOptions{PerPage: 100}
+	for {
+		commits, resp, err := client.MergeRequests.GetMergeRequestCommits(c.ProjectID(), mrIID, opts)
+		if err != nil {
+			return mrCommits, err
+		}
+		for _, commit := range commits {
+			prCommit := types.PRCommit{SHA: commit.ID, Author: types.PRUser{Name: commit.AuthorName}}
+			if commit.CommittedDate != nil {
+				prCommit.Timestamp = commit.CommittedDate.Unix()
+			}
+			mrCommits = append(mrCommits, prCommit)
+		}
+		if resp.NextPage == 0 {
+			return mrCommits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -304,3 +304,24 @@ func (suite *GitlabTestSuite) TestGetMergeRequestApprovers() {
 func TestGitlabTestSuite(t *testing.T) {
 	suite.Run(t, new(GitlabTestSuite))
 }
+
+func TestBranchMatches(t *testing.T) {
+	for _, tt := range []struct {
+		protectedName string
+		branch        string
+		want          bool
+	}{
+		{protectedName: "main", branch: "main", want: true},
+		{protectedName: "main", branch: "main-2", want: false},
+		{protectedName: "release/*", branch: "release/1.0", want: true},
+		{protectedName: "release/*", branch: "release/1.0/hotfix", want: true},
+		{protectedName: "release/*", branch: "releases/1.0", want: false},
+		{protectedName: "*-stable", branch: "1.0-stable", want: true},
+		{protectedName: "v1.*", branch: "v1x0", want: false},
+		{protectedName: "*", branch: "anything", want: true},
+	} {
+		t.Run(tt.protectedName+" "+tt.branch, func(t *testing.T) {
+			require.Equal(t, tt.want, branchMatches(tt.protectedName, tt.branch))
+		})
+	}
+}
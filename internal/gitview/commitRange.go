@@ -0,0 +1,142 @@
+package gitview
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// RangeOptions control which commits of a commit range are listed
+type RangeOptions struct {
+	// FirstParent only follows the first parent of merge commits,
+	// so the commits of the merged branches are not listed, only their merge commits
+	FirstParent bool
+	// Paths are glob patterns of the files to list the commits of. Only the commits that change matching
+	// files are listed. A pattern matches a file, or a directory and all its files, and '**' matches any
+	// number of directories. All commits are listed when empty.
+	Paths []string
+	// FileStats adds the changed files with their numbers of inserted and deleted lines to the listed commits,
+	// which is slow for large commits
+	FileStats bool
+}
+
+const (
+	// reachableFromNewest marks the commits of the range
+	reachableFromNewest uint8 = 1 << iota
+	// reachableFromOldest marks the commits that are excluded from the range
+	reachableFromOldest
+	// popped marks the commits that are no longer queued
+	popped
+)
+
+// queuedCommit is a commit in a commitQueue, with the order it was queued in
+type queuedCommit struct {
+	commit *object.Commit
+	order  int
+}
+
+// commitQueue is a priority queue of commits, the most recently committed first,
+// and the first queued first for commits with the same committer time
+type commitQueue struct {
+	commits []queuedCommit
+	queued  int
+}
+
+func (q *commitQueue) Len() int { return len(q.commits) }
+func (q *commitQueue) Less(i, j int) bool {
+	a, b := q.commits[i], q.commits[j]
+	if a.commit.Committer.When.Equal(b.commit.Committer.When) {
+		return a.order < b.order
+	}
+	return a.commit.Committer.When.After(b.commit.Committer.When)
+}
+func (q *commitQueue) Swap(i, j int) { q.commits[i], q.commits[j] = q.commits[j], q.commits[i] }
+func (q *commitQueue) Push(x interface{}) {
+	q.commits = append(q.commits, queuedCommit{commit: x.(*object.Commit), order: q.queued})
+	q.queued++
+}
+func (q *commitQueue) Pop() interface{} {
+	last := q.commits[len(q.commits)-1]
+	q.commits = q.commits[:len(q.commits)-1]
+	return last.commit
+}
+
+// commitRange lists the commits that are reachable from newest and not from oldest (git's oldest..newest),
+// the most recently committed first.
+// The walk goes from both ends in committer time order, and stops when all commits left to visit
+// are reachable from oldest. When oldest is the zero hash, all the commits reachable from newest are listed.
+// Missing commits, e.g. beyond the boundary of a shallow clone, fail the walk unless allowMissing is true,
+// in which case the returned bool is false as the listed commits may be incomplete.
+// As in git, a commit with a committer time older than one of its descendants (clock skew)
+// may be listed even though it is reachable from oldest.
+func (gv *GitView) commitRange(oldest, newest plumbing.Hash, opts *RangeOptions, allowMissing bool) ([]*object.Commit, bool, error) {
+	if opts == nil {
+		opts = &RangeOptions{}
+	}
+	commits := []*object.Commit{}
+	complete := true
+	marks := make(map[plumbing.Hash]uint8)
+	queue := &commitQueue{}
+	// the number of queued commits that are not reachable from oldest
+	pending := 0
+
+	visit := func(hash plumbing.Hash, mark uint8) error {
+		previous, seen := marks[hash]
+		if seen && previous|mark == previous {
+			return nil
+		}
+		if seen {
+			marks[hash] = previous | mark
+			if previous&popped == 0 && previous&reachableFromOldest == 0 && mark&reachableFromOldest != 0 {
+				pending--
+			}
+			return nil
+		}
+		commit, err := gv.repository.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get git commit %s: %v", hash, err)
+		}
+		marks[hash] = mark
+		heap.Push(queue, commit)
+		if mark&reachableFromOldest == 0 {
+			pending++
+		}
+		return nil
+	}
+
+	if err := visit(newest, reachableFromNewest); err != nil {
+		return commits, false, err
+	}
+	if !oldest.IsZero() {
+		if err := visit(oldest, reachableFromOldest); err != nil && !allowMissing {
+			return commits, false, err
+		}
+	}
+
+	for pending > 0 {
+		commit := heap.Pop(queue).(*object.Commit)
+		marks[commit.Hash] |= popped
+		mark := marks[commit.Hash] &^ popped
+		excluded := mark&reachableFromOldest != 0
+		if !excluded {
+			pending--
+			commits = append(commits, commit)
+		}
+		parents := commit.ParentHashes
+		if opts.FirstParent && !excluded && len(parents) > 1 {
+			parents = parents[:1]
+		}
+		for _, parent := range parents {
+			err := visit(parent, mark)
+			if err != nil {
+				if !allowMissing {
+					return commits, false, err
+				}
+				complete = false
+			}
+		}
+	}
+	return commits, complete, nil
+}
@@ -0,0 +1,121 @@
+package gitview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/kosli-dev/cli/internal/logger"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitRangeTestSuite struct {
+	suite.Suite
+	gv      *GitView
+	logger  *logger.Logger
+	commits map[string]string
+	when    time.Time
+}
+
+// SetupTest creates the history below, where M merges the side branch C1-C2 into main,
+// and D is committed after the merge:
+//
+//	A - B ------ M - D
+//	     \      /
+//	      C1 - C2
+func (suite *CommitRangeTestSuite) SetupTest() {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(suite.T(), err)
+	suite.gv = &GitView{repository: repo}
+	suite.logger = logger.NewStandardLogger()
+	suite.commits = make(map[string]string)
+	suite.when = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.commit("A")
+	suite.commit("B", "A")
+	suite.commit("C1", "B")
+	suite.commit("C2", "C1")
+	suite.commit("M", "B", "C2")
+	suite.commit("D", "M")
+	err = repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/master", plumbing.NewHash(suite.commits["D"])))
+	require.NoError(suite.T(), err)
+}
+
+// commit stores a commit with the given name as message, a minute after the previous commit,
+// and the commits with the given names as parents
+func (suite *CommitRangeTestSuite) commit(name string, parents ...string) {
+	storer := suite.gv.repository.Storer
+	tree := storer.NewEncodedObject()
+	require.NoError(suite.T(), (&object.Tree{}).Encode(tree))
+	treeHash, err := storer.SetEncodedObject(tree)
+	require.NoError(suite.T(), err)
+
+	suite.when = suite.when.Add(time.Minute)
+	author := object.Signature{Name: "Alice", Email: "alice@example.com", When: suite.when}
+	commit := &object.Commit{Author: author, Committer: author, Message: name, TreeHash: treeHash}
+	for _, parent := range parents {
+		commit.ParentHashes = append(commit.ParentHashes, plumbing.NewHash(suite.commits[parent]))
+	}
+	encoded := storer.NewEncodedObject()
+	require.NoError(suite.T(), commit.Encode(encoded))
+	hash, err := storer.SetEncodedObject(encoded)
+	require.NoError(suite.T(), err)
+	suite.commits[name] = hash.String()
+}
+
+func (suite *CommitRangeTestSuite) TestCommitsBetween() {
+	for _, t := range []struct {
+		name            string
+		oldest          string
+		newest          string
+		opts            *RangeOptions
+		expectedCommits []string
+	}{
+		{
+			name:            "the commits of merged branches are listed",
+			oldest:          "B",
+			newest:          "D",
+			expectedCommits: []string{"D", "M", "C2", "C1"},
+		},
+		{
+			name:            "only the first-parent chain is listed with first parent",
+			oldest:          "B",
+			newest:          "D",
+			opts:            &RangeOptions{FirstParent: true},
+			expectedCommits: []string{"D", "M"},
+		},
+		{
+			name:            "commits reachable from a side branch oldest commit are not listed",
+			oldest:          "C1",
+			newest:          "D",
+			expectedCommits: []string{"D", "M", "C2"},
+		},
+		{
+			name:            "commits of the main branch are not listed when oldest is the merged commit",
+			oldest:          "C2",
+			newest:          "M",
+			expectedCommits: []string{"M"},
+		},
+	} {
+		suite.Run(t.name, func() {
+			commits, err := suite.gv.CommitsBetween(suite.commits[t.oldest], suite.commits[t.newest], t.opts, suite.logger)
+			require.NoError(suite.T(), err)
+			messages := []string{}
+			for _, commit := range commits {
+				messages = append(messages, commit.Message)
+				require.Equal(suite.T(), commit.Message == "M", commit.IsMerge)
+			}
+			require.Equal(suite.T(), t.expectedCommits, messages)
+		})
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestCommitRangeTestSuite(t *testing.T) {
+	suite.Run(t, new(CommitRangeTestSuite))
+}
@@ -18,6 +18,38 @@ type CommitInfo struct {
 	Timestamp int64    `json:"timestamp"`
 	Branch    string   `json:"branch"`
 	Parents   []string `json:"parents"`
+	// IsMerge is true for commits with more than one parent
+	IsMerge bool `json:"is_merge"`
+	// ChangedFiles are the files the commit changed compared to its first parent, only set when listing commits by paths
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// SignatureType is the type of the commit signature, one of the SignatureType values, or empty if unsigned
+	SignatureType string `json:"signature_type,omitempty"`
+	// Committer is who committed the commit, which can differ from its author, e.g. after a rebase
+	Committer string `json:"committer"`
+	// CoAuthors are the authors in the Co-authored-by trailers of the commit message
+	CoAuthors []string `json:"co_authors,omitempty"`
+	// Files are the files the commit changed compared to its first parent with their diff stats,
+	// only set when asked for
+	Files []FileStat `json:"files,omitempty"`
+}
+
+// FileStat is the numbers of lines inserted and deleted in a file by a commit
+type FileStat struct {
+	Path       string `json:"path"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+}
+
+// coAuthorTrailer matches the Co-authored-by trailers of a commit message
+var coAuthorTrailer = regexp.MustCompile(`(?im)^co-authored-by:[ \t]*(.+?)[ \t]*$`)
+
+// coAuthors returns the co-authors in the trailers of a commit message
+func coAuthors(message string) []string {
+	authors := []string{}
+	for _, match := range coAuthorTrailer.FindAllStringSubmatch(message, -1) {
+		authors = append(authors, match[1])
+	}
+	return authors
 }
 
 // GitView
@@ -41,8 +73,10 @@ func New(repositoryRoot string) (*GitView, error) {
 	}, nil
 }
 
-// CommitsBetween list all commits that have happened between two commits in a git repo
-func (gv *GitView) CommitsBetween(oldest, newest string, logger *logger.Logger) ([]*CommitInfo, error) {
+// CommitsBetween lists the commits that are reachable from newest and not from oldest in a git repo,
+// newest first, like 'git log oldest..newest'. When oldest and newest are the same commit, that commit is listed.
+// opts can be nil to follow all parents of merge commits and list commits regardless of the files they change.
+func (gv *GitView) CommitsBetween(oldest, newest string, opts *RangeOptions, logger *logger.Logger) ([]*CommitInfo, error) {
 	// Using 'var commits []*ArtifactCommit' will make '[]' convert to 'null' when converting to json
 	// which will fail on the server side.
 	// Using 'commits := make([]*ArtifactCommit, 0)' will make '[]' convert to '[]' when converting to json
@@ -53,6 +87,11 @@ func (gv *GitView) CommitsBetween(oldest, newest string, logger *logger.Logger)
 	if err != nil {
 		return commits, err
 	}
+	if opts != nil {
+		if err := validatePaths(opts.Paths); err != nil {
+			return commits, err
+		}
+	}
 
 	newestHash, err := gv.repository.ResolveRevision(plumbing.Revision(newest))
 	hint := "The commit does not exist in the git repository.\nThis may be caused by insufficient git clone depth."
@@ -67,33 +106,24 @@ func (gv *GitView) CommitsBetween(oldest, newest string, logger *logger.Logger)
 	logger.Debug("newest commit hash %s", newestHash.String())
 	logger.Debug("oldest commit hash %s", oldestHash.String())
 
+	commitObjects := []*object.Commit{}
 	if oldestHash.String() == newestHash.String() {
 		commitObject, err := gv.repository.CommitObject(*newestHash)
 		if err != nil {
 			return commits, err
 		}
-		commit := asCommitInfo(commitObject, branchName)
-		commits = append(commits, commit)
+		commitObjects = append(commitObjects, commitObject)
 
 	} else {
-		commitsIter, err := gv.repository.Log(&git.LogOptions{From: *newestHash, Order: git.LogOrderCommitterTime})
+		commitObjects, _, err = gv.commitRange(*oldestHash, *newestHash, opts, false)
 		if err != nil {
-			return commits, fmt.Errorf("failed to git log: %v", err)
-		}
-
-		for {
-			commit, err := commitsIter.Next()
-			if err != nil {
-				return commits, fmt.Errorf("failed to get next git commit: %v\n%s", err, hint)
-			}
-			if commit.Hash != *oldestHash {
-				nextCommit := asCommitInfo(commit, branchName)
-				commits = append(commits, nextCommit)
-			} else {
-				break
-			}
+			return commits, fmt.Errorf("failed to list git commits between %s and %s: %v\n%s", oldest, newest, err, hint)
 		}
 	}
+	commits, err = asCommitInfos(commitObjects, branchName, opts)
+	if err != nil {
+		return commits, err
+	}
 
 	logger.Debug("parsed %d commits between newest and oldest git commits", len(commits))
 	return commits, nil
@@ -122,23 +152,38 @@ func ExtractRepoURLFromRemote(remoteUrl string) string {
 // ChangeLog attempts to collect the changelog list of commits for an artifact,
 // the changelog is all commits between current commit and the commit from which the previous artifact in Kosli
 // was created.
-// If collecting the changelog fails (e.g. if git history has been rewritten, or the clone depth is too shallow),
-// the changelog only contains the single commit info which is the current commit
-func (gv *GitView) ChangeLog(currentCommit, previousCommit string, logger *logger.Logger) ([]*CommitInfo, error) {
+// If the repository is a shallow clone that does not contain the whole changelog, its history is completed
+// as configured in opts, and the changelog is truncated to the available commits otherwise.
+// If collecting the changelog fails for other reasons (e.g. if git history has been rewritten),
+// the changelog only contains the single commit info which is the current commit.
+// opts can be nil to use the default options.
+// The returned bool is true when the changelog is truncated.
+func (gv *GitView) ChangeLog(currentCommit, previousCommit string, opts *ChangeLogOptions, logger *logger.Logger) ([]*CommitInfo, bool, error) {
+	if opts == nil {
+		opts = &ChangeLogOptions{}
+	}
 	if previousCommit != "" {
-		commitsList, err := gv.CommitsBetween(previousCommit, currentCommit, logger)
-		if err != nil {
-			logger.Warning(err.Error())
-		} else {
-			return commitsList, nil
+		commitsList, truncated, err := gv.CompleteCommitsBetween(previousCommit, currentCommit, opts, logger)
+		if err == nil {
+			return commitsList, truncated, nil
 		}
+		logger.Warning(err.Error())
 	}
 
 	currentArtifactCommit, err := gv.GetCommitInfoFromCommitSHA(currentCommit)
 	if err != nil {
-		return []*CommitInfo{}, fmt.Errorf("could not retrieve current git commit for %s: %v", currentCommit, err)
+		return []*CommitInfo{}, false, fmt.Errorf("could not retrieve current git commit for %s: %v", currentCommit, err)
 	}
-	return []*CommitInfo{currentArtifactCommit}, nil
+	if opts.FileStats {
+		commit, err := gv.repository.CommitObject(plumbing.NewHash(currentArtifactCommit.Sha1))
+		if err == nil {
+			currentArtifactCommit.Files, err = fileStats(commit)
+		}
+		if err != nil {
+			return []*CommitInfo{}, false, err
+		}
+	}
+	return []*CommitInfo{currentArtifactCommit}, previousCommit != "", nil
 }
 
 // BranchName returns the current branch name on a repository,
@@ -181,35 +226,17 @@ func asCommitInfo(commit *object.Commit, branchName string) *CommitInfo {
 		commitParents = append(commitParents, hash.String())
 	}
 	return &CommitInfo{
-		Sha1:      commit.Hash.String(),
-		Message:   strings.TrimSpace(commit.Message),
-		Author:    commit.Author.String(),
-		Timestamp: commit.Author.When.UTC().Unix(),
-		Branch:    branchName,
-		Parents:   commitParents,
-	}
-}
-
-// MatchPatternInCommitMessageORBranchName returns a slice of strings matching a pattern in a commit message or branch name
-// matches lookup happens in the commit message first, and if none is found, matching against the branch name is done
-// if no matches are found in both the commit message and the branch name, an empty slice is returned
-func (gv *GitView) MatchPatternInCommitMessageORBranchName(pattern, commitSHA string) ([]string, error) {
-	commitInfo, err := gv.GetCommitInfoFromCommitSHA(commitSHA)
-	if err != nil {
-		return []string{}, err
-	}
-
-	re := regexp.MustCompile(pattern)
-	matches := re.FindAllString(commitInfo.Message, -1)
-	if matches != nil {
-		return matches, nil
-	} else {
-		matches := re.FindAllString(commitInfo.Branch, -1)
-		if matches != nil {
-			return matches, nil
-		}
+		Sha1:          commit.Hash.String(),
+		Message:       strings.TrimSpace(commit.Message),
+		Author:        commit.Author.String(),
+		Committer:     commit.Committer.String(),
+		CoAuthors:     coAuthors(commit.Message),
+		Timestamp:     commit.Author.When.UTC().Unix(),
+		Branch:        branchName,
+		Parents:       commitParents,
+		IsMerge:       len(commit.ParentHashes) > 1,
+		SignatureType: signatureType(commit.PGPSignature),
 	}
-	return []string{}, nil
 }
 
 // ResolveRevision returns an explicit commit SHA1 from commit SHA or ref (e.g. HEAD~2)
@@ -113,7 +113,7 @@ func (suite *GitViewTestSuite) TestCommitsBetween() {
 
 			gv, err := New(worktree.Filesystem.Root())
 			require.NoError(suite.T(), err)
-			commits, err := gv.CommitsBetween(t.oldestCommit, t.newestCommit, suite.logger)
+			commits, err := gv.CommitsBetween(t.oldestCommit, t.newestCommit, nil, suite.logger)
 			if t.expectError {
 				require.Error(suite.T(), err)
 			} else {
@@ -123,6 +123,56 @@ func (suite *GitViewTestSuite) TestCommitsBetween() {
 	}
 }
 
+func (suite *GitViewTestSuite) TestCommitsBetweenWithPaths() {
+	dirPath := filepath.Join(suite.tmpDir, "repoName")
+	_, worktree, err := initializeRepoAndCommit(dirPath, 4)
+	require.NoError(suite.T(), err)
+	gv, err := New(worktree.Filesystem.Root())
+	require.NoError(suite.T(), err)
+
+	for _, t := range []struct {
+		name                 string
+		paths                []string
+		expectedChangedFiles [][]string
+		expectError          bool
+	}{
+		{
+			name:                 "only the commits changing a matching file are listed",
+			paths:                []string{"file-3.txt"},
+			expectedChangedFiles: [][]string{{"file-3.txt"}},
+		},
+		{
+			name:                 "a glob pattern matches the files of several commits",
+			paths:                []string{"*.txt"},
+			expectedChangedFiles: [][]string{{"file-4.txt"}, {"file-3.txt"}, {"file-2.txt"}},
+		},
+		{
+			name:                 "no commits are listed when no file matches",
+			paths:                []string{"docs"},
+			expectedChangedFiles: [][]string{},
+		},
+		{
+			name:        "fails when a pattern is malformed",
+			paths:       []string{"file-["},
+			expectError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			commits, err := gv.CommitsBetween("HEAD~3", "HEAD", &RangeOptions{Paths: t.paths}, suite.logger)
+			if t.expectError {
+				require.Error(suite.T(), err)
+				return
+			}
+			require.NoError(suite.T(), err)
+			changedFiles := [][]string{}
+			for _, commit := range commits {
+				changedFiles = append(changedFiles, commit.ChangedFiles)
+			}
+			require.Equal(suite.T(), t.expectedChangedFiles, changedFiles)
+		})
+	}
+}
+
 func (suite *GitViewTestSuite) TestChangeLog() {
 	for i, t := range []struct {
 		name                    string
@@ -130,6 +180,7 @@ func (suite *GitViewTestSuite) TestChangeLog() {
 		previousCommit          string
 		commitsNumber           int
 		expectedNumberOfCommits int
+		expectTruncated         bool
 		expectError             bool
 	}{
 		{
@@ -166,6 +217,7 @@ func (suite *GitViewTestSuite) TestChangeLog() {
 			currentCommit:           "HEAD",
 			previousCommit:          "HEAD~2",
 			expectedNumberOfCommits: 1,
+			expectTruncated:         true,
 		},
 		{
 			name:           "fails when current commit cannot be resolved",
@@ -190,11 +242,12 @@ func (suite *GitViewTestSuite) TestChangeLog() {
 
 			gv, err := New(worktree.Filesystem.Root())
 			require.NoError(suite.T(), err)
-			commitsInfo, err := gv.ChangeLog(t.currentCommit, t.previousCommit, suite.logger)
+			commitsInfo, truncated, err := gv.ChangeLog(t.currentCommit, t.previousCommit, nil, suite.logger)
 			if t.expectError {
 				require.Error(suite.T(), err)
 			} else {
 				require.Len(suite.T(), commitsInfo, t.expectedNumberOfCommits)
+				require.Equal(suite.T(), t.expectTruncated, truncated)
 			}
 		})
 	}
@@ -261,78 +314,33 @@ func (suite *GitViewTestSuite) TestNewCommitInfoFromGitCommit() {
 	require.Equal(suite.T(), "Added file 1", ci.Message)
 	require.Equal(suite.T(), "master", ci.Branch)
 	require.Empty(suite.T(), ci.Parents)
+	require.Equal(suite.T(), ci.Author, ci.Committer)
+	require.Empty(suite.T(), ci.CoAuthors)
 }
 
-func (suite *GitViewTestSuite) TestMatchPatternInCommitMessageORBranchName() {
-	_, workTree, fs, err := testHelpers.InitializeGitRepo(suite.tmpDir)
-	require.NoError(suite.T(), err)
-
-	for _, t := range []struct {
-		name          string
-		pattern       string
-		commitMessage string
-		wantError     bool
-		want          []string
-		commitSha     string
-	}{
-		{
-			name:          "One Jira reference found",
-			pattern:       "[A-Z][A-Z0-9]{1,9}-[0-9]+",
-			commitMessage: "EX-1 test commit",
-			want:          []string{"EX-1"},
-			wantError:     false,
-		},
-		{
-			name:          "Two Jira references found",
-			pattern:       "[A-Z][A-Z0-9]{1,9}-[0-9]+",
-			commitMessage: "EX-1 ABC-22 test commit",
-			want:          []string{"EX-1", "ABC-22"},
-			wantError:     false,
-		},
-		{
-			name:          "No Jira references found",
-			pattern:       "[A-Z][A-Z0-9]{1,9}-[0-9]+",
-			commitMessage: "test commit",
-			want:          []string{},
-			wantError:     false,
-		},
-		{
-			name:          "No Jira references found, despite something that looks similar to Jira reference",
-			pattern:       "[A-Z][A-Z0-9]{1,9}-[0-9]+",
-			commitMessage: "Ea-1 test commit",
-			want:          []string{},
-			wantError:     false,
-		},
-		{
-			name:      "Commit not found, expect an error",
-			pattern:   "[A-Z][A-Z0-9]{1,9}-[0-9]+",
-			commitSha: "3b7420d0392114794591aaefcd84d7b100b8d095",
-			wantError: true,
-		},
-		{
-			name:          "GitHub reference found",
-			pattern:       "#[0-9]+",
-			commitMessage: "#324 test commit",
-			want:          []string{"#324"},
-			wantError:     false,
-		},
-	} {
-		suite.Run(t.name, func() {
-
-			if t.commitSha == "" {
-				t.commitSha, err = testHelpers.CommitToRepo(workTree, fs, t.commitMessage)
-				require.NoError(suite.T(), err)
-			}
+func (suite *GitViewTestSuite) TestCoAuthors() {
+	message := "Fix the build\n\nCo-authored-by: Bob <bob@example.com>\nco-authored-by:  Carol <carol@example.com> \n"
+	require.Equal(suite.T(), []string{"Bob <bob@example.com>", "Carol <carol@example.com>"}, coAuthors(message))
+	require.Empty(suite.T(), coAuthors("Fix the build"))
+}
 
-			gitView, err := New(suite.tmpDir)
-			require.NoError(suite.T(), err)
+func (suite *GitViewTestSuite) TestCommitsBetweenWithFileStats() {
+	dirPath := filepath.Join(suite.tmpDir, "repoName")
+	_, worktree, err := initializeRepoAndCommit(dirPath, 3)
+	require.NoError(suite.T(), err)
+	gv, err := New(worktree.Filesystem.Root())
+	require.NoError(suite.T(), err)
 
-			actual, err := gitView.MatchPatternInCommitMessageORBranchName(t.pattern, t.commitSha)
-			require.True(suite.T(), (err != nil) == t.wantError)
-			require.ElementsMatch(suite.T(), t.want, actual)
+	commits, err := gv.CommitsBetween("HEAD~2", "HEAD", nil, suite.logger)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), commits, 2)
+	require.Nil(suite.T(), commits[0].Files)
 
-		})
-	}
+	commits, err = gv.CommitsBetween("HEAD~2", "HEAD", &RangeOptions{FileStats: true}, suite.logger)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), commits, 2)
+	require.Equal(suite.T(), []FileStat{{Path: "file-3.txt", Insertions: 1}}, commits[0].Files)
+	require.Equal(suite.T(), []FileStat{{Path: "file-2.txt", Insertions: 1}}, commits[1].Files)
 }
 
 func (suite *GitViewTestSuite) TestResolveRevision() {
@@ -0,0 +1,147 @@
+package gitview
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/kosli-dev/cli/internal/utils"
+)
+
+// validatePaths returns an error if one of the path glob patterns is malformed
+func validatePaths(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := utils.MatchPath(pattern, ""); err != nil {
+			return fmt.Errorf("invalid source path pattern %s: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// asCommitInfos returns the CommitInfos of the commits that change files matching the path glob patterns
+// of opts, with their changed files. When no patterns are given, all commits are returned.
+// A merge commit is kept if it differs from each of its parents in the matching files,
+// or, with first parent, if it differs from its first parent, as it then stands for the merged commits.
+// The file stats of the commits are added if opts ask for them.
+func asCommitInfos(commits []*object.Commit, branchName string, opts *RangeOptions) ([]*CommitInfo, error) {
+	commitInfos := make([]*CommitInfo, 0)
+	if opts == nil {
+		opts = &RangeOptions{}
+	}
+	for _, commit := range commits {
+		commitInfo := asCommitInfo(commit, branchName)
+		if opts.FileStats {
+			stats, err := fileStats(commit)
+			if err != nil {
+				return commitInfos, err
+			}
+			commitInfo.Files = stats
+		}
+		if len(opts.Paths) == 0 {
+			commitInfos = append(commitInfos, commitInfo)
+			continue
+		}
+
+		parents := len(commit.ParentHashes)
+		if opts.FirstParent || parents == 0 {
+			parents = 1
+		}
+		keep := true
+		for i := 0; i < parents; i++ {
+			files, err := changedFiles(commit, i)
+			if err != nil {
+				return commitInfos, err
+			}
+			if i == 0 {
+				commitInfo.ChangedFiles = files
+			}
+			matches, err := matchAny(files, opts.Paths)
+			if err != nil {
+				return commitInfos, err
+			}
+			keep = keep && matches
+		}
+		if keep {
+			commitInfos = append(commitInfos, commitInfo)
+		}
+	}
+	return commitInfos, nil
+}
+
+// changedFiles returns the sorted paths of the files a commit changed compared to its parent
+// with the given index. All files of the commit are changed if it has no such parent,
+// or if the parent is missing, e.g. at the boundary of a shallow clone.
+func changedFiles(commit *object.Commit, parentIndex int) ([]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the tree of git commit %s: %v", commit.Hash, err)
+	}
+	var parentTree *object.Tree
+	if parentIndex < len(commit.ParentHashes) {
+		if parent, err := commit.Parent(parentIndex); err == nil {
+			parentTree, err = parent.Tree()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the tree of git commit %s: %v", parent.Hash, err)
+			}
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff git commit %s: %v", commit.Hash, err)
+	}
+
+	unique := make(map[string]bool)
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				unique[name] = true
+			}
+		}
+	}
+	files := []string{}
+	for name := range unique {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// fileStats returns the files a commit changed compared to its first parent, with their numbers of
+// inserted and deleted lines. All files of the commit are inserted if it has no parent,
+// or if its parent is missing, e.g. at the boundary of a shallow clone.
+func fileStats(commit *object.Commit) ([]FileStat, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the tree of git commit %s: %v", commit.Hash, err)
+	}
+	parentTree := &object.Tree{}
+	if parent, err := commit.Parent(0); err == nil {
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the tree of git commit %s: %v", parent.Hash, err)
+		}
+	}
+	patch, err := parentTree.Patch(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff git commit %s: %v", commit.Hash, err)
+	}
+
+	stats := []FileStat{}
+	for _, stat := range patch.Stats() {
+		stats = append(stats, FileStat{Path: stat.Name, Insertions: stat.Addition, Deletions: stat.Deletion})
+	}
+	return stats, nil
+}
+
+// matchAny returns true if one of the files matches one of the path glob patterns
+func matchAny(files, patterns []string) (bool, error) {
+	for _, file := range files {
+		for _, pattern := range patterns {
+			ok, err := utils.MatchPath(pattern, file)
+			if ok || err != nil {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,200 @@
+package gitview
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kosli-dev/cli/internal/logger"
+)
+
+// CommitComparer lists the commits between two commits through the API of a git provider.
+// It is used to compute a changelog when the local clone does not contain its history.
+type CommitComparer interface {
+	// CompareCommits returns the commits reachable from head and not from base, newest first,
+	// and whether the provider only returned part of them
+	CompareCommits(base, head string) ([]*CommitInfo, bool, error)
+	// CommitChangedFiles returns the paths of the files a commit changed compared to its first parent.
+	// It is used to filter the compared commits by source paths.
+	CommitChangedFiles(sha string) ([]string, error)
+}
+
+// ChangeLogOptions control which commits ChangeLog lists,
+// and how it completes a changelog whose history is missing from a shallow clone
+type ChangeLogOptions struct {
+	RangeOptions
+	// AutoFetchDepth is the depth the history of a shallow clone is fetched to from the 'origin' remote.
+	// 0 disables fetching.
+	AutoFetchDepth int
+	// Comparer lists the commits of the changelog through a git provider when fetching is disabled or fails
+	Comparer CommitComparer
+}
+
+// IsShallow returns true if the repository is a shallow clone
+func (gv *GitView) IsShallow() (bool, error) {
+	shallows, err := gv.repository.Storer.Shallow()
+	if err != nil {
+		return false, fmt.Errorf("failed to read the shallow commits of the git repository: %v", err)
+	}
+	return len(shallows) > 0, nil
+}
+
+// FetchHistory deepens the history of a shallow clone by fetching it to the given depth
+// from the 'origin' remote
+func (gv *GitView) FetchHistory(depth int) error {
+	err := gv.repository.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		Depth:      depth,
+		Tags:       git.NoTags,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to fetch git history to a depth of %d: %v", depth, err)
+	}
+	return nil
+}
+
+// availableCommits lists the commits that are reachable from newest and not from oldest, newest first.
+// The listing stops where the local history ends, e.g. at the boundary of a shallow clone,
+// in which case the returned bool is false.
+func (gv *GitView) availableCommits(newest, oldest string, opts *RangeOptions) ([]*CommitInfo, bool, error) {
+	commits := make([]*CommitInfo, 0)
+	branchName, err := gv.BranchName()
+	if err != nil {
+		return commits, false, err
+	}
+	newestHash, err := gv.repository.ResolveRevision(plumbing.Revision(newest))
+	if err != nil {
+		return commits, false, fmt.Errorf("failed to resolve git reference %s: %v", newest, err)
+	}
+	// the oldest commit is usually not in a shallow clone, and its full SHA is needed to spot it
+	oldestHash := plumbing.NewHash(oldest)
+	if hash, err := gv.repository.ResolveRevision(plumbing.Revision(oldest)); err == nil {
+		oldestHash = *hash
+	}
+
+	commitObjects, complete, err := gv.commitRange(oldestHash, *newestHash, opts, true)
+	if err != nil {
+		return commits, false, err
+	}
+	commits, err = asCommitInfos(commitObjects, branchName, opts)
+	return commits, complete, err
+}
+
+// CompleteCommitsBetween lists the commits that are reachable from newest and not from oldest, like CommitsBetween.
+// If the repository is a shallow clone that does not contain the history between them, its history is completed
+// as configured in opts, and the list is truncated to the available commits otherwise.
+// The returned bool is true when the list is truncated.
+func (gv *GitView) CompleteCommitsBetween(oldest, newest string, opts *ChangeLogOptions, logger *logger.Logger) ([]*CommitInfo, bool, error) {
+	commits, err := gv.CommitsBetween(oldest, newest, &opts.RangeOptions, logger)
+	if err == nil {
+		return commits, false, nil
+	}
+
+	shallow, shallowErr := gv.IsShallow()
+	if shallowErr != nil || !shallow {
+		return commits, false, err
+	}
+	completed, truncated, completeErr := gv.completeChangeLog(newest, oldest, opts, logger)
+	if completeErr != nil || len(completed) == 0 {
+		return commits, false, err
+	}
+	if truncated {
+		logger.Warning("the commits of %s are truncated to %d commits as the git history is not deep enough", newest, len(completed))
+	}
+	return completed, truncated, nil
+}
+
+// completeChangeLog computes the changelog between previousCommit and currentCommit when
+// the shallow clone does not contain the history between them.
+// It deepens the history if allowed, otherwise asks the git provider for the commits,
+// and falls back to the commits available locally, in which case the changelog is truncated.
+func (gv *GitView) completeChangeLog(currentCommit, previousCommit string, opts *ChangeLogOptions, logger *logger.Logger) ([]*CommitInfo, bool, error) {
+	if opts.AutoFetchDepth > 0 {
+		logger.Info("the git repository is a shallow clone, fetching its history to a depth of %d", opts.AutoFetchDepth)
+		err := gv.FetchHistory(opts.AutoFetchDepth)
+		if err != nil {
+			logger.Warning(err.Error())
+		} else {
+			commits, err := gv.CommitsBetween(previousCommit, currentCommit, &opts.RangeOptions, logger)
+			if err == nil {
+				return commits, false, nil
+			}
+			logger.Debug("the fetched git history does not contain the changelog: %v", err)
+		}
+	}
+
+	if opts.Comparer != nil {
+		currentSHA, err := gv.ResolveRevision(currentCommit)
+		if err != nil {
+			return []*CommitInfo{}, false, fmt.Errorf("failed to resolve git reference %s: %v", currentCommit, err)
+		}
+		commits, truncated, err := opts.Comparer.CompareCommits(previousCommit, currentSHA)
+		if err == nil && opts.FirstParent {
+			commits = firstParentCommits(commits, currentSHA)
+		}
+		if err == nil && len(opts.Paths) > 0 {
+			commits, err = filterComparedCommits(opts.Comparer, commits, opts.Paths)
+		}
+		if err == nil {
+			branchName, _ := gv.BranchName()
+			for _, commit := range commits {
+				commit.Branch = branchName
+			}
+			logger.Debug("got %d commits between %s and %s from the git provider", len(commits), previousCommit, currentSHA)
+			return commits, truncated, nil
+		}
+		logger.Warning("failed to get the commits between %s and %s from the git provider: %v", previousCommit, currentSHA, err)
+	}
+
+	commits, complete, err := gv.availableCommits(currentCommit, previousCommit, &opts.RangeOptions)
+	return commits, !complete, err
+}
+
+// filterComparedCommits returns the commits from a git provider that change files matching one of the
+// path glob patterns, with their changed files. Unlike for local commits, merge commits are only
+// compared to their first parent.
+func filterComparedCommits(comparer CommitComparer, commits []*CommitInfo, patterns []string) ([]*CommitInfo, error) {
+	filtered := []*CommitInfo{}
+	for _, commit := range commits {
+		changed, err := comparer.CommitChangedFiles(commit.Sha1)
+		if err != nil {
+			return filtered, err
+		}
+		unique := make(map[string]bool)
+		files := []string{}
+		for _, file := range changed {
+			if !unique[file] {
+				unique[file] = true
+				files = append(files, file)
+			}
+		}
+		sort.Strings(files)
+		matches, err := matchAny(files, patterns)
+		if err != nil {
+			return filtered, err
+		}
+		if matches {
+			commit.ChangedFiles = files
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered, nil
+}
+
+// firstParentCommits returns the commits that are on the first-parent chain of head
+func firstParentCommits(commits []*CommitInfo, head string) []*CommitInfo {
+	bySha := make(map[string]*CommitInfo)
+	for _, commit := range commits {
+		bySha[commit.Sha1] = commit
+	}
+	chain := []*CommitInfo{}
+	for commit, ok := bySha[head]; ok; {
+		chain = append(chain, commit)
+		if len(commit.Parents) == 0 {
+			break
+		}
+		commit, ok = bySha[commit.Parents[0]]
+	}
+	return chain
+}
@@ -0,0 +1,161 @@
+package gitview
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kosli-dev/cli/internal/logger"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShallowTestSuite struct {
+	suite.Suite
+	tmpDir     string
+	originPath string
+	logger     *logger.Logger
+}
+
+type fakeComparer struct {
+	commits   []*CommitInfo
+	truncated bool
+	files     map[string][]string
+}
+
+func (c *fakeComparer) CompareCommits(base, head string) ([]*CommitInfo, bool, error) {
+	return c.commits, c.truncated, nil
+}
+
+func (c *fakeComparer) CommitChangedFiles(sha string) ([]string, error) {
+	return c.files[sha], nil
+}
+
+func (suite *ShallowTestSuite) SetupTest() {
+	var err error
+	suite.logger = logger.NewStandardLogger()
+	suite.tmpDir, err = os.MkdirTemp("", "testShallowRepoDir")
+	require.NoError(suite.T(), err)
+	suite.originPath = filepath.Join(suite.tmpDir, "origin")
+	_, _, err = initializeRepoAndCommit(suite.originPath, 5)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *ShallowTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoError(suite.T(), err)
+}
+
+// shallowClone clones the origin repo with a depth of 1 and returns a view of the clone
+func (suite *ShallowTestSuite) shallowClone(name string) *GitView {
+	clonePath := filepath.Join(suite.tmpDir, name)
+	_, err := git.PlainClone(clonePath, false, &git.CloneOptions{URL: suite.originPath, Depth: 1})
+	require.NoError(suite.T(), err)
+	gv, err := New(clonePath)
+	require.NoError(suite.T(), err)
+	return gv
+}
+
+// originCommit returns the SHA1 of a revision in the origin repo
+func (suite *ShallowTestSuite) originCommit(revision string) string {
+	origin, err := git.PlainOpen(suite.originPath)
+	require.NoError(suite.T(), err)
+	hash, err := origin.ResolveRevision(plumbing.Revision(revision))
+	require.NoError(suite.T(), err)
+	return hash.String()
+}
+
+func (suite *ShallowTestSuite) TestIsShallow() {
+	origin, err := New(suite.originPath)
+	require.NoError(suite.T(), err)
+	shallow, err := origin.IsShallow()
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), shallow)
+
+	shallow, err = suite.shallowClone("clone").IsShallow()
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), shallow)
+}
+
+func (suite *ShallowTestSuite) TestChangeLogInShallowClone() {
+	for i, t := range []struct {
+		name                    string
+		opts                    *ChangeLogOptions
+		expectedNumberOfCommits int
+		expectTruncated         bool
+	}{
+		{
+			name:                    "the changelog is truncated to the available commits when completing it is not allowed",
+			expectedNumberOfCommits: 1,
+			expectTruncated:         true,
+		},
+		{
+			name:                    "the changelog is complete when the history can be fetched",
+			opts:                    &ChangeLogOptions{AutoFetchDepth: 10},
+			expectedNumberOfCommits: 3,
+		},
+		{
+			name: "the changelog is got from the comparer when the history is not fetched",
+			opts: &ChangeLogOptions{Comparer: &fakeComparer{
+				commits: []*CommitInfo{{Sha1: "c3"}, {Sha1: "c2"}, {Sha1: "c1"}},
+			}},
+			expectedNumberOfCommits: 3,
+		},
+		{
+			name: "the changelog is truncated when the comparer only returns part of it",
+			opts: &ChangeLogOptions{Comparer: &fakeComparer{
+				commits:   []*CommitInfo{{Sha1: "c3"}, {Sha1: "c2"}},
+				truncated: true,
+			}},
+			expectedNumberOfCommits: 2,
+			expectTruncated:         true,
+		},
+		{
+			name: "the commits from the comparer are filtered by source paths",
+			opts: &ChangeLogOptions{
+				RangeOptions: RangeOptions{Paths: []string{"src"}},
+				Comparer: &fakeComparer{
+					commits: []*CommitInfo{{Sha1: "c3"}, {Sha1: "c2"}, {Sha1: "c1"}},
+					files: map[string][]string{
+						"c3": {"src/main.go", "README.md"},
+						"c2": {"docs/index.md"},
+						"c1": {"src/lib/lib.go"},
+					},
+				},
+			},
+			expectedNumberOfCommits: 2,
+		},
+	} {
+		suite.Run(t.name, func() {
+			gv := suite.shallowClone(string(rune('a' + i)))
+			commits, truncated, err := gv.ChangeLog("HEAD", suite.originCommit("HEAD~3"), t.opts, suite.logger)
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), commits, t.expectedNumberOfCommits)
+			require.Equal(suite.T(), t.expectTruncated, truncated)
+			for _, commit := range commits {
+				require.Equal(suite.T(), "master", commit.Branch)
+			}
+		})
+	}
+}
+
+func (suite *ShallowTestSuite) TestCompleteCommitsBetween() {
+	gv := suite.shallowClone("clone")
+	commits, truncated, err := gv.CompleteCommitsBetween(suite.originCommit("HEAD~3"), "HEAD", &ChangeLogOptions{AutoFetchDepth: 10}, suite.logger)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), commits, 3)
+	require.False(suite.T(), truncated)
+
+	origin, err := New(suite.originPath)
+	require.NoError(suite.T(), err)
+	_, _, err = origin.CompleteCommitsBetween("0123456789012345678901234567890123456789", "HEAD", &ChangeLogOptions{}, suite.logger)
+	require.Error(suite.T(), err)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestShallowTestSuite(t *testing.T) {
+	suite.Run(t, new(ShallowTestSuite))
+}
@@ -0,0 +1,345 @@
+package gitview
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgpErrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	SignatureTypeGPG  = "gpg"
+	SignatureTypeSSH  = "ssh"
+	SignatureTypeX509 = "x509"
+
+	sshSignatureArmorStart = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorEnd   = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic      = "SSHSIG"
+	// sshSignatureVersion is the only version of SSH signatures
+	sshSignatureVersion = 1
+	// sshSignatureNamespace is the namespace git signs commits in
+	sshSignatureNamespace = "git"
+)
+
+// CommitSignature is the result of verifying the signature of a commit
+type CommitSignature struct {
+	CommitSHA string `json:"commit_sha"`
+	Signed    bool   `json:"signed"`
+	// Type is the type of the signature, one of the SignatureType values
+	Type     string `json:"type,omitempty"`
+	Verified bool   `json:"verified"`
+	// Signer is the identity of the trusted key: the user id of a GPG key,
+	// or the principals of an SSH key in the allowed signers file
+	Signer string `json:"signer,omitempty"`
+	// KeyID is the GPG key id, or the SHA256 fingerprint of the SSH key
+	KeyID string `json:"key_id,omitempty"`
+	// Reason explains why a commit is not verified
+	Reason string `json:"reason,omitempty"`
+}
+
+// allowedSigner is an entry of an SSH allowed signers file
+type allowedSigner struct {
+	principals string
+	key        ssh.PublicKey
+	// validAfter and validBefore limit when the key is valid, if they are not zero
+	validAfter  time.Time
+	validBefore time.Time
+}
+
+// validAt returns true if the key of the signer is valid at the time
+func (s allowedSigner) validAt(t time.Time) bool {
+	if !s.validAfter.IsZero() && t.Before(s.validAfter) {
+		return false
+	}
+	return s.validBefore.IsZero() || t.Before(s.validBefore)
+}
+
+// SignatureVerifier verifies commit signatures against trusted GPG keys and SSH allowed signers
+type SignatureVerifier struct {
+	keyRing        openpgp.EntityList
+	allowedSigners []allowedSigner
+}
+
+// NewSignatureVerifier returns a SignatureVerifier for an armored GPG keyring and
+// the content of an SSH allowed signers file, see ssh-keygen(1). Either can be empty.
+func NewSignatureVerifier(armoredKeyRing, allowedSigners string) (*SignatureVerifier, error) {
+	verifier := &SignatureVerifier{}
+	if strings.TrimSpace(armoredKeyRing) != "" {
+		keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKeyRing))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GPG keyring: %v", err)
+		}
+		verifier.keyRing = keyRing
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(allowedSigners))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.SplitN(text, " ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid allowed signers entry on line %d", line)
+		}
+		key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed signers entry on line %d: %v", line, err)
+		}
+		if !allowsGitNamespace(options) {
+			continue
+		}
+		signer := allowedSigner{principals: fields[0], key: key}
+		signer.validAfter, signer.validBefore, err = validityPeriod(options)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed signers entry on line %d: %v", line, err)
+		}
+		verifier.allowedSigners = append(verifier.allowedSigners, signer)
+	}
+	return verifier, scanner.Err()
+}
+
+// allowsGitNamespace returns false if the options of an allowed signer restrict it to other namespaces than git.
+// Certificate authorities are not supported and are not allowed either.
+func allowsGitNamespace(options []string) bool {
+	for _, option := range options {
+		name, value, _ := strings.Cut(option, "=")
+		switch strings.ToLower(name) {
+		case "cert-authority":
+			return false
+		case "namespaces":
+			for _, namespace := range strings.Split(strings.Trim(value, `"`), ",") {
+				if namespace == sshSignatureNamespace {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// validityPeriod returns the valid-after and valid-before options of an allowed signer,
+// which are zero if they are not set
+func validityPeriod(options []string) (validAfter, validBefore time.Time, err error) {
+	for _, option := range options {
+		name, value, _ := strings.Cut(option, "=")
+		switch strings.ToLower(name) {
+		case "valid-after":
+			validAfter, err = parseAllowedSignerTime(strings.Trim(value, `"`))
+		case "valid-before":
+			validBefore, err = parseAllowedSignerTime(strings.Trim(value, `"`))
+		}
+		if err != nil {
+			return validAfter, validBefore, fmt.Errorf("invalid %s option: %v", strings.ToLower(name), err)
+		}
+	}
+	return validAfter, validBefore, nil
+}
+
+// parseAllowedSignerTime parses a time of the allowed signers file, in the YYYYMMDD[HHMM[SS]] format,
+// in the local time zone, or in UTC if it ends with Z
+func parseAllowedSignerTime(value string) (time.Time, error) {
+	location := time.Local
+	if strings.HasSuffix(value, "Z") {
+		value = strings.TrimSuffix(value, "Z")
+		location = time.UTC
+	}
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(value)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("'%s' is not in the YYYYMMDD[HHMM[SS]][Z] format", value)
+	}
+	return time.ParseInLocation(layout, value, location)
+}
+
+// signatureType returns the type of an armored commit signature, or "" if there is none
+func signatureType(signature string) string {
+	switch {
+	case signature == "":
+		return ""
+	case strings.HasPrefix(signature, sshSignatureArmorStart):
+		return SignatureTypeSSH
+	case strings.HasPrefix(signature, "-----BEGIN SIGNED MESSAGE-----"):
+		return SignatureTypeX509
+	default:
+		return SignatureTypeGPG
+	}
+}
+
+// VerifyCommitSignature verifies the signature of a commit
+// the gitCommit can be SHA1 or a revision: e.g. HEAD or HEAD~2 etc
+func (gv *GitView) VerifyCommitSignature(gitCommit string, verifier *SignatureVerifier) (*CommitSignature, error) {
+	hash, err := gv.repository.ResolveRevision(plumbing.Revision(gitCommit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git reference %s: %v", gitCommit, err)
+	}
+	commit, err := gv.repository.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve commit for %s: %v", *hash, err)
+	}
+	return verifier.Verify(commit)
+}
+
+// Verify verifies the signature of a commit
+func (v *SignatureVerifier) Verify(commit *object.Commit) (*CommitSignature, error) {
+	result := &CommitSignature{
+		CommitSHA: commit.Hash.String(),
+		Signed:    commit.PGPSignature != "",
+		Type:      signatureType(commit.PGPSignature),
+	}
+	if !result.Signed {
+		result.Reason = "commit is not signed"
+		return result, nil
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(encoded); err != nil {
+		return nil, err
+	}
+	reader, err := encoded.Reader()
+	if err != nil {
+		return nil, err
+	}
+	message, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result.Type {
+	case SignatureTypeGPG:
+		v.verifyGPG(result, commit.PGPSignature, message)
+	case SignatureTypeSSH:
+		v.verifySSH(result, commit.PGPSignature, message, commit.Committer.When)
+	default:
+		result.Reason = fmt.Sprintf("%s signatures are not supported", result.Type)
+	}
+	return result, nil
+}
+
+func (v *SignatureVerifier) verifyGPG(result *CommitSignature, signature string, message []byte) {
+	if len(v.keyRing) == 0 {
+		result.Reason = "no GPG keyring to verify the signature with"
+		return
+	}
+	entity, err := openpgp.CheckArmoredDetachedSignature(v.keyRing, bytes.NewReader(message), strings.NewReader(signature), nil)
+	if err != nil {
+		if errors.Is(err, pgpErrors.ErrUnknownIssuer) {
+			result.Reason = "signed with a GPG key that is not in the keyring"
+		} else {
+			result.Reason = fmt.Sprintf("invalid GPG signature: %v", err)
+		}
+		return
+	}
+	result.Verified = true
+	result.KeyID = entity.PrimaryKey.KeyIdString()
+	if identity := entity.PrimaryIdentity(); identity != nil {
+		result.Signer = identity.Name
+	}
+}
+
+// sshSignature is an SSH signature as specified in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+type sshSignature struct {
+	Magic         [6]byte
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data an SSH signature is made over, after the magic preamble
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// verifySSH verifies an SSH signature made at signedAt, which is checked against the validity
+// period of the allowed signers like git does with the committer date
+func (v *SignatureVerifier) verifySSH(result *CommitSignature, signature string, message []byte, signedAt time.Time) {
+	armored := strings.TrimSpace(signature)
+	armored = strings.TrimSuffix(strings.TrimPrefix(armored, sshSignatureArmorStart), sshSignatureArmorEnd)
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(armored), ""))
+	if err != nil {
+		result.Reason = fmt.Sprintf("invalid SSH signature: %v", err)
+		return
+	}
+	sig := sshSignature{}
+	if err := ssh.Unmarshal(blob, &sig); err != nil || string(sig.Magic[:]) != sshSignatureMagic {
+		result.Reason = "invalid SSH signature"
+		return
+	}
+	if sig.Version != sshSignatureVersion {
+		result.Reason = fmt.Sprintf("unsupported SSH signature version %d", sig.Version)
+		return
+	}
+	if sig.Namespace != sshSignatureNamespace {
+		result.Reason = fmt.Sprintf("SSH signature is for namespace '%s', not '%s'", sig.Namespace, sshSignatureNamespace)
+		return
+	}
+	publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		result.Reason = fmt.Sprintf("invalid SSH signature key: %v", err)
+		return
+	}
+	result.KeyID = ssh.FingerprintSHA256(publicKey)
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		result.Reason = fmt.Sprintf("unsupported SSH signature hash algorithm '%s'", sig.HashAlgorithm)
+		return
+	}
+	h.Write(message)
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+	sshSig := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, sshSig); err != nil {
+		result.Reason = "invalid SSH signature"
+		return
+	}
+	if err := publicKey.Verify(signedData, sshSig); err != nil {
+		result.Reason = fmt.Sprintf("invalid SSH signature: %v", err)
+		return
+	}
+
+	result.Reason = "signed with an SSH key that is not in the allowed signers"
+	for _, signer := range v.allowedSigners {
+		if !bytes.Equal(signer.key.Marshal(), publicKey.Marshal()) {
+			continue
+		}
+		if !signer.validAt(signedAt) {
+			result.Reason = fmt.Sprintf("the SSH key of %s is not valid at the commit time", signer.principals)
+			continue
+		}
+		result.Verified = true
+		result.Signer = signer.principals
+		result.Reason = ""
+		return
+	}
+}
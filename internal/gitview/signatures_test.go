@@ -0,0 +1,234 @@
+package gitview
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/ssh"
+)
+
+type SignaturesTestSuite struct {
+	suite.Suite
+	gv        *GitView
+	gpgKey    *openpgp.Entity
+	sshSigner ssh.Signer
+}
+
+func (suite *SignaturesTestSuite) SetupTest() {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(suite.T(), err)
+	suite.gv = &GitView{repository: repo}
+
+	suite.gpgKey, err = openpgp.NewEntity("Alice", "", "alice@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(suite.T(), err)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(suite.T(), err)
+	suite.sshSigner, err = ssh.NewSignerFromKey(privateKey)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *SignaturesTestSuite) TestVerifyCommitSignature() {
+	otherKey, err := openpgp.NewEntity("Mallory", "", "mallory@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	require.NoError(suite.T(), err)
+	allowedSigners := "alice@example.com " + string(ssh.MarshalAuthorizedKey(suite.sshSigner.PublicKey()))
+	withOptions := func(options string) string {
+		return strings.Replace(allowedSigners, " ", " "+options+" ", 1)
+	}
+	sshKeyID := ssh.FingerprintSHA256(suite.sshSigner.PublicKey())
+
+	for _, t := range []struct {
+		name           string
+		sign           func(message []byte) string
+		keyRing        string
+		allowedSigners string
+		want           *CommitSignature
+	}{
+		{
+			name: "unsigned commit",
+			want: &CommitSignature{Reason: "commit is not signed"},
+		},
+		{
+			name:    "GPG signature with a key in the keyring",
+			sign:    suite.signGPG,
+			keyRing: suite.armoredPublicKey(suite.gpgKey),
+			want: &CommitSignature{Signed: true, Type: SignatureTypeGPG, Verified: true,
+				Signer: "Alice <alice@example.com>", KeyID: suite.gpgKey.PrimaryKey.KeyIdString()},
+		},
+		{
+			name:    "GPG signature with a key that is not in the keyring",
+			sign:    suite.signGPG,
+			keyRing: suite.armoredPublicKey(otherKey),
+			want:    &CommitSignature{Signed: true, Type: SignatureTypeGPG, Reason: "signed with a GPG key that is not in the keyring"},
+		},
+		{
+			name: "GPG signature without a keyring",
+			sign: suite.signGPG,
+			want: &CommitSignature{Signed: true, Type: SignatureTypeGPG, Reason: "no GPG keyring to verify the signature with"},
+		},
+		{
+			name:           "SSH signature with an allowed signer",
+			sign:           suite.signSSH,
+			allowedSigners: "# trusted keys\n" + allowedSigners,
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH, Verified: true,
+				Signer: "alice@example.com", KeyID: ssh.FingerprintSHA256(suite.sshSigner.PublicKey())},
+		},
+		{
+			name: "SSH signature without allowed signers",
+			sign: suite.signSSH,
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH,
+				KeyID: ssh.FingerprintSHA256(suite.sshSigner.PublicKey()), Reason: "signed with an SSH key that is not in the allowed signers"},
+		},
+		{
+			name:           "SSH signer restricted to other namespaces",
+			sign:           suite.signSSH,
+			allowedSigners: strings.Replace(allowedSigners, " ", ` namespaces="file" `, 1),
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH,
+				KeyID: ssh.FingerprintSHA256(suite.sshSigner.PublicKey()), Reason: "signed with an SSH key that is not in the allowed signers"},
+		},
+		{
+			name:           "SSH signer within its validity period",
+			sign:           suite.signSSH,
+			allowedSigners: withOptions(`valid-after="20000101",valid-before="99991231235959Z"`),
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH, Verified: true,
+				Signer: "alice@example.com", KeyID: sshKeyID},
+		},
+		{
+			name:           "SSH signer that expired before the commit",
+			sign:           suite.signSSH,
+			allowedSigners: withOptions(`valid-before="20000101Z"`),
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH, KeyID: sshKeyID,
+				Reason: "the SSH key of alice@example.com is not valid at the commit time"},
+		},
+		{
+			name:           "SSH signer that is valid after the commit",
+			sign:           suite.signSSH,
+			allowedSigners: withOptions(`valid-after="99990101"`),
+			want: &CommitSignature{Signed: true, Type: SignatureTypeSSH, KeyID: sshKeyID,
+				Reason: "the SSH key of alice@example.com is not valid at the commit time"},
+		},
+		{
+			name:           "SSH signature with an unsupported version",
+			sign:           suite.signSSHVersion(2),
+			allowedSigners: allowedSigners,
+			want:           &CommitSignature{Signed: true, Type: SignatureTypeSSH, Reason: "unsupported SSH signature version 2"},
+		},
+	} {
+		suite.Run(t.name, func() {
+			commitSHA := suite.commit(t.sign)
+			verifier, err := NewSignatureVerifier(t.keyRing, t.allowedSigners)
+			require.NoError(suite.T(), err)
+
+			signature, err := suite.gv.VerifyCommitSignature(commitSHA, verifier)
+			require.NoError(suite.T(), err)
+			t.want.CommitSHA = commitSHA
+			require.Equal(suite.T(), t.want, signature)
+		})
+	}
+}
+
+func (suite *SignaturesTestSuite) TestNewSignatureVerifierWithInvalidAllowedSigners() {
+	_, err := NewSignatureVerifier("", "alice@example.com not-a-key")
+	require.ErrorContains(suite.T(), err, "invalid allowed signers entry on line 1")
+
+	allowedSigner := "alice@example.com valid-before=\"2024-01-01\" " + string(ssh.MarshalAuthorizedKey(suite.sshSigner.PublicKey()))
+	_, err = NewSignatureVerifier("", allowedSigner)
+	require.ErrorContains(suite.T(), err, "invalid valid-before option: '2024-01-01' is not in the YYYYMMDD[HHMM[SS]][Z] format")
+}
+
+// commit stores a commit signed by sign, or an unsigned commit if sign is nil, and returns its SHA1
+func (suite *SignaturesTestSuite) commit(sign func(message []byte) string) string {
+	storer := suite.gv.repository.Storer
+	tree := storer.NewEncodedObject()
+	require.NoError(suite.T(), (&object.Tree{}).Encode(tree))
+	treeHash, err := storer.SetEncodedObject(tree)
+	require.NoError(suite.T(), err)
+
+	author := object.Signature{Name: "Alice", Email: "alice@example.com", When: time.Now()}
+	commit := &object.Commit{Author: author, Committer: author, Message: "signed commit", TreeHash: treeHash}
+	if sign != nil {
+		unsigned := &plumbing.MemoryObject{}
+		require.NoError(suite.T(), commit.EncodeWithoutSignature(unsigned))
+		reader, err := unsigned.Reader()
+		require.NoError(suite.T(), err)
+		message, err := io.ReadAll(reader)
+		require.NoError(suite.T(), err)
+		commit.PGPSignature = sign(message)
+	}
+
+	encoded := storer.NewEncodedObject()
+	require.NoError(suite.T(), commit.Encode(encoded))
+	hash, err := storer.SetEncodedObject(encoded)
+	require.NoError(suite.T(), err)
+	return hash.String()
+}
+
+func (suite *SignaturesTestSuite) armoredPublicKey(entity *openpgp.Entity) string {
+	buffer := &bytes.Buffer{}
+	writer, err := armor.Encode(buffer, openpgp.PublicKeyType, nil)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), entity.Serialize(writer))
+	require.NoError(suite.T(), writer.Close())
+	return buffer.String()
+}
+
+func (suite *SignaturesTestSuite) signGPG(message []byte) string {
+	buffer := &bytes.Buffer{}
+	require.NoError(suite.T(), openpgp.ArmoredDetachSign(buffer, suite.gpgKey, bytes.NewReader(message), nil))
+	return buffer.String()
+}
+
+// signSSH signs the message like 'ssh-keygen -Y sign -n git' does
+func (suite *SignaturesTestSuite) signSSH(message []byte) string {
+	return suite.signSSHVersion(1)(message)
+}
+
+// signSSHVersion returns a signer that makes SSH signatures with the version
+func (suite *SignaturesTestSuite) signSSHVersion(version uint32) func(message []byte) string {
+	return func(message []byte) string {
+		return suite.sshSignature(message, version)
+	}
+}
+
+func (suite *SignaturesTestSuite) sshSignature(message []byte, version uint32) string {
+	digest := sha512.Sum512(message)
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          digest[:],
+	})...)
+	signature, err := suite.sshSigner.Sign(rand.Reader, signedData)
+	require.NoError(suite.T(), err)
+
+	blob := ssh.Marshal(sshSignature{
+		Version:       version,
+		PublicKey:     suite.sshSigner.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})
+	copy(blob, sshSignatureMagic)
+	return sshSignatureArmorStart + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSignatureArmorEnd + "\n"
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestSignaturesTestSuite(t *testing.T) {
+	suite.Run(t, new(SignaturesTestSuite))
+}
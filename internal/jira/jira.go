@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	jira "github.com/andygrunwald/go-jira"
+	"github.com/kosli-dev/cli/internal/types"
 )
 
 type JiraConfig struct {
@@ -14,12 +15,6 @@ type JiraConfig struct {
 	BaseURL  string
 }
 
-type JiraIssueInfo struct {
-	IssueID     string `json:"issue_id"`
-	IssueURL    string `json:"issue_url"`
-	IssueExists bool   `json:"issue_exists"`
-}
-
 // NewJiraConfig returns a new JiraConfig
 func NewJiraConfig(baseURL, username, apiToken, PAT string) *JiraConfig {
 	return &JiraConfig{
@@ -59,10 +54,15 @@ func (jc *JiraConfig) NewJiraClient() (*jira.Client, error) {
 	return jiraClient, nil
 }
 
-// GetJiraIssueInfo retrieve Jira issue information
-// if issue is not found, we still return a JiraIssueInfo object with IssueExists set to false
-func (jc *JiraConfig) GetJiraIssueInfo(issueID string) (*JiraIssueInfo, error) {
-	result := &JiraIssueInfo{
+// IssueKeyPattern returns the pattern of Jira issue keys
+func (jc *JiraConfig) IssueKeyPattern() string {
+	return types.DefaultIssueKeyPattern
+}
+
+// GetIssueInfo retrieve Jira issue information
+// if issue is not found, we still return an IssueInfo object with IssueExists set to false
+func (jc *JiraConfig) GetIssueInfo(issueID string) (*types.IssueInfo, error) {
+	result := &types.IssueInfo{
 		IssueID:     issueID,
 		IssueExists: false,
 		IssueURL:    fmt.Sprintf("%s/browse/%s", jc.BaseURL, issueID),
@@ -79,6 +79,19 @@ func (jc *JiraConfig) GetJiraIssueInfo(issueID string) (*JiraIssueInfo, error) {
 
 	if issue != nil {
 		result.IssueExists = true
+		if fields := issue.Fields; fields != nil {
+			result.Summary = fields.Summary
+			result.IssueType = fields.Type.Name
+			if fields.Status != nil {
+				result.Status = fields.Status.Name
+			}
+			if fields.Assignee != nil {
+				result.Assignee = fields.Assignee.DisplayName
+			}
+			for _, version := range fields.FixVersions {
+				result.FixVersions = append(result.FixVersions, version.Name)
+			}
+		}
 	}
 	return result, nil
 }
@@ -0,0 +1,168 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/kosli-dev/cli/internal/types"
+)
+
+// DefaultAPIURL is the GraphQL API of Linear
+const DefaultAPIURL = "https://api.linear.app/graphql"
+
+// requestTimeout is the timeout of each request to the Linear API
+const requestTimeout = 30 * time.Second
+
+type LinearConfig struct {
+	APIKey     string
+	APIURL     string
+	HTTPClient *http.Client
+}
+
+const issueQuery = `query Issue($id: String!) {
+  issue(id: $id) {
+    identifier
+    title
+    url
+    state { name }
+    assignee { name }
+    labels { nodes { name } }
+    projectMilestone { name }
+  }
+}`
+
+type linearName struct {
+	Name string `json:"name"`
+}
+
+type linearIssue struct {
+	Identifier string      `json:"identifier"`
+	Title      string      `json:"title"`
+	URL        string      `json:"url"`
+	State      *linearName `json:"state"`
+	Assignee   *linearName `json:"assignee"`
+	Labels     struct {
+		Nodes []linearName `json:"nodes"`
+	} `json:"labels"`
+	ProjectMilestone *linearName `json:"projectMilestone"`
+}
+
+type issueResponse struct {
+	Data struct {
+		Issue *linearIssue `json:"issue"`
+	} `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// NewLinearConfig returns a new LinearConfig
+func NewLinearConfig(apiKey, apiURL string) *LinearConfig {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &LinearConfig{
+		APIKey:     apiKey,
+		APIURL:     apiURL,
+		HTTPClient: newHTTPClient(),
+	}
+}
+
+// newHTTPClient returns an HTTP client that times out, and retries on connection and server errors
+func newHTTPClient() *http.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.Logger = nil // this silences logging each individual attempt
+	retryClient.HTTPClient.Timeout = requestTimeout
+	return retryClient.StandardClient()
+}
+
+// IssueKeyPattern returns the pattern of Linear issue identifiers, e.g. ENG-123
+func (c *LinearConfig) IssueKeyPattern() string {
+	return types.DefaultIssueKeyPattern
+}
+
+// GetIssueInfo retrieves Linear issue information
+// if issue is not found, we still return an IssueInfo object with IssueExists set to false
+func (c *LinearConfig) GetIssueInfo(issueID string) (*types.IssueInfo, error) {
+	result := &types.IssueInfo{
+		IssueID:     issueID,
+		IssueExists: false,
+	}
+
+	response := &issueResponse{}
+	err := c.query(issueQuery, map[string]interface{}{"id": issueID}, response)
+	if err != nil {
+		return result, err
+	}
+	for _, e := range response.Errors {
+		if e.Extensions.Code == "ENTITY_NOT_FOUND" || strings.Contains(strings.ToLower(e.Message), "not found") {
+			return result, nil
+		}
+		return result, fmt.Errorf("failed to get Linear issue %s: %s", issueID, e.Message)
+	}
+
+	issue := response.Data.Issue
+	if issue == nil {
+		return result, nil
+	}
+	result.IssueExists = true
+	result.IssueURL = issue.URL
+	result.Summary = issue.Title
+	if issue.State != nil {
+		result.Status = issue.State.Name
+	}
+	if issue.Assignee != nil {
+		result.Assignee = issue.Assignee.Name
+	}
+	// Linear has no issue types, labels such as Bug are used instead
+	if len(issue.Labels.Nodes) > 0 {
+		result.IssueType = issue.Labels.Nodes[0].Name
+	}
+	if issue.ProjectMilestone != nil {
+		result.FixVersions = []string{issue.ProjectMilestone.Name}
+	}
+	return result, nil
+}
+
+// query calls the Linear GraphQL API and decodes the JSON response into v
+func (c *LinearConfig) query(query string, variables map[string]interface{}, v interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.APIURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Linear personal API keys are passed without a Bearer prefix
+	req.Header.Set("Authorization", c.APIKey)
+
+	client := c.HTTPClient
+	if client == nil {
+		client = newHTTPClient()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// GraphQL errors, such as a missing issue, are returned in the body
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("POST %s: %d %s", c.APIURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
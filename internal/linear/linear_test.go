@@ -0,0 +1,79 @@
+package linear
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/types"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LinearTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	config *LinearConfig
+}
+
+// SetupTest starts a stand-in for the Linear GraphQL API with the issue ENG-1
+func (suite *LinearTestSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors": [{"message": "Authentication required"}]}`))
+			return
+		}
+		var request struct {
+			Variables map[string]string `json:"variables"`
+		}
+		require.NoError(suite.T(), json.NewDecoder(r.Body).Decode(&request))
+		if request.Variables["id"] != "ENG-1" {
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "Entity not found: Issue", "extensions": {"code": "ENTITY_NOT_FOUND"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"issue": {
+			"identifier": "ENG-1", "title": "Fix login", "url": "https://linear.app/kosli/issue/ENG-1",
+			"state": {"name": "Done"}, "assignee": {"name": "Alice"},
+			"labels": {"nodes": [{"name": "Bug"}]}, "projectMilestone": {"name": "v1.0"}}}}`))
+	}))
+	suite.config = NewLinearConfig("secret", suite.server.URL)
+}
+
+func (suite *LinearTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *LinearTestSuite) TestGetIssueInfo() {
+	issue, err := suite.config.GetIssueInfo("ENG-1")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), &types.IssueInfo{
+		IssueID:     "ENG-1",
+		IssueURL:    "https://linear.app/kosli/issue/ENG-1",
+		IssueExists: true,
+		Summary:     "Fix login",
+		Status:      "Done",
+		IssueType:   "Bug",
+		Assignee:    "Alice",
+		FixVersions: []string{"v1.0"},
+	}, issue)
+}
+
+func (suite *LinearTestSuite) TestGetIssueInfoNotFound() {
+	issue, err := suite.config.GetIssueInfo("ENG-2")
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), issue.IssueExists)
+}
+
+func (suite *LinearTestSuite) TestGetIssueInfoUnauthorized() {
+	suite.config.APIKey = "wrong"
+	_, err := suite.config.GetIssueInfo("ENG-1")
+	require.ErrorContains(suite.T(), err, "401")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestLinearTestSuite(t *testing.T) {
+	suite.Run(t, new(LinearTestSuite))
+}
@@ -57,6 +57,13 @@ func (l *Logger) Error(format string, v ...interface{}) {
 	l.errLog.Fatalf(format, v...)
 }
 
+// ErrorWithExitCode logs an error and exits with the given code
+func (l *Logger) ErrorWithExitCode(code int, format string, v ...interface{}) {
+	format = fmt.Sprintf("Error: %s\n", format)
+	l.errLog.Printf(format, v...)
+	os.Exit(code)
+}
+
 func (l *Logger) Info(format string, v ...interface{}) {
 	format = fmt.Sprintf("%s\n", format)
 	l.infoLog.Printf(format, v...)
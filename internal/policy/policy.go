@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a list of rules, written as CEL expressions, that a Kosli object must satisfy
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a named CEL expression that evaluates to true when the rule is satisfied.
+// Message explains why the rule fails.
+type Rule struct {
+	Name       string `yaml:"name"`
+	Expression string `yaml:"expression"`
+	Message    string `yaml:"message"`
+}
+
+// Result is the outcome of evaluating a rule
+type Result struct {
+	Rule    string `json:"rule"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// Load reads a policy from a YAML (or JSON) file
+func Load(path string) (*Policy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %v", path, err)
+	}
+	policy := &Policy{}
+	err = yaml.Unmarshal(content, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %v", path, err)
+	}
+	err = policy.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %v", path, err)
+	}
+	return policy, nil
+}
+
+// validate returns an error if the policy has no rules, or a rule has no name, a duplicate name or no expression
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("the policy has no rules")
+	}
+	names := make(map[string]bool)
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule name '%s' is used more than once", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Expression == "" {
+			return fmt.Errorf("rule '%s' has no expression", rule.Name)
+		}
+	}
+	return nil
+}
+
+// Evaluate evaluates the rules of the policy against the input, which the expressions refer to
+// with the given variable name, e.g. 'artifact'. The expressions can also refer to 'now', the
+// time of the evaluation, as a CEL timestamp.
+// A rule fails if its expression is false or cannot be evaluated, e.g. because a field of the input is missing.
+// An error is returned if an expression is not valid CEL or does not evaluate to a bool.
+func (p *Policy) Evaluate(variable string, input map[string]interface{}, now time.Time) ([]*Result, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(variable, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the policy environment: %v", err)
+	}
+
+	results := []*Result{}
+	for _, rule := range p.Rules {
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid expression of rule '%s': %v", rule.Name, issues.Err())
+		}
+		// a dyn expression, e.g. a field of the input, is checked when it is evaluated
+		outputType := ast.OutputType()
+		if !cel.BoolType.IsAssignableType(outputType) && !outputType.IsAssignableType(cel.BoolType) {
+			return nil, fmt.Errorf("the expression of rule '%s' must evaluate to a bool, not %s", rule.Name, outputType)
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression of rule '%s': %v", rule.Name, err)
+		}
+
+		result := &Result{Rule: rule.Name}
+		value, _, err := program.Eval(map[string]interface{}{variable: input, "now": now})
+		if err != nil {
+			result.Message = fmt.Sprintf("failed to evaluate the expression: %v", err)
+		} else if passed, ok := value.Value().(bool); !ok {
+			result.Message = fmt.Sprintf("the expression evaluated to %v, not a bool", value.Value())
+		} else {
+			result.Passed = passed
+			if !passed {
+				result.Message = rule.Message
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PolicyTestSuite struct {
+	suite.Suite
+	tmpDir   string
+	artifact map[string]interface{}
+	friday   time.Time
+}
+
+func (suite *PolicyTestSuite) SetupTest() {
+	var err error
+	suite.tmpDir, err = os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err, "error creating a temporary test directory")
+	suite.artifact = map[string]interface{}{
+		"state": "COMPLIANT",
+		"running": []interface{}{
+			map[string]interface{}{"environment_name": "staging", "snapshot_index": 3.0},
+		},
+		"pull_requests": []interface{}{
+			map[string]interface{}{"approvers": []interface{}{"alice", "bob"}},
+		},
+	}
+	suite.friday = time.Date(2023, 6, 2, 15, 0, 0, 0, time.UTC)
+}
+
+func (suite *PolicyTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoErrorf(suite.T(), err, "error cleaning up the temporary test directory %s", suite.tmpDir)
+}
+
+func (suite *PolicyTestSuite) writePolicy(content string) string {
+	path := filepath.Join(suite.tmpDir, "policy.yaml")
+	err := os.WriteFile(path, []byte(content), 0644)
+	require.NoError(suite.T(), err)
+	return path
+}
+
+func (suite *PolicyTestSuite) TestLoad() {
+	for _, t := range []struct {
+		name      string
+		content   string
+		wantError string
+	}{
+		{
+			name: "a policy with rules is loaded",
+			content: `rules:
+  - name: compliant
+    expression: artifact.state == "COMPLIANT"
+`,
+		},
+		{
+			name:      "a policy without rules fails",
+			content:   "rules: []\n",
+			wantError: "the policy has no rules",
+		},
+		{
+			name: "a rule without a name fails",
+			content: `rules:
+  - expression: "true"
+`,
+			wantError: "rule 1 has no name",
+		},
+		{
+			name: "duplicate rule names fail",
+			content: `rules:
+  - name: compliant
+    expression: "true"
+  - name: compliant
+    expression: "false"
+`,
+			wantError: "rule name 'compliant' is used more than once",
+		},
+		{
+			name: "a rule without an expression fails",
+			content: `rules:
+  - name: compliant
+`,
+			wantError: "rule 'compliant' has no expression",
+		},
+		{
+			name:      "malformed YAML fails",
+			content:   "rules: [",
+			wantError: "failed to parse policy file ",
+		},
+	} {
+		suite.Run(t.name, func() {
+			policy, err := Load(suite.writePolicy(t.content))
+			if t.wantError != "" {
+				require.ErrorContains(suite.T(), err, t.wantError)
+				return
+			}
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), policy.Rules, 1)
+		})
+	}
+}
+
+func (suite *PolicyTestSuite) TestEvaluate() {
+	policy := &Policy{Rules: []Rule{
+		{Name: "compliant", Expression: `artifact.state == "COMPLIANT"`},
+		{Name: "two approvers", Expression: `artifact.pull_requests.all(pr, size(pr.approvers) >= 2)`},
+		{Name: "not in prod", Expression: `!artifact.running.exists(e, e.environment_name == "prod")`},
+		{Name: "not on Friday", Expression: `now.getDayOfWeek() != 5`, Message: "no deployments on Fridays"},
+		{Name: "has sbom", Expression: `artifact.sbom.components > 0`},
+	}}
+
+	results, err := policy.Evaluate("artifact", suite.artifact, suite.friday)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []*Result{
+		{Rule: "compliant", Passed: true},
+		{Rule: "two approvers", Passed: true},
+		{Rule: "not in prod", Passed: true},
+		{Rule: "not on Friday", Passed: false, Message: "no deployments on Fridays"},
+		{Rule: "has sbom", Passed: false, Message: "failed to evaluate the expression: no such key: sbom"},
+	}, results)
+}
+
+func (suite *PolicyTestSuite) TestEvaluateInvalidExpressions() {
+	for _, t := range []struct {
+		name      string
+		rule      Rule
+		wantError string
+	}{
+		{
+			name:      "a syntax error fails",
+			rule:      Rule{Name: "broken", Expression: `artifact.state ==`},
+			wantError: "invalid expression of rule 'broken': ",
+		},
+		{
+			name:      "an unknown variable fails",
+			rule:      Rule{Name: "unknown", Expression: `trail.state == "COMPLIANT"`},
+			wantError: "invalid expression of rule 'unknown': ",
+		},
+		{
+			name:      "an expression that is not a bool fails",
+			rule:      Rule{Name: "string", Expression: `"COMPLIANT"`},
+			wantError: "the expression of rule 'string' must evaluate to a bool, not string",
+		},
+	} {
+		suite.Run(t.name, func() {
+			policy := &Policy{Rules: []Rule{t.rule}}
+			_, err := policy.Evaluate("artifact", suite.artifact, suite.friday)
+			require.ErrorContains(suite.T(), err, t.wantError)
+		})
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
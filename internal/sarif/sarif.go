@@ -0,0 +1,245 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// SARIF result levels, ordered from the most to the least severe
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+	LevelNone    = "none"
+)
+
+// Levels are the valid values of a SARIF result level (and of the --fail-on flag)
+var Levels = []string{LevelError, LevelWarning, LevelNote, LevelNone}
+
+// Summary is a summary of the findings in a SARIF log
+type Summary struct {
+	Version      string        `json:"version"`
+	ResultCount  int           `json:"result_count"`
+	ErrorCount   int           `json:"error_count"`
+	WarningCount int           `json:"warning_count"`
+	NoteCount    int           `json:"note_count"`
+	NoneCount    int           `json:"none_count"`
+	Tools        []ToolSummary `json:"tools"`
+}
+
+// ToolSummary is a summary of the findings reported by one run of a tool
+type ToolSummary struct {
+	Name        string        `json:"name"`
+	Version     string        `json:"version,omitempty"`
+	ResultCount int           `json:"result_count"`
+	Rules       []RuleSummary `json:"rules"`
+}
+
+// RuleSummary is the number of findings of a rule at a given level
+type RuleSummary struct {
+	RuleID string `json:"rule_id"`
+	Level  string `json:"level"`
+	Count  int    `json:"count"`
+}
+
+// Policy decides whether a SARIF summary is compliant
+type Policy struct {
+	// FailOn is the least severe level that makes the summary non-compliant.
+	// LevelNone disables the level check.
+	FailOn string
+	// MaxWarnings is the maximum number of warning-level findings allowed.
+	// A negative value disables the check.
+	MaxWarnings int
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name            string      `json:"name"`
+			Version         string      `json:"version"`
+			SemanticVersion string      `json:"semanticVersion"`
+			Rules           []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string `json:"ruleId"`
+	RuleIndex *int   `json:"ruleIndex"`
+	Rule      *struct {
+		ID    string `json:"id"`
+		Index *int   `json:"index"`
+	} `json:"rule"`
+	Level string `json:"level"`
+	Kind  string `json:"kind"`
+}
+
+// ParseFile reads a SARIF 2.1.0 log file and summarizes its findings
+func ParseFile(path string) (*Summary, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid SARIF file: %v", path, err)
+	}
+	return summary, nil
+}
+
+// Parse summarizes the findings of a SARIF 2.1.0 log
+func Parse(content []byte) (*Summary, error) {
+	var log sarifLog
+	if err := json.Unmarshal(content, &log); err != nil {
+		return nil, err
+	}
+	if log.Version != "2.1.0" {
+		return nil, fmt.Errorf("unsupported SARIF version '%s', only 2.1.0 is supported", log.Version)
+	}
+	if log.Runs == nil {
+		return nil, fmt.Errorf("runs is missing")
+	}
+
+	summary := &Summary{Version: log.Version, Tools: []ToolSummary{}}
+	for _, run := range log.Runs {
+		tool := ToolSummary{
+			Name:    run.Tool.Driver.Name,
+			Version: run.Tool.Driver.Version,
+			Rules:   []RuleSummary{},
+		}
+		if tool.Version == "" {
+			tool.Version = run.Tool.Driver.SemanticVersion
+		}
+
+		counts := make(map[RuleSummary]int)
+		for _, result := range run.Results {
+			ruleID, rule := lookupRule(run.Tool.Driver.Rules, result)
+			level := resultLevel(result, rule)
+			counts[RuleSummary{RuleID: ruleID, Level: level}]++
+			tool.ResultCount++
+			switch level {
+			case LevelError:
+				summary.ErrorCount++
+			case LevelWarning:
+				summary.WarningCount++
+			case LevelNote:
+				summary.NoteCount++
+			default:
+				summary.NoneCount++
+			}
+		}
+		for key, count := range counts {
+			key.Count = count
+			tool.Rules = append(tool.Rules, key)
+		}
+		sort.Slice(tool.Rules, func(i, j int) bool {
+			if tool.Rules[i].RuleID != tool.Rules[j].RuleID {
+				return tool.Rules[i].RuleID < tool.Rules[j].RuleID
+			}
+			return tool.Rules[i].Level < tool.Rules[j].Level
+		})
+
+		summary.ResultCount += tool.ResultCount
+		summary.Tools = append(summary.Tools, tool)
+	}
+	return summary, nil
+}
+
+// lookupRule returns the rule id of a result and, if the tool describes it, the rule itself
+func lookupRule(rules []sarifRule, result sarifResult) (string, *sarifRule) {
+	ruleID := result.RuleID
+	index := result.RuleIndex
+	if result.Rule != nil {
+		if ruleID == "" {
+			ruleID = result.Rule.ID
+		}
+		if index == nil {
+			index = result.Rule.Index
+		}
+	}
+	if index != nil && *index >= 0 && *index < len(rules) {
+		rule := &rules[*index]
+		if ruleID == "" {
+			ruleID = rule.ID
+		}
+		return ruleID, rule
+	}
+	for i := range rules {
+		if rules[i].ID == ruleID {
+			return ruleID, &rules[i]
+		}
+	}
+	return ruleID, nil
+}
+
+// resultLevel returns the effective level of a result as defined by the SARIF 2.1.0 spec:
+// the result level, otherwise the rule default level, otherwise 'warning' for failing results
+// and 'none' for any other kind of result
+func resultLevel(result sarifResult, rule *sarifRule) string {
+	if result.Level != "" {
+		return result.Level
+	}
+	if result.Kind != "" && result.Kind != "fail" {
+		return LevelNone
+	}
+	if rule != nil && rule.DefaultConfiguration.Level != "" {
+		return rule.DefaultConfiguration.Level
+	}
+	return LevelWarning
+}
+
+// ValidateLevel returns an error if level is not a valid SARIF result level
+func ValidateLevel(level string) error {
+	for _, l := range Levels {
+		if l == level {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a valid level. Valid levels are: %v", level, Levels)
+}
+
+// Evaluate returns whether the summary is compliant with the policy,
+// and the reasons why it is not
+func (s *Summary) Evaluate(policy Policy) (bool, []string) {
+	violations := []string{}
+	warningsFailed := false
+	counts := []struct {
+		level string
+		count int
+	}{
+		{LevelError, s.ErrorCount},
+		{LevelWarning, s.WarningCount},
+		{LevelNote, s.NoteCount},
+	}
+	if policy.FailOn != LevelNone {
+		for _, c := range counts {
+			if c.count > 0 {
+				violations = append(violations, fmt.Sprintf("found %d %s-level result(s)", c.count, c.level))
+				warningsFailed = warningsFailed || c.level == LevelWarning
+			}
+			if c.level == policy.FailOn {
+				break
+			}
+		}
+	}
+	if !warningsFailed && policy.MaxWarnings >= 0 && s.WarningCount > policy.MaxWarnings {
+		violations = append(violations, fmt.Sprintf("found %d warning-level result(s), the maximum allowed is %d",
+			s.WarningCount, policy.MaxWarnings))
+	}
+	return len(violations) == 0, violations
+}
@@ -0,0 +1,191 @@
+package sarif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/utils"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const twoRunsSARIF = `{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "CodeQL",
+          "semanticVersion": "2.14.0",
+          "rules": [
+            {"id": "go/sql-injection", "defaultConfiguration": {"level": "error"}},
+            {"id": "go/unused-variable", "defaultConfiguration": {"level": "note"}}
+          ]
+        }
+      },
+      "results": [
+        {"ruleId": "go/sql-injection", "ruleIndex": 0},
+        {"ruleId": "go/sql-injection", "level": "warning"},
+        {"rule": {"index": 1}},
+        {"ruleId": "go/unused-variable", "kind": "pass"}
+      ]
+    },
+    {
+      "tool": {"driver": {"name": "Semgrep", "version": "1.30.0"}},
+      "results": [
+        {"ruleId": "generic.secrets.aws-key"},
+        {"ruleId": "generic.secrets.aws-key"}
+      ]
+    }
+  ]
+}`
+
+type SARIFTestSuite struct {
+	suite.Suite
+	tmpDir string
+}
+
+func (suite *SARIFTestSuite) SetupTest() {
+	var err error
+	suite.tmpDir, err = os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err, "error creating a temporary test directory")
+}
+
+func (suite *SARIFTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoErrorf(suite.T(), err, "error cleaning up the temporary test directory %s", suite.tmpDir)
+}
+
+func (suite *SARIFTestSuite) TestParseFile() {
+	for _, t := range []struct {
+		name      string
+		content   string
+		wantError bool
+		want      *Summary
+	}{
+		{
+			name:    "results are summarized per tool, rule and level",
+			content: twoRunsSARIF,
+			want: &Summary{
+				Version:      "2.1.0",
+				ResultCount:  6,
+				ErrorCount:   1,
+				WarningCount: 3,
+				NoteCount:    1,
+				NoneCount:    1,
+				Tools: []ToolSummary{
+					{
+						Name:        "CodeQL",
+						Version:     "2.14.0",
+						ResultCount: 4,
+						Rules: []RuleSummary{
+							{RuleID: "go/sql-injection", Level: LevelError, Count: 1},
+							{RuleID: "go/sql-injection", Level: LevelWarning, Count: 1},
+							{RuleID: "go/unused-variable", Level: LevelNone, Count: 1},
+							{RuleID: "go/unused-variable", Level: LevelNote, Count: 1},
+						},
+					},
+					{
+						Name:        "Semgrep",
+						Version:     "1.30.0",
+						ResultCount: 2,
+						Rules: []RuleSummary{
+							{RuleID: "generic.secrets.aws-key", Level: LevelWarning, Count: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "a run without results is summarized",
+			content: `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "gosec"}}, "results": []}]}`,
+			want: &Summary{
+				Version: "2.1.0",
+				Tools:   []ToolSummary{{Name: "gosec", Rules: []RuleSummary{}}},
+			},
+		},
+		{
+			name:      "an older SARIF version fails",
+			content:   `{"version": "2.0.0", "runs": []}`,
+			wantError: true,
+		},
+		{
+			name:      "SARIF without runs fails",
+			content:   `{"version": "2.1.0"}`,
+			wantError: true,
+		},
+		{
+			name:      "invalid JSON fails",
+			content:   `not json`,
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			path := filepath.Join(suite.tmpDir, "results.sarif")
+			err := utils.CreateFileWithContent(path, t.content)
+			require.NoError(suite.T(), err)
+
+			summary, err := ParseFile(path)
+			if t.wantError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+				require.Equal(suite.T(), t.want, summary)
+			}
+		})
+	}
+}
+
+func (suite *SARIFTestSuite) TestEvaluate() {
+	summary := &Summary{ErrorCount: 0, WarningCount: 3, NoteCount: 2}
+	for _, t := range []struct {
+		name           string
+		policy         Policy
+		wantCompliant  bool
+		wantViolations []string
+	}{
+		{
+			name:           "fail on error is compliant without errors",
+			policy:         Policy{FailOn: LevelError, MaxWarnings: -1},
+			wantCompliant:  true,
+			wantViolations: []string{},
+		},
+		{
+			name:           "fail on warning is not compliant with warnings",
+			policy:         Policy{FailOn: LevelWarning, MaxWarnings: -1},
+			wantViolations: []string{"found 3 warning-level result(s)"},
+		},
+		{
+			name:           "fail on note reports warnings and notes",
+			policy:         Policy{FailOn: LevelNote, MaxWarnings: 10},
+			wantViolations: []string{"found 3 warning-level result(s)", "found 2 note-level result(s)"},
+		},
+		{
+			name:           "more warnings than allowed is not compliant",
+			policy:         Policy{FailOn: LevelError, MaxWarnings: 2},
+			wantViolations: []string{"found 3 warning-level result(s), the maximum allowed is 2"},
+		},
+		{
+			name:           "fail on none with enough allowed warnings is compliant",
+			policy:         Policy{FailOn: LevelNone, MaxWarnings: 3},
+			wantCompliant:  true,
+			wantViolations: []string{},
+		},
+	} {
+		suite.Run(t.name, func() {
+			compliant, violations := summary.Evaluate(t.policy)
+			require.Equal(suite.T(), t.wantCompliant, compliant)
+			require.Equal(suite.T(), t.wantViolations, violations)
+		})
+	}
+}
+
+func (suite *SARIFTestSuite) TestValidateLevel() {
+	require.NoError(suite.T(), ValidateLevel(LevelWarning))
+	require.Error(suite.T(), ValidateLevel("critical"))
+}
+
+func TestSARIFTestSuite(t *testing.T) {
+	suite.Run(t, new(SARIFTestSuite))
+}
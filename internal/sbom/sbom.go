@@ -0,0 +1,470 @@
+package sbom
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kosli-dev/cli/internal/utils"
+)
+
+const (
+	CycloneDXJSON = "cyclonedx-json"
+	CycloneDXXML  = "cyclonedx-xml"
+	SPDXJSON      = "spdx-json"
+	SPDXTagValue  = "spdx-tag-value"
+)
+
+// Summary is the information extracted from an SBOM document
+type Summary struct {
+	Format         string   `json:"format"`
+	SpecVersion    string   `json:"spec_version"`
+	SubjectName    string   `json:"subject_name,omitempty"`
+	SubjectDigest  string   `json:"subject_digest,omitempty"`
+	ComponentCount int      `json:"component_count"`
+	Licenses       []string `json:"licenses"`
+	Purls          []string `json:"purls"`
+}
+
+// sha256Pattern matches a sha256 digest as it appears in image versions and purls
+var sha256Pattern = regexp.MustCompile(`sha256:([a-fA-F0-9]{64})`)
+
+// plainSha256Pattern matches a bare sha256 hex digest
+var plainSha256Pattern = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+
+// ParseFile reads an SBOM file, detects its format and returns its summary.
+// Supported formats are CycloneDX (JSON and XML) and SPDX (JSON and tag-value).
+func ParseFile(path string) (*Summary, error) {
+	content, err := utils.LoadFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := Parse([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid SBOM: %v", path, err)
+	}
+	return summary, nil
+}
+
+// Parse detects the format of an SBOM document and returns its summary
+func Parse(content []byte) (*Summary, error) {
+	trimmed := strings.TrimSpace(string(content))
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		var probe map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &probe); err != nil {
+			return nil, err
+		}
+		if _, ok := probe["bomFormat"]; ok {
+			return parseCycloneDXJSON([]byte(trimmed))
+		}
+		if _, ok := probe["spdxVersion"]; ok {
+			return parseSPDXJSON([]byte(trimmed))
+		}
+		return nil, fmt.Errorf("JSON document is neither CycloneDX nor SPDX")
+	case strings.HasPrefix(trimmed, "<"):
+		return parseCycloneDXXML([]byte(trimmed))
+	case strings.Contains(trimmed, "SPDXVersion:"):
+		return parseSPDXTagValue(trimmed)
+	}
+	return nil, fmt.Errorf("unsupported SBOM format. Supported formats are CycloneDX (JSON, XML) and SPDX (JSON, tag-value)")
+}
+
+// MatchesDigest returns true if the SBOM subject digest equals the given sha256 fingerprint
+func (s *Summary) MatchesDigest(fingerprint string) bool {
+	if s.SubjectDigest == "" || fingerprint == "" {
+		return false
+	}
+	return strings.EqualFold(s.SubjectDigest, strings.TrimPrefix(fingerprint, "sha256:"))
+}
+
+// summaryBuilder accumulates components and de-duplicates licenses and purls
+type summaryBuilder struct {
+	summary  *Summary
+	licenses map[string]bool
+	purls    map[string]bool
+}
+
+func newSummaryBuilder(format, specVersion string) *summaryBuilder {
+	return &summaryBuilder{
+		summary: &Summary{
+			Format:      format,
+			SpecVersion: specVersion,
+			Licenses:    []string{},
+			Purls:       []string{},
+		},
+		licenses: map[string]bool{},
+		purls:    map[string]bool{},
+	}
+}
+
+func (b *summaryBuilder) addComponent(purl string, licenses ...string) {
+	b.summary.ComponentCount++
+	if purl != "" && !b.purls[purl] {
+		b.purls[purl] = true
+		b.summary.Purls = append(b.summary.Purls, purl)
+	}
+	for _, license := range licenses {
+		if license == "" || license == "NOASSERTION" || license == "NONE" || b.licenses[license] {
+			continue
+		}
+		b.licenses[license] = true
+		b.summary.Licenses = append(b.summary.Licenses, license)
+	}
+}
+
+func (b *summaryBuilder) setSubject(name string, digestCandidates ...string) {
+	b.summary.SubjectName = name
+	for _, candidate := range digestCandidates {
+		if digest := extractSha256(candidate); digest != "" {
+			b.summary.SubjectDigest = digest
+			return
+		}
+	}
+}
+
+func (b *summaryBuilder) build() *Summary {
+	sort.Strings(b.summary.Licenses)
+	sort.Strings(b.summary.Purls)
+	return b.summary
+}
+
+// extractSha256 returns a lowercase sha256 hex digest from a plain digest,
+// a 'sha256:' prefixed string or a (url encoded) purl
+func extractSha256(value string) string {
+	if value == "" {
+		return ""
+	}
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	if match := sha256Pattern.FindStringSubmatch(value); match != nil {
+		return strings.ToLower(match[1])
+	}
+	if plainSha256Pattern.MatchString(value) {
+		return strings.ToLower(value)
+	}
+	return ""
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg" xml:"alg,attr"`
+	Content string `json:"content" xml:",chardata"`
+}
+
+type cdxLicenseChoice struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type cdxComponent struct {
+	Name       string             `json:"name"`
+	Version    string             `json:"version"`
+	Purl       string             `json:"purl"`
+	Hashes     []cdxHash          `json:"hashes"`
+	Licenses   []cdxLicenseChoice `json:"licenses"`
+	Components []cdxComponent     `json:"components"`
+}
+
+type cdxJSONDocument struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Component *cdxComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+func (c cdxComponent) licenseNames() []string {
+	names := []string{}
+	for _, l := range c.Licenses {
+		if l.Expression != "" {
+			names = append(names, l.Expression)
+		} else if l.License != nil {
+			if l.License.ID != "" {
+				names = append(names, l.License.ID)
+			} else {
+				names = append(names, l.License.Name)
+			}
+		}
+	}
+	return names
+}
+
+func (c cdxComponent) digestCandidates() []string {
+	candidates := []string{}
+	for _, h := range c.Hashes {
+		if strings.EqualFold(h.Alg, "SHA-256") {
+			candidates = append(candidates, h.Content)
+		}
+	}
+	return append(candidates, c.Version, c.Purl, c.Name)
+}
+
+func parseCycloneDXJSON(content []byte) (*Summary, error) {
+	var doc cdxJSONDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("unexpected bomFormat '%s'", doc.BOMFormat)
+	}
+	if doc.SpecVersion == "" {
+		return nil, fmt.Errorf("specVersion is missing")
+	}
+
+	b := newSummaryBuilder(CycloneDXJSON, doc.SpecVersion)
+	if doc.Metadata.Component != nil {
+		b.setSubject(doc.Metadata.Component.Name, doc.Metadata.Component.digestCandidates()...)
+	}
+	var walk func(components []cdxComponent)
+	walk = func(components []cdxComponent) {
+		for _, c := range components {
+			b.addComponent(c.Purl, c.licenseNames()...)
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+	return b.build(), nil
+}
+
+type cdxXMLComponent struct {
+	Name     string    `xml:"name"`
+	Version  string    `xml:"version"`
+	Purl     string    `xml:"purl"`
+	Hashes   []cdxHash `xml:"hashes>hash"`
+	Licenses struct {
+		Licenses []struct {
+			ID   string `xml:"id"`
+			Name string `xml:"name"`
+		} `xml:"license"`
+		Expressions []string `xml:"expression"`
+	} `xml:"licenses"`
+	Components []cdxXMLComponent `xml:"components>component"`
+}
+
+type cdxXMLDocument struct {
+	XMLName  xml.Name `xml:"bom"`
+	Metadata struct {
+		Component *cdxXMLComponent `xml:"component"`
+	} `xml:"metadata"`
+	Components []cdxXMLComponent `xml:"components>component"`
+}
+
+func (c cdxXMLComponent) licenseNames() []string {
+	names := append([]string{}, c.Licenses.Expressions...)
+	for _, l := range c.Licenses.Licenses {
+		if l.ID != "" {
+			names = append(names, l.ID)
+		} else {
+			names = append(names, l.Name)
+		}
+	}
+	return names
+}
+
+func parseCycloneDXXML(content []byte) (*Summary, error) {
+	var doc cdxXMLDocument
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	// the spec version is only available from the schema namespace, e.g. http://cyclonedx.org/schema/bom/1.4
+	namespace := doc.XMLName.Space
+	if !strings.HasPrefix(namespace, "http://cyclonedx.org/schema/bom/") {
+		return nil, fmt.Errorf("XML document is not a CycloneDX bom")
+	}
+
+	b := newSummaryBuilder(CycloneDXXML, strings.TrimPrefix(namespace, "http://cyclonedx.org/schema/bom/"))
+	if c := doc.Metadata.Component; c != nil {
+		candidates := []string{}
+		for _, h := range c.Hashes {
+			if strings.EqualFold(h.Alg, "SHA-256") {
+				candidates = append(candidates, strings.TrimSpace(h.Content))
+			}
+		}
+		b.setSubject(c.Name, append(candidates, c.Version, c.Purl, c.Name)...)
+	}
+	var walk func(components []cdxXMLComponent)
+	walk = func(components []cdxXMLComponent) {
+		for _, c := range components {
+			b.addComponent(c.Purl, c.licenseNames()...)
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+	return b.build(), nil
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	Checksums        []spdxChecksum    `json:"checksums"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	SPDXID            string             `json:"SPDXID"`
+	DocumentDescribes []string           `json:"documentDescribes"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+func (p spdxPackage) purl() string {
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceType == "purl" {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+func (p spdxPackage) digestCandidates() []string {
+	candidates := []string{}
+	for _, c := range p.Checksums {
+		if c.Algorithm == "SHA256" {
+			candidates = append(candidates, c.ChecksumValue)
+		}
+	}
+	return append(candidates, p.VersionInfo, p.purl(), p.Name)
+}
+
+func parseSPDXJSON(content []byte) (*Summary, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	return summarizeSPDX(doc, SPDXJSON)
+}
+
+// summarizeSPDX validates an SPDX document and builds its summary.
+// The packages described by the document are the SBOM subject, all others are components.
+func summarizeSPDX(doc spdxDocument, format string) (*Summary, error) {
+	if !strings.HasPrefix(doc.SPDXVersion, "SPDX-") {
+		return nil, fmt.Errorf("unexpected spdxVersion '%s'", doc.SPDXVersion)
+	}
+	if doc.SPDXID != "SPDXRef-DOCUMENT" {
+		return nil, fmt.Errorf("document SPDXID must be SPDXRef-DOCUMENT")
+	}
+
+	described := append([]string{}, doc.DocumentDescribes...)
+	for _, r := range doc.Relationships {
+		if r.SPDXElementID == "SPDXRef-DOCUMENT" && r.RelationshipType == "DESCRIBES" {
+			described = append(described, r.RelatedSPDXElement)
+		}
+	}
+
+	b := newSummaryBuilder(format, strings.TrimPrefix(doc.SPDXVersion, "SPDX-"))
+	for _, p := range doc.Packages {
+		if utils.Contains(described, p.SPDXID) {
+			if b.summary.SubjectName == "" {
+				b.setSubject(p.Name, p.digestCandidates()...)
+			}
+			continue
+		}
+		b.addComponent(p.purl(), p.LicenseConcluded, p.LicenseDeclared)
+	}
+	return b.build(), nil
+}
+
+func parseSPDXTagValue(content string) (*Summary, error) {
+	doc := spdxDocument{}
+	// index of the package whose tags are being read, -1 for document level tags
+	current := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		tag, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		tag = strings.TrimSpace(tag)
+		value = strings.TrimSpace(value)
+
+		switch tag {
+		case "SPDXVersion":
+			doc.SPDXVersion = value
+		case "DocumentDescribes":
+			doc.DocumentDescribes = append(doc.DocumentDescribes, value)
+		case "Relationship":
+			fields := strings.Fields(value)
+			if len(fields) == 3 {
+				doc.Relationships = append(doc.Relationships, spdxRelationship{
+					SPDXElementID:      fields[0],
+					RelationshipType:   fields[1],
+					RelatedSPDXElement: fields[2],
+				})
+			}
+		case "PackageName":
+			doc.Packages = append(doc.Packages, spdxPackage{Name: value})
+			current = len(doc.Packages) - 1
+		case "SPDXID":
+			if current < 0 {
+				doc.SPDXID = value
+			} else {
+				doc.Packages[current].SPDXID = value
+			}
+		}
+
+		if current < 0 {
+			continue
+		}
+		p := &doc.Packages[current]
+		switch tag {
+		case "PackageVersion":
+			p.VersionInfo = value
+		case "PackageLicenseConcluded":
+			p.LicenseConcluded = value
+		case "PackageLicenseDeclared":
+			p.LicenseDeclared = value
+		case "PackageChecksum":
+			// e.g. PackageChecksum: SHA256: 2a8c...
+			algorithm, checksum, _ := strings.Cut(value, ":")
+			p.Checksums = append(p.Checksums, spdxChecksum{
+				Algorithm:     strings.TrimSpace(algorithm),
+				ChecksumValue: strings.TrimSpace(checksum),
+			})
+		case "ExternalRef":
+			// e.g. ExternalRef: PACKAGE-MANAGER purl pkg:golang/github.com/spf13/cobra@v1.7.0
+			fields := strings.Fields(value)
+			if len(fields) == 3 {
+				p.ExternalRefs = append(p.ExternalRefs, spdxExternalRef{
+					ReferenceType:    fields[1],
+					ReferenceLocator: fields[2],
+				})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return summarizeSPDX(doc, SPDXTagValue)
+}
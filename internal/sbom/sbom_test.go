@@ -0,0 +1,236 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/utils"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const imageDigest = "7509e5bda0c762d2bac7f90d758b5b2263fa01ccbc542ab5e3df163be08e6ca9"
+
+const cycloneDXJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {
+    "component": {
+      "type": "container",
+      "name": "ghcr.io/kosli-dev/cli",
+      "version": "sha256:` + imageDigest + `"
+    }
+  },
+  "components": [
+    {
+      "name": "cobra",
+      "version": "v1.7.0",
+      "purl": "pkg:golang/github.com/spf13/cobra@v1.7.0",
+      "licenses": [{"license": {"id": "Apache-2.0"}}],
+      "components": [
+        {"name": "pflag", "purl": "pkg:golang/github.com/spf13/pflag@v1.0.5", "licenses": [{"expression": "BSD-3-Clause"}]}
+      ]
+    },
+    {
+      "name": "testify",
+      "purl": "pkg:golang/github.com/stretchr/testify@v1.8.2",
+      "licenses": [{"license": {"id": "MIT"}}]
+    }
+  ]
+}`
+
+const cycloneDXXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1">
+  <metadata>
+    <component type="file">
+      <name>app.tgz</name>
+      <hashes>
+        <hash alg="SHA-256">` + imageDigest + `</hash>
+      </hashes>
+    </component>
+  </metadata>
+  <components>
+    <component type="library">
+      <name>cobra</name>
+      <purl>pkg:golang/github.com/spf13/cobra@v1.7.0</purl>
+      <licenses><license><id>Apache-2.0</id></license></licenses>
+    </component>
+  </components>
+</bom>`
+
+const spdxJSON = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "cli",
+  "documentDescribes": ["SPDXRef-Image"],
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Image",
+      "name": "cli",
+      "checksums": [{"algorithm": "SHA256", "checksumValue": "` + imageDigest + `"}]
+    },
+    {
+      "SPDXID": "SPDXRef-Package-cobra",
+      "name": "cobra",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "Apache-2.0",
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/spf13/cobra@v1.7.0"}]
+    }
+  ]
+}`
+
+const spdxTagValue = `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: cli
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Image
+
+PackageName: cli
+SPDXID: SPDXRef-Image
+PackageVersion: sha256:` + imageDigest + `
+
+PackageName: cobra
+SPDXID: SPDXRef-Package-cobra
+PackageVersion: v1.7.0
+PackageLicenseConcluded: Apache-2.0
+PackageLicenseDeclared: Apache-2.0
+ExternalRef: PACKAGE-MANAGER purl pkg:golang/github.com/spf13/cobra@v1.7.0
+
+PackageName: pflag
+SPDXID: SPDXRef-Package-pflag
+PackageLicenseConcluded: BSD-3-Clause
+`
+
+type SBOMTestSuite struct {
+	suite.Suite
+	tmpDir string
+}
+
+func (suite *SBOMTestSuite) SetupTest() {
+	var err error
+	suite.tmpDir, err = os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err, "error creating a temporary test directory")
+}
+
+func (suite *SBOMTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoErrorf(suite.T(), err, "error cleaning up the temporary test directory %s", suite.tmpDir)
+}
+
+func (suite *SBOMTestSuite) TestParseFile() {
+	for _, t := range []struct {
+		name      string
+		content   string
+		wantError bool
+		want      *Summary
+	}{
+		{
+			name:    "CycloneDX JSON with nested components is parsed",
+			content: cycloneDXJSON,
+			want: &Summary{
+				Format:         CycloneDXJSON,
+				SpecVersion:    "1.4",
+				SubjectName:    "ghcr.io/kosli-dev/cli",
+				SubjectDigest:  imageDigest,
+				ComponentCount: 3,
+				Licenses:       []string{"Apache-2.0", "BSD-3-Clause", "MIT"},
+				Purls: []string{
+					"pkg:golang/github.com/spf13/cobra@v1.7.0",
+					"pkg:golang/github.com/spf13/pflag@v1.0.5",
+					"pkg:golang/github.com/stretchr/testify@v1.8.2",
+				},
+			},
+		},
+		{
+			name:    "CycloneDX XML is parsed",
+			content: cycloneDXXML,
+			want: &Summary{
+				Format:         CycloneDXXML,
+				SpecVersion:    "1.5",
+				SubjectName:    "app.tgz",
+				SubjectDigest:  imageDigest,
+				ComponentCount: 1,
+				Licenses:       []string{"Apache-2.0"},
+				Purls:          []string{"pkg:golang/github.com/spf13/cobra@v1.7.0"},
+			},
+		},
+		{
+			name:    "SPDX JSON is parsed and the described package is the subject",
+			content: spdxJSON,
+			want: &Summary{
+				Format:         SPDXJSON,
+				SpecVersion:    "2.3",
+				SubjectName:    "cli",
+				SubjectDigest:  imageDigest,
+				ComponentCount: 1,
+				Licenses:       []string{"Apache-2.0"},
+				Purls:          []string{"pkg:golang/github.com/spf13/cobra@v1.7.0"},
+			},
+		},
+		{
+			name:    "SPDX tag-value is parsed",
+			content: spdxTagValue,
+			want: &Summary{
+				Format:         SPDXTagValue,
+				SpecVersion:    "2.2",
+				SubjectName:    "cli",
+				SubjectDigest:  imageDigest,
+				ComponentCount: 2,
+				Licenses:       []string{"Apache-2.0", "BSD-3-Clause"},
+				Purls:          []string{"pkg:golang/github.com/spf13/cobra@v1.7.0"},
+			},
+		},
+		{
+			name:      "JSON that is not an SBOM fails",
+			content:   `{"vulnerabilities": []}`,
+			wantError: true,
+		},
+		{
+			name:      "CycloneDX JSON without specVersion fails",
+			content:   `{"bomFormat": "CycloneDX"}`,
+			wantError: true,
+		},
+		{
+			name:      "XML that is not a CycloneDX bom fails",
+			content:   `<testsuites><testsuite name="foo"></testsuite></testsuites>`,
+			wantError: true,
+		},
+		{
+			name:      "SPDX without a document SPDXID fails",
+			content:   `{"spdxVersion": "SPDX-2.3"}`,
+			wantError: true,
+		},
+		{
+			name:      "plain text fails",
+			content:   "this is not an SBOM",
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			path := filepath.Join(suite.tmpDir, "sbom")
+			err := utils.CreateFileWithContent(path, t.content)
+			require.NoError(suite.T(), err)
+
+			summary, err := ParseFile(path)
+			if t.wantError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+				require.Equal(suite.T(), t.want, summary)
+			}
+		})
+	}
+}
+
+func (suite *SBOMTestSuite) TestMatchesDigest() {
+	summary := &Summary{SubjectDigest: imageDigest}
+	require.True(suite.T(), summary.MatchesDigest(imageDigest))
+	require.True(suite.T(), summary.MatchesDigest("sha256:"+imageDigest))
+	require.False(suite.T(), summary.MatchesDigest("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"))
+	require.False(suite.T(), (&Summary{}).MatchesDigest(imageDigest))
+}
+
+func TestSBOMTestSuite(t *testing.T) {
+	suite.Run(t, new(SBOMTestSuite))
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// States of the checks reported on a commit
+const (
+	CheckSuccess = "success"
+	CheckFailure = "failure"
+	CheckPending = "pending"
+	CheckNeutral = "neutral"
+)
+
+// BranchProtection is the protection of a branch and the status of the checks of a commit on it
+type BranchProtection struct {
+	Branch    string `json:"branch"`
+	Commit    string `json:"commit"`
+	Protected bool   `json:"protected"`
+	// RequiredApprovals is the number of pull request approvals required to merge
+	RequiredApprovals       int  `json:"required_approvals"`
+	RequireCodeOwnerReviews bool `json:"require_code_owner_reviews"`
+	DismissStaleReviews     bool `json:"dismiss_stale_reviews"`
+	// RequiredChecks are the names of the checks that must pass to merge
+	RequiredChecks []string `json:"required_checks"`
+	// RequirePassingChecks is set when all the checks of a commit must pass to merge,
+	// for providers that do not name the required checks
+	RequirePassingChecks bool `json:"require_passing_checks"`
+	// AllowForcePushes is nil when the provider does not expose it
+	AllowForcePushes *bool `json:"allow_force_pushes"`
+	// EnforceAdmins is nil when the provider does not expose it
+	EnforceAdmins *bool         `json:"enforce_admins"`
+	Checks        []CheckStatus `json:"checks"`
+}
+
+// CheckStatus is the state of a check (CI status or check run) reported on a commit
+type CheckStatus struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type BranchProtectionRetriever interface {
+	BranchProtectionForCommit(branch, commit string) (*BranchProtection, error)
+}
+
+// Evaluate returns whether the branch protection is compliant, and the reasons why it is not.
+// A branch is compliant if it is protected, requires approvals and checks,
+// does not allow force pushes, and the required checks passed on the commit.
+func (b *BranchProtection) Evaluate() (bool, []string) {
+	violations := []string{}
+	if !b.Protected {
+		violations = append(violations, fmt.Sprintf("branch %s is not protected", b.Branch))
+		return false, violations
+	}
+	if b.RequiredApprovals < 1 {
+		violations = append(violations, fmt.Sprintf("branch %s does not require pull request approvals", b.Branch))
+	}
+	if b.AllowForcePushes != nil && *b.AllowForcePushes {
+		violations = append(violations, fmt.Sprintf("branch %s allows force pushes", b.Branch))
+	}
+	if len(b.RequiredChecks) == 0 && !b.RequirePassingChecks {
+		violations = append(violations, fmt.Sprintf("branch %s does not require status checks", b.Branch))
+	}
+	for _, name := range b.RequiredChecks {
+		check := b.check(name)
+		if check == nil {
+			violations = append(violations, fmt.Sprintf("required check %s was not reported on commit %s", name, b.Commit))
+		} else if check.State != CheckSuccess {
+			violations = append(violations, fmt.Sprintf("required check %s is %s on commit %s", name, check.State, b.Commit))
+		}
+	}
+	if b.RequirePassingChecks {
+		if len(b.Checks) == 0 {
+			violations = append(violations, fmt.Sprintf("no checks were reported on commit %s", b.Commit))
+		}
+		for _, check := range b.Checks {
+			if check.State != CheckSuccess && check.State != CheckNeutral {
+				violations = append(violations, fmt.Sprintf("check %s is %s on commit %s", check.Name, check.State, b.Commit))
+			}
+		}
+	}
+	return len(violations) == 0, violations
+}
+
+// check returns the check with the given name, case-insensitively, or nil if it was not reported.
+// If a check was reported several times, a successful one is preferred.
+func (b *BranchProtection) check(name string) *CheckStatus {
+	var found *CheckStatus
+	for i, check := range b.Checks {
+		if strings.EqualFold(check.Name, name) {
+			if found == nil || check.State == CheckSuccess {
+				found = &b.Checks[i]
+			}
+		}
+	}
+	return found
+}
+
+// NormalizeCheckState maps a provider specific check or status state to one of the check states
+func NormalizeCheckState(state string) string {
+	switch strings.ToLower(state) {
+	case "success", "successful", "succeeded", "passed":
+		return CheckSuccess
+	case "failure", "failed", "error", "timed_out", "cancelled", "canceled", "action_required", "stopped":
+		return CheckFailure
+	case "neutral", "skipped", "notapplicable", "not_applicable", "manual":
+		return CheckNeutral
+	}
+	return CheckPending
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BranchProtectionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BranchProtectionTestSuite) TestEvaluate() {
+	yes, no := true, false
+	for _, t := range []struct {
+		name           string
+		protection     BranchProtection
+		wantCompliant  bool
+		wantViolations []string
+	}{
+		{
+			name: "protected branch with passing required checks is compliant",
+			protection: BranchProtection{Branch: "main", Commit: "abc", Protected: true, RequiredApprovals: 1,
+				RequiredChecks: []string{"build"}, AllowForcePushes: &no,
+				Checks: []CheckStatus{{Name: "build", State: CheckFailure}, {Name: "Build", State: CheckSuccess}}},
+			wantCompliant:  true,
+			wantViolations: []string{},
+		},
+		{
+			name:           "unprotected branch is not compliant",
+			protection:     BranchProtection{Branch: "main", Commit: "abc"},
+			wantViolations: []string{"branch main is not protected"},
+		},
+		{
+			name: "bypassed rules are not compliant",
+			protection: BranchProtection{Branch: "main", Commit: "abc", Protected: true,
+				RequiredChecks: []string{"build", "test"}, AllowForcePushes: &yes,
+				Checks: []CheckStatus{{Name: "build", State: CheckPending}}},
+			wantViolations: []string{
+				"branch main does not require pull request approvals",
+				"branch main allows force pushes",
+				"required check build is pending on commit abc",
+				"required check test was not reported on commit abc",
+			},
+		},
+		{
+			name: "all checks must pass when checks are required without names",
+			protection: BranchProtection{Branch: "main", Commit: "abc", Protected: true, RequiredApprovals: 2,
+				RequirePassingChecks: true,
+				Checks:               []CheckStatus{{Name: "lint", State: CheckNeutral}, {Name: "test", State: CheckFailure}}},
+			wantViolations: []string{"check test is failure on commit abc"},
+		},
+		{
+			name:           "no required checks is not compliant",
+			protection:     BranchProtection{Branch: "main", Commit: "abc", Protected: true, RequiredApprovals: 1},
+			wantViolations: []string{"branch main does not require status checks"},
+		},
+	} {
+		suite.Run(t.name, func() {
+			compliant, violations := t.protection.Evaluate()
+			require.Equal(suite.T(), t.wantCompliant, compliant)
+			require.Equal(suite.T(), t.wantViolations, violations)
+		})
+	}
+}
+
+func (suite *BranchProtectionTestSuite) TestNormalizeCheckState() {
+	for state, want := range map[string]string{
+		"SUCCESSFUL":  CheckSuccess,
+		"succeeded":   CheckSuccess,
+		"timed_out":   CheckFailure,
+		"error":       CheckFailure,
+		"skipped":     CheckNeutral,
+		"in_progress": CheckPending,
+		"":            CheckPending,
+	} {
+		require.Equal(suite.T(), want, NormalizeCheckState(state), state)
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestBranchProtectionTestSuite(t *testing.T) {
+	suite.Run(t, new(BranchProtectionTestSuite))
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FourEyesResult is the outcome of the four-eyes review analysis of a pull request
+type FourEyesResult struct {
+	Passed bool `json:"passed"`
+	// IndependentApprovers are the approvers who are neither the pull request author nor a commit author
+	IndependentApprovers []string `json:"independent_approvers"`
+	Violations           []string `json:"violations"`
+}
+
+// String returns the login of the user, or its name if the login is unknown
+func (u PRUser) String() string {
+	if u.Login != "" {
+		return u.Login
+	}
+	return u.Name
+}
+
+// Same returns true if both users are the same person.
+// Logins are compared when both are known, otherwise names are compared case-insensitively.
+func (u PRUser) Same(other PRUser) bool {
+	if u.Login != "" && other.Login != "" {
+		return strings.EqualFold(u.Login, other.Login)
+	}
+	return u.Name != "" && strings.EqualFold(u.Name, other.Name)
+}
+
+// AnalyzeFourEyes fills in the last commit, the self approval and the four-eyes
+// verdict of the pull request evidence from its author, commits and reviews.
+// A pull request satisfies the four-eyes rule if it has at least one approval from someone
+// who did not author the pull request or any of its commits, and no commits were
+// pushed after the last approval. If the git provider does not report when the pull request
+// was approved, commits pushed after the approval cannot be ruled out, which is a violation.
+func (e *PREvidence) AnalyzeFourEyes() {
+	result := &FourEyesResult{IndependentApprovers: []string{}, Violations: []string{}}
+	e.CommitsAfterApproval = []string{}
+
+	var lastApproval int64
+	for _, review := range e.Reviews {
+		if review.Timestamp > lastApproval {
+			lastApproval = review.Timestamp
+		}
+		if e.isContributor(review.Reviewer) {
+			e.SelfApproved = true
+			result.Violations = append(result.Violations,
+				fmt.Sprintf("pull request is approved by %s who is the author or a committer", review.Reviewer))
+			continue
+		}
+		if !containsString(result.IndependentApprovers, review.Reviewer.String()) {
+			result.IndependentApprovers = append(result.IndependentApprovers, review.Reviewer.String())
+		}
+	}
+	if len(result.IndependentApprovers) == 0 {
+		result.Violations = append(result.Violations, "pull request has no approval from an independent reviewer")
+	}
+
+	var lastCommitTime int64
+	for _, commit := range e.Commits {
+		if commit.Timestamp >= lastCommitTime {
+			lastCommitTime = commit.Timestamp
+			e.LastCommit = commit.SHA
+			e.LastCommitter = commit.Author.String()
+		}
+		if lastApproval > 0 && commit.Timestamp > lastApproval {
+			e.CommitsAfterApproval = append(e.CommitsAfterApproval, commit.SHA)
+		}
+	}
+	if len(e.Reviews) > 0 && len(e.Commits) > 0 && lastApproval == 0 {
+		result.Violations = append(result.Violations,
+			"the approval time is unknown, so commits pushed after the last approval cannot be ruled out")
+	}
+	if len(e.CommitsAfterApproval) > 0 {
+		result.Violations = append(result.Violations,
+			fmt.Sprintf("commits were pushed after the last approval: %s", strings.Join(e.CommitsAfterApproval, ", ")))
+	}
+
+	result.Passed = len(result.Violations) == 0
+	e.FourEyes = result
+}
+
+// isContributor returns true if the user authored the pull request or one of its commits
+func (e *PREvidence) isContributor(user PRUser) bool {
+	if user.Same(e.Author) {
+		return true
+	}
+	for _, commit := range e.Commits {
+		if user.Same(commit.Author) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type FourEyesTestSuite struct {
+	suite.Suite
+}
+
+var (
+	alice = PRUser{Login: "alice", Name: "Alice Smith"}
+	bob   = PRUser{Login: "bob", Name: "Bob Jones"}
+	carol = PRUser{Login: "carol", Name: "Carol White"}
+)
+
+func (suite *FourEyesTestSuite) TestSame() {
+	for _, t := range []struct {
+		name  string
+		user  PRUser
+		other PRUser
+		want  bool
+	}{
+		{name: "same logins", user: alice, other: PRUser{Login: "Alice"}, want: true},
+		{name: "different logins", user: alice, other: PRUser{Login: "bob", Name: "Alice Smith"}, want: false},
+		{name: "names are compared when a login is unknown", user: alice, other: PRUser{Name: "alice smith"}, want: true},
+		{name: "empty names are not the same", user: PRUser{Login: "alice"}, other: PRUser{}, want: false},
+	} {
+		suite.Run(t.name, func() {
+			require.Equal(suite.T(), t.want, t.user.Same(t.other))
+		})
+	}
+}
+
+func (suite *FourEyesTestSuite) TestAnalyzeFourEyes() {
+	for _, t := range []struct {
+		name                     string
+		evidence                 *PREvidence
+		wantPassed               bool
+		wantSelfApproved         bool
+		wantIndependent          []string
+		wantCommitsAfterApproval []string
+		wantLastCommit           string
+		wantLastCommitter        string
+		wantViolations           int
+	}{
+		{
+			name: "independent approval after the last commit passes",
+			evidence: &PREvidence{
+				Author:  alice,
+				Commits: []PRCommit{{SHA: "c1", Author: alice, Timestamp: 100}, {SHA: "c2", Author: alice, Timestamp: 200}},
+				Reviews: []PRReview{{Reviewer: bob, Timestamp: 300}},
+			},
+			wantPassed:               true,
+			wantIndependent:          []string{"bob"},
+			wantCommitsAfterApproval: []string{},
+			wantLastCommit:           "c2",
+			wantLastCommitter:        "alice",
+		},
+		{
+			name: "approval by a committer is a self approval",
+			evidence: &PREvidence{
+				Author:  alice,
+				Commits: []PRCommit{{SHA: "c1", Author: alice, Timestamp: 100}, {SHA: "c2", Author: PRUser{Name: "Bob Jones"}, Timestamp: 200}},
+				Reviews: []PRReview{{Reviewer: bob, Timestamp: 300}},
+			},
+			wantSelfApproved:         true,
+			wantIndependent:          []string{},
+			wantCommitsAfterApproval: []string{},
+			wantLastCommit:           "c2",
+			wantLastCommitter:        "Bob Jones",
+			wantViolations:           2,
+		},
+		{
+			name: "commits after the last approval fail",
+			evidence: &PREvidence{
+				Author:  alice,
+				Commits: []PRCommit{{SHA: "c1", Author: alice, Timestamp: 100}, {SHA: "c2", Author: alice, Timestamp: 400}},
+				Reviews: []PRReview{{Reviewer: bob, Timestamp: 200}, {Reviewer: carol, Timestamp: 300}},
+			},
+			wantIndependent:          []string{"bob", "carol"},
+			wantCommitsAfterApproval: []string{"c2"},
+			wantLastCommit:           "c2",
+			wantLastCommitter:        "alice",
+			wantViolations:           1,
+		},
+		{
+			name: "unknown approval time fails",
+			evidence: &PREvidence{
+				Author:  alice,
+				Commits: []PRCommit{{SHA: "c1", Author: alice, Timestamp: 100}},
+				Reviews: []PRReview{{Reviewer: bob}},
+			},
+			wantIndependent:          []string{"bob"},
+			wantCommitsAfterApproval: []string{},
+			wantLastCommit:           "c1",
+			wantLastCommitter:        "alice",
+			wantViolations:           1,
+		},
+		{
+			name: "a known approval time is used when another approval time is unknown",
+			evidence: &PREvidence{
+				Author:  alice,
+				Commits: []PRCommit{{SHA: "c1", Author: alice, Timestamp: 100}},
+				Reviews: []PRReview{{Reviewer: bob}, {Reviewer: carol, Timestamp: 200}},
+			},
+			wantPassed:               true,
+			wantIndependent:          []string{"bob", "carol"},
+			wantCommitsAfterApproval: []string{},
+			wantLastCommit:           "c1",
+			wantLastCommitter:        "alice",
+		},
+		{
+			name:                     "no approvals fail",
+			evidence:                 &PREvidence{Author: alice},
+			wantIndependent:          []string{},
+			wantCommitsAfterApproval: []string{},
+			wantViolations:           1,
+		},
+	} {
+		suite.Run(t.name, func() {
+			t.evidence.AnalyzeFourEyes()
+			require.Equal(suite.T(), t.wantPassed, t.evidence.FourEyes.Passed)
+			require.Equal(suite.T(), t.wantSelfApproved, t.evidence.SelfApproved)
+			require.Equal(suite.T(), t.wantIndependent, t.evidence.FourEyes.IndependentApprovers)
+			require.Equal(suite.T(), t.wantCommitsAfterApproval, t.evidence.CommitsAfterApproval)
+			require.Equal(suite.T(), t.wantLastCommit, t.evidence.LastCommit)
+			require.Equal(suite.T(), t.wantLastCommitter, t.evidence.LastCommitter)
+			require.Len(suite.T(), t.evidence.FourEyes.Violations, t.wantViolations)
+		})
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestFourEyesTestSuite(t *testing.T) {
+	suite.Run(t, new(FourEyesTestSuite))
+}
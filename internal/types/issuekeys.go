@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultIssueKeyPattern matches issue keys that consist of [project-key]-[sequential-number], as used by Jira and Linear.
+// The project key must be at least 2 characters long and start with an uppercase letter.
+// More info: https://support.atlassian.com/jira-software-cloud/docs/what-is-an-issue/#Workingwithissues-Projectandissuekeys
+const DefaultIssueKeyPattern = `[A-Z][A-Z0-9]{1,9}-[0-9]+`
+
+// IssueKeyMatcher finds issue keys in texts such as commit messages, branch names and pull request titles
+type IssueKeyMatcher struct {
+	patterns    []*regexp.Regexp
+	projectKeys map[string]bool
+}
+
+// NewIssueKeyMatcher returns an IssueKeyMatcher for the patterns, or DefaultIssueKeyPattern if there are none.
+// If a pattern has a capture group, the first group is the issue key, otherwise the whole match is.
+// If projectKeys are given, only the [project-key]-[sequential-number] keys of these projects are matched.
+func NewIssueKeyMatcher(patterns, projectKeys []string) (*IssueKeyMatcher, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultIssueKeyPattern}
+	}
+	matcher := &IssueKeyMatcher{projectKeys: make(map[string]bool)}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue key pattern '%s': %v", pattern, err)
+		}
+		matcher.patterns = append(matcher.patterns, re)
+	}
+	for _, key := range projectKeys {
+		matcher.projectKeys[strings.ToUpper(strings.TrimSpace(key))] = true
+	}
+	return matcher, nil
+}
+
+// FindKeys returns the unique issue keys found in the texts, in order of appearance
+func (m *IssueKeyMatcher) FindKeys(texts ...string) []string {
+	keys := []string{}
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, re := range m.patterns {
+			for _, match := range re.FindAllStringSubmatch(text, -1) {
+				key := match[0]
+				if len(match) > 1 {
+					key = match[1]
+				}
+				if seen[key] || !m.allowed(key) {
+					continue
+				}
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// allowed returns true if the project of the issue key is in the allowlist, or if there is no allowlist
+func (m *IssueKeyMatcher) allowed(key string) bool {
+	if len(m.projectKeys) == 0 {
+		return true
+	}
+	separator := strings.LastIndex(key, "-")
+	return separator > 0 && m.projectKeys[strings.ToUpper(key[:separator])]
+}
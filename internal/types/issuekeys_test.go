@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IssueKeyMatcherTestSuite struct {
+	suite.Suite
+}
+
+func (suite *IssueKeyMatcherTestSuite) TestFindKeys() {
+	for _, t := range []struct {
+		name        string
+		patterns    []string
+		projectKeys []string
+		texts       []string
+		want        []string
+	}{
+		{
+			name:  "default pattern finds unique keys in all texts",
+			texts: []string{"EX-1 fix login", "feature/EX-1-OPS-22", "Relates to SEC-3"},
+			want:  []string{"EX-1", "OPS-22", "SEC-3"},
+		},
+		{
+			name:        "project keys filter the matches",
+			projectKeys: []string{"ex", "SEC"},
+			texts:       []string{"EX-1 UTF-8 SEC-3"},
+			want:        []string{"EX-1", "SEC-3"},
+		},
+		{
+			name:     "custom pattern with a capture group",
+			patterns: []string{`\[([A-Z]+-[0-9]+)\]`},
+			texts:    []string{"[EX-7] fix EX-8"},
+			want:     []string{"EX-7"},
+		},
+		{
+			name:  "no keys",
+			texts: []string{"fix typo"},
+			want:  []string{},
+		},
+	} {
+		suite.Run(t.name, func() {
+			matcher, err := NewIssueKeyMatcher(t.patterns, t.projectKeys)
+			require.NoError(suite.T(), err)
+			require.Equal(suite.T(), t.want, matcher.FindKeys(t.texts...))
+		})
+	}
+}
+
+func (suite *IssueKeyMatcherTestSuite) TestInvalidPattern() {
+	_, err := NewIssueKeyMatcher([]string{"EX-("}, nil)
+	require.ErrorContains(suite.T(), err, "invalid issue key pattern 'EX-('")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestIssueKeyMatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(IssueKeyMatcherTestSuite))
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueRules decide whether the issues referenced by a commit are compliant.
+// Issues that do not exist are always a violation. References to pull requests, e.g. in the
+// "Merge pull request #45" message of a merge commit, are not issue references and are not checked.
+type IssueRules struct {
+	// RequireIssues makes a commit without issue references non-compliant
+	RequireIssues bool
+	// AllowedStatuses are the statuses an issue must be in. Any status is allowed if empty.
+	AllowedStatuses []string
+	// FixVersionRequiredTypes are the issue types, e.g. Bug, that must have a fix version
+	FixVersionRequiredTypes []string
+}
+
+// Evaluate returns whether the issues are compliant with the rules,
+// and the reasons why they are not
+func (r IssueRules) Evaluate(issues []*IssueInfo) (bool, []string) {
+	violations := []string{}
+	references := []*IssueInfo{}
+	for _, issue := range issues {
+		if issue.IssueType != PullRequestIssueType {
+			references = append(references, issue)
+		}
+	}
+	if r.RequireIssues && len(references) == 0 {
+		violations = append(violations, "no issue references found")
+	}
+	for _, issue := range references {
+		if !issue.IssueExists {
+			violations = append(violations, fmt.Sprintf("%s: issue not found", issue.IssueID))
+			continue
+		}
+		if len(r.AllowedStatuses) > 0 && !containsFold(r.AllowedStatuses, issue.Status) {
+			violations = append(violations, fmt.Sprintf("%s: status '%s' is not one of %v",
+				issue.IssueID, issue.Status, r.AllowedStatuses))
+		}
+		if containsFold(r.FixVersionRequiredTypes, issue.IssueType) && len(issue.FixVersions) == 0 {
+			violations = append(violations, fmt.Sprintf("%s: issue of type '%s' has no fix version",
+				issue.IssueID, issue.IssueType))
+		}
+	}
+	return len(violations) == 0, violations
+}
+
+// containsFold returns true if values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
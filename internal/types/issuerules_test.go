@@ -0,0 +1,77 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IssueRulesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *IssueRulesTestSuite) TestEvaluate() {
+	done := &IssueInfo{IssueID: "EX-1", IssueExists: true, Status: "Done", IssueType: "Story"}
+	bug := &IssueInfo{IssueID: "EX-2", IssueExists: true, Status: "In Progress", IssueType: "Bug"}
+	missing := &IssueInfo{IssueID: "EX-3"}
+	pullRequest := &IssueInfo{IssueID: "#45", IssueExists: true, Status: "closed", IssueType: PullRequestIssueType}
+	for _, t := range []struct {
+		name           string
+		rules          IssueRules
+		issues         []*IssueInfo
+		wantViolations []string
+	}{
+		{
+			name:           "existing issues are compliant without rules",
+			issues:         []*IssueInfo{done, bug},
+			wantViolations: []string{},
+		},
+		{
+			name:           "no issues are compliant unless required",
+			issues:         []*IssueInfo{},
+			wantViolations: []string{},
+		},
+		{
+			name:           "required issues",
+			rules:          IssueRules{RequireIssues: true},
+			issues:         []*IssueInfo{},
+			wantViolations: []string{"no issue references found"},
+		},
+		{
+			name:           "references to pull requests are not issue references",
+			rules:          IssueRules{RequireIssues: true, AllowedStatuses: []string{"done"}},
+			issues:         []*IssueInfo{pullRequest},
+			wantViolations: []string{"no issue references found"},
+		},
+		{
+			name:           "missing issues are not compliant",
+			issues:         []*IssueInfo{done, missing},
+			wantViolations: []string{"EX-3: issue not found"},
+		},
+		{
+			name:           "allowed statuses are case insensitive",
+			rules:          IssueRules{AllowedStatuses: []string{"done"}},
+			issues:         []*IssueInfo{done, bug},
+			wantViolations: []string{"EX-2: status 'In Progress' is not one of [done]"},
+		},
+		{
+			name:           "bugs without fix version",
+			rules:          IssueRules{FixVersionRequiredTypes: []string{"Bug"}},
+			issues:         []*IssueInfo{done, bug},
+			wantViolations: []string{"EX-2: issue of type 'Bug' has no fix version"},
+		},
+	} {
+		suite.Run(t.name, func() {
+			compliant, violations := t.rules.Evaluate(t.issues)
+			require.Equal(suite.T(), t.wantViolations, violations)
+			require.Equal(suite.T(), len(t.wantViolations) == 0, compliant)
+		})
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestIssueRulesTestSuite(t *testing.T) {
+	suite.Run(t, new(IssueRulesTestSuite))
+}
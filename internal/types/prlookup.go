@@ -0,0 +1,141 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Strategies used to find the pull requests of a commit
+const (
+	// PRResolvedByCommit means the git provider associates the pull request with the commit
+	PRResolvedByCommit = "commit"
+	// PRResolvedByPRNumber means the pull request number was given explicitly
+	PRResolvedByPRNumber = "pr-number"
+	// PRResolvedByCommitMessage means the pull request number was found in the commit message
+	PRResolvedByCommitMessage = "commit-message"
+	// PRResolvedByMergeCommit means the merge commit of a closed pull request is the commit
+	PRResolvedByMergeCommit = "merge-commit"
+)
+
+// PRLookupRetriever is a PRRetriever that can also find pull requests which are not associated
+// with a commit by the git provider, e.g. after a squash or rebase merge, or a cherry-pick
+type PRLookupRetriever interface {
+	PRRetriever
+	// PREvidenceForNumber returns the evidence of a pull request by its number
+	PREvidenceForNumber(number int) (*PREvidence, error)
+	// PREvidenceForMergeCommit returns the evidence of the recently closed pull requests
+	// whose merge commit is the commit
+	PREvidenceForMergeCommit(commit string) ([]*PREvidence, error)
+	// CommitMessage returns the message of a commit
+	CommitMessage(commit string) (string, error)
+}
+
+// prNumberPatterns match the pull request number in the commit messages created by git providers when merging
+var prNumberPatterns = []*regexp.Regexp{
+	// Github and Gitea squash merges: "Title (#123)"
+	regexp.MustCompile(`\(#(\d+)\)`),
+	// Github merge commits: "Merge pull request #123 from org/branch"
+	regexp.MustCompile(`(?m)^Merge pull request #(\d+)`),
+	// Gitlab merge and squash commits: "See merge request group/project!45"
+	regexp.MustCompile(`See merge request [\w./-]*!(\d+)`),
+	// Azure DevOps: "Merged PR 123: Title"
+	regexp.MustCompile(`(?m)^Merged PR (\d+):`),
+	// Bitbucket Cloud: "Merged in branch (pull request #12)"
+	regexp.MustCompile(`\(pull request #(\d+)\)`),
+	// Bitbucket Data Center: "Pull request #12: Title"
+	regexp.MustCompile(`(?m)^Pull request #(\d+):`),
+}
+
+// PRNumbersFromCommitMessage returns the pull request numbers referenced in a commit message, in order of appearance
+func PRNumbersFromCommitMessage(message string) []int {
+	numbers := []int{}
+	seen := make(map[int]bool)
+	for _, pattern := range prNumberPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(message, -1) {
+			number, err := strconv.Atoi(match[1])
+			if err != nil || seen[number] {
+				continue
+			}
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// FindPREvidence returns the pull requests of a commit, and records how each was found.
+// If prNumber is set, only that pull request is returned. Otherwise, the pull requests
+// associated with the commit are returned. If there are none and the retriever supports it,
+// the closed pull requests whose merge commit is the commit are returned, and if there are none either,
+// the pull requests referenced in the commit message. As anyone can reference a pull request
+// in a commit message, a referenced pull request is only returned if it is merged and it
+// contains the commit, or the commit is its merge or squash commit.
+// A commit message that cannot be read, or a referenced number that is not a pull request, is ignored.
+func FindPREvidence(retriever PRRetriever, commit string, prNumber int) ([]*PREvidence, error) {
+	lookup, canLookup := retriever.(PRLookupRetriever)
+	if prNumber > 0 {
+		if !canLookup {
+			return []*PREvidence{}, fmt.Errorf("looking up a pull request by number is not supported for this git provider")
+		}
+		evidence, err := lookup.PREvidenceForNumber(prNumber)
+		if err != nil {
+			return []*PREvidence{}, err
+		}
+		return resolvedBy([]*PREvidence{evidence}, PRResolvedByPRNumber), nil
+	}
+
+	pullRequestsEvidence, err := retriever.PREvidenceForCommit(commit)
+	if err != nil || len(pullRequestsEvidence) > 0 || !canLookup {
+		return resolvedBy(pullRequestsEvidence, PRResolvedByCommit), err
+	}
+
+	pullRequestsEvidence, err = lookup.PREvidenceForMergeCommit(commit)
+	if err != nil || len(pullRequestsEvidence) > 0 {
+		return resolvedBy(pullRequestsEvidence, PRResolvedByMergeCommit), err
+	}
+
+	// the commit may not exist in the git provider, and a number in a commit message may not be
+	// a pull request, so this lookup is best effort
+	message, err := lookup.CommitMessage(commit)
+	if err == nil {
+		for _, number := range PRNumbersFromCommitMessage(message) {
+			evidence, err := lookup.PREvidenceForNumber(number)
+			if err == nil && evidence.Introduces(commit) {
+				pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+			}
+		}
+	}
+	return resolvedBy(pullRequestsEvidence, PRResolvedByCommitMessage), nil
+}
+
+// Introduces returns true if the pull request is merged, and the commit is one of its commits,
+// or its merge or squash commit
+func (e *PREvidence) Introduces(commit string) bool {
+	if !e.Merged || commit == "" {
+		return false
+	}
+	if sameCommit(e.MergeCommit, commit) || sameCommit(e.SquashCommit, commit) {
+		return true
+	}
+	for _, c := range e.Commits {
+		if sameCommit(c.SHA, commit) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameCommit returns true if sha is the commit, or its abbreviated hash,
+// as some git providers (e.g. Bitbucket Cloud) only return the short hash of merge commits
+func sameCommit(sha, commit string) bool {
+	return sha != "" && (sha == commit || (len(sha) < len(commit) && strings.HasPrefix(commit, sha)))
+}
+
+func resolvedBy(pullRequestsEvidence []*PREvidence, strategy string) []*PREvidence {
+	for _, evidence := range pullRequestsEvidence {
+		evidence.ResolvedBy = strategy
+	}
+	return pullRequestsEvidence
+}
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PRLookupTestSuite struct {
+	suite.Suite
+}
+
+// fakeLookupRetriever is a PRLookupRetriever with pull requests by number
+type fakeLookupRetriever struct {
+	byCommit      map[string][]int
+	byMergeCommit map[string][]int
+	messages      map[string]string
+	// merged are the merge commits of the merged pull requests
+	merged map[int]string
+	// commits are the commits of the pull requests
+	commits map[int][]string
+}
+
+func (r *fakeLookupRetriever) PREvidenceForCommit(commit string) ([]*PREvidence, error) {
+	return r.evidence(r.byCommit[commit])
+}
+
+func (r *fakeLookupRetriever) PREvidenceForNumber(number int) (*PREvidence, error) {
+	evidence := &PREvidence{URL: fmt.Sprintf("https://example.com/pulls/%d", number)}
+	evidence.MergeCommit, evidence.Merged = r.merged[number]
+	for _, sha := range r.commits[number] {
+		evidence.Commits = append(evidence.Commits, PRCommit{SHA: sha})
+	}
+	return evidence, nil
+}
+
+func (r *fakeLookupRetriever) PREvidenceForMergeCommit(commit string) ([]*PREvidence, error) {
+	return r.evidence(r.byMergeCommit[commit])
+}
+
+func (r *fakeLookupRetriever) CommitMessage(commit string) (string, error) {
+	return r.messages[commit], nil
+}
+
+func (r *fakeLookupRetriever) evidence(numbers []int) ([]*PREvidence, error) {
+	pullRequestsEvidence := []*PREvidence{}
+	for _, number := range numbers {
+		evidence, _ := r.PREvidenceForNumber(number)
+		pullRequestsEvidence = append(pullRequestsEvidence, evidence)
+	}
+	return pullRequestsEvidence, nil
+}
+
+// commitOnlyRetriever is a PRRetriever that can only find pull requests by commit
+type commitOnlyRetriever struct{}
+
+func (r *commitOnlyRetriever) PREvidenceForCommit(commit string) ([]*PREvidence, error) {
+	return []*PREvidence{}, nil
+}
+
+func (suite *PRLookupTestSuite) TestPRNumbersFromCommitMessage() {
+	for _, t := range []struct {
+		name    string
+		message string
+		want    []int
+	}{
+		{name: "github squash merge", message: "Add feature (#123)\n\n* wip", want: []int{123}},
+		{name: "github merge commit", message: "Merge pull request #42 from kosli-dev/feature\n\nAdd feature", want: []int{42}},
+		{name: "gitlab merge commit", message: "Merge branch 'feature' into 'main'\n\nSee merge request kosli/cli!45", want: []int{45}},
+		{name: "azure devops", message: "Merged PR 7: Add feature", want: []int{7}},
+		{name: "bitbucket cloud", message: "Merged in feature (pull request #12)\n\nAdd feature", want: []int{12}},
+		{name: "bitbucket data center", message: "Pull request #3: Add feature\n\nMerge in KOS/cli from feature to main", want: []int{3}},
+		{name: "duplicate references", message: "Add feature (#5)\n\nMerge pull request #5 from kosli-dev/feature", want: []int{5}},
+		{name: "no reference", message: "Fix #9 in the parser", want: []int{}},
+	} {
+		suite.Run(t.name, func() {
+			require.Equal(suite.T(), t.want, PRNumbersFromCommitMessage(t.message))
+		})
+	}
+}
+
+func (suite *PRLookupTestSuite) TestFindPREvidence() {
+	retriever := &fakeLookupRetriever{
+		byCommit:      map[string][]int{"head": {1}},
+		byMergeCommit: map[string][]int{"rebased": {3}},
+		messages: map[string]string{
+			"squashed":      "Add feature (#2)",
+			"rebased":       "Add feature (#2)",
+			"cherry-picked": "Fix bug (#4)",
+			"unrelated":     "Fix bug (#4)",
+			"unmerged":      "Fix bug (#5)",
+			"a1b2c3d4e5f60718293a4b5c6d7e8f9012345678": "Merged in feature (pull request #6)",
+		},
+		merged:  map[int]string{2: "squashed", 3: "rebased", 4: "merge-of-4", 6: "a1b2c3d4e5f6"},
+		commits: map[int][]string{4: {"cherry-picked"}, 5: {"unmerged"}},
+	}
+	for _, t := range []struct {
+		name       string
+		commit     string
+		prNumber   int
+		wantURLs   []string
+		resolvedBy string
+	}{
+		{name: "by commit", commit: "head", wantURLs: []string{"https://example.com/pulls/1"}, resolvedBy: PRResolvedByCommit},
+		{name: "by pr number", commit: "head", prNumber: 9, wantURLs: []string{"https://example.com/pulls/9"}, resolvedBy: PRResolvedByPRNumber},
+		{name: "by commit message", commit: "squashed", wantURLs: []string{"https://example.com/pulls/2"}, resolvedBy: PRResolvedByCommitMessage},
+		{name: "by merge commit", commit: "rebased", wantURLs: []string{"https://example.com/pulls/3"}, resolvedBy: PRResolvedByMergeCommit},
+		{name: "by commit message of a commit in the pull request", commit: "cherry-picked", wantURLs: []string{"https://example.com/pulls/4"}, resolvedBy: PRResolvedByCommitMessage},
+		{name: "by commit message of a merge commit with a short hash", commit: "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", wantURLs: []string{"https://example.com/pulls/6"}, resolvedBy: PRResolvedByCommitMessage},
+		{name: "a merged pull request without the commit is ignored", commit: "unrelated", wantURLs: []string{}},
+		{name: "a pull request that is not merged is ignored", commit: "unmerged", wantURLs: []string{}},
+		{name: "not found", commit: "unknown", wantURLs: []string{}},
+	} {
+		suite.Run(t.name, func() {
+			evidence, err := FindPREvidence(retriever, t.commit, t.prNumber)
+			require.NoError(suite.T(), err)
+			urls := []string{}
+			for _, pr := range evidence {
+				urls = append(urls, pr.URL)
+				require.Equal(suite.T(), t.resolvedBy, pr.ResolvedBy)
+			}
+			require.Equal(suite.T(), t.wantURLs, urls)
+		})
+	}
+}
+
+func (suite *PRLookupTestSuite) TestFindPREvidenceByNumberIsNotSupported() {
+	_, err := FindPREvidence(&commitOnlyRetriever{}, "head", 1)
+	require.ErrorContains(suite.T(), err, "not supported")
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestPRLookupTestSuite(t *testing.T) {
+	suite.Run(t, new(PRLookupTestSuite))
+}
@@ -1,15 +1,75 @@
 package types
 
 type PREvidence struct {
-	MergeCommit string   `json:"merge_commit"`
-	URL         string   `json:"url"`
-	State       string   `json:"state"`
-	Approvers   []string `json:"approvers"`
-	// LastCommit             string `json:"lastCommit"`
-	// LastCommitter          string `json:"lastCommitter"`
-	// SelfApproved           bool   `json:"selfApproved"`
+	MergeCommit string `json:"merge_commit"`
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	// Body is the description of the pull request, used to find issue references
+	Body          string   `json:"-"`
+	State         string   `json:"state"`
+	Approvers     []string `json:"approvers"`
+	Author        PRUser   `json:"author"`
+	LastCommit    string   `json:"last_commit,omitempty"`
+	LastCommitter string   `json:"last_committer,omitempty"`
+	SelfApproved  bool     `json:"self_approved"`
+	// CommitsAfterApproval are the commits pushed after the last approval
+	CommitsAfterApproval []string        `json:"commits_after_approval"`
+	FourEyes             *FourEyesResult `json:"four_eyes,omitempty"`
+	// ResolvedBy is the strategy used to find the pull request, one of the PRResolvedBy values
+	ResolvedBy string     `json:"resolved_by,omitempty"`
+	Commits    []PRCommit `json:"-"`
+	Reviews    []PRReview `json:"-"`
+	// Merged is true if the pull request is merged
+	Merged bool `json:"-"`
+	// SquashCommit is the commit created by squashing the pull request when merging it,
+	// for git providers that report it separately from the merge commit
+	SquashCommit string `json:"-"`
+}
+
+// PRUser is the author of a pull request, a commit or a review
+type PRUser struct {
+	Login string `json:"login,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// PRCommit is a commit in a pull request.
+// Timestamp is the commit time in unix seconds, 0 if unknown.
+type PRCommit struct {
+	SHA       string
+	Author    PRUser
+	Timestamp int64
+}
+
+// PRReview is an approval of a pull request.
+// Timestamp is the approval time in unix seconds, 0 if unknown.
+type PRReview struct {
+	Reviewer  PRUser
+	Timestamp int64
 }
 
 type PRRetriever interface {
 	PREvidenceForCommit(string) ([]*PREvidence, error)
 }
+
+// PullRequestIssueType is the type of issues that are pull requests, e.g. in GitHub where pull requests are issues
+const PullRequestIssueType = "pull request"
+
+// IssueInfo is the information of an issue referenced by a commit.
+// If the issue is not found in the issue tracker, IssueExists is false.
+type IssueInfo struct {
+	IssueID     string   `json:"issue_id"`
+	IssueURL    string   `json:"issue_url"`
+	IssueExists bool     `json:"issue_exists"`
+	Summary     string   `json:"summary,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	IssueType   string   `json:"issue_type,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	FixVersions []string `json:"fix_versions,omitempty"`
+}
+
+// IssueTracker looks up the issues referenced by commits
+type IssueTracker interface {
+	// IssueKeyPattern is the default pattern of the issue keys of the tracker
+	IssueKeyPattern() string
+	GetIssueInfo(issueID string) (*IssueInfo, error)
+}
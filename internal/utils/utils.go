@@ -8,7 +8,9 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -150,3 +152,120 @@ func CreateFileWithContent(path, content string) error {
 	_, err = file.Write([]byte(content))
 	return err
 }
+
+// GlobFiles returns the sorted, de-duplicated regular files matching any of the glob patterns.
+// In addition to the filepath.Match syntax, a '**' path segment matches any number of directories.
+// A pattern that does not match any file is an error.
+func GlobFiles(patterns []string) ([]string, error) {
+	matches := map[string]bool{}
+	for _, pattern := range patterns {
+		found := 0
+		pattern = filepath.Clean(pattern)
+		if !strings.Contains(pattern, "**") {
+			paths, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				if ok, _ := IsFile(path); ok {
+					matches[path] = true
+					found++
+				}
+			}
+		} else {
+			root, rest := splitGlobRoot(pattern)
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.Type().IsRegular() {
+					return nil
+				}
+				relPath, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				ok, err := matchSegments(strings.Split(rest, string(filepath.Separator)),
+					strings.Split(relPath, string(filepath.Separator)))
+				if err != nil {
+					return err
+				}
+				if ok {
+					matches[path] = true
+					found++
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if found == 0 {
+			return nil, fmt.Errorf("no files match the pattern %s", pattern)
+		}
+	}
+
+	files := []string{}
+	for path := range matches {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// MatchPath returns true if a slash-separated path, or one of its parent directories, matches a glob pattern.
+// In addition to the path.Match syntax, a '**' path segment matches any number of directories.
+func MatchPath(pattern, filePath string) (bool, error) {
+	patternSegments := strings.Split(path.Clean(pattern), "/")
+	pathSegments := strings.Split(path.Clean(filePath), "/")
+	for i := len(pathSegments); i > 0; i-- {
+		ok, err := matchSegments(patternSegments, pathSegments[:i])
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// splitGlobRoot splits a pattern into the directory before its first wildcard
+// and the rest of the pattern
+func splitGlobRoot(pattern string) (string, string) {
+	segments := strings.Split(pattern, string(filepath.Separator))
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "*?[") {
+			root := strings.Join(segments[:i], string(filepath.Separator))
+			if root == "" {
+				root = "."
+				if filepath.IsAbs(pattern) {
+					root = string(filepath.Separator)
+				}
+			}
+			return root, strings.Join(segments[i:], string(filepath.Separator))
+		}
+	}
+	return pattern, ""
+}
+
+// matchSegments matches path segments against pattern segments where '**' matches zero or more segments
+func matchSegments(pattern, path []string) (bool, error) {
+	if len(pattern) == 0 {
+		return len(path) == 0, nil
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			ok, err := matchSegments(pattern[1:], path[i:])
+			if ok || err != nil {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	if len(path) == 0 {
+		return false, nil
+	}
+	ok, err := filepath.Match(pattern[0], path[0])
+	if !ok || err != nil {
+		return false, err
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
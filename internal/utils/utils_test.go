@@ -250,6 +250,90 @@ func (suite *UtilsTestSuite) TestTar() {
 	}
 }
 
+func (suite *UtilsTestSuite) TestGlobFiles() {
+	tmpDir, err := os.MkdirTemp("", "")
+	require.NoError(suite.T(), err)
+	defer os.RemoveAll(tmpDir)
+	for _, path := range []string{"a/TEST-one.xml", "a/b/TEST-two.xml", "a/b/c/other.txt", "TEST-root.xml"} {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(suite.T(), os.MkdirAll(filepath.Dir(fullPath), 0755))
+		suite.createFileWithContent(fullPath, "")
+	}
+
+	for _, t := range []struct {
+		name      string
+		patterns  []string
+		want      []string
+		wantError bool
+	}{
+		{
+			name:     "a simple pattern matches files in one directory",
+			patterns: []string{"a/*.xml"},
+			want:     []string{"a/TEST-one.xml"},
+		},
+		{
+			name:     "'**' matches any number of directories",
+			patterns: []string{"**/TEST-*.xml"},
+			want:     []string{"TEST-root.xml", "a/TEST-one.xml", "a/b/TEST-two.xml"},
+		},
+		{
+			name:     "'**' in the middle of a pattern and overlapping patterns",
+			patterns: []string{"a/**/*.xml", "a/b/TEST-two.xml"},
+			want:     []string{"a/TEST-one.xml", "a/b/TEST-two.xml"},
+		},
+		{
+			name:      "a pattern without matches fails",
+			patterns:  []string{"**/*.json"},
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			patterns := []string{}
+			for _, pattern := range t.patterns {
+				patterns = append(patterns, filepath.Join(tmpDir, pattern))
+			}
+			files, err := GlobFiles(patterns)
+			if t.wantError {
+				require.Error(suite.T(), err)
+				return
+			}
+			require.NoError(suite.T(), err)
+			want := []string{}
+			for _, path := range t.want {
+				want = append(want, filepath.Join(tmpDir, path))
+			}
+			require.Equal(suite.T(), want, files)
+		})
+	}
+}
+
+func (suite *UtilsTestSuite) TestMatchPath() {
+	for _, t := range []struct {
+		pattern   string
+		path      string
+		want      bool
+		wantError bool
+	}{
+		{pattern: "services/api", path: "services/api/main.go", want: true},
+		{pattern: "services/api", path: "services/apigw/main.go", want: false},
+		{pattern: "services/*/go.mod", path: "services/api/go.mod", want: true},
+		{pattern: "**/*.go", path: "services/api/main.go", want: true},
+		{pattern: "**/*.go", path: "README.md", want: false},
+		{pattern: "services/**/docs", path: "services/api/v1/docs/index.md", want: true},
+		{pattern: "services/[", path: "services/api", wantError: true},
+	} {
+		suite.Run(fmt.Sprintf("%s matches %s: %v", t.pattern, t.path, t.want), func() {
+			ok, err := MatchPath(t.pattern, t.path)
+			if t.wantError {
+				require.Error(suite.T(), err)
+				return
+			}
+			require.NoError(suite.T(), err)
+			require.Equal(suite.T(), t.want, ok)
+		})
+	}
+}
+
 func (suite *UtilsTestSuite) createFileWithContent(path, content string) {
 	err := CreateFileWithContent(path, content)
 	require.NoErrorf(suite.T(), err, "error creating file %s", path)
@@ -0,0 +1,71 @@
+package vulnscan
+
+import "fmt"
+
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				State    string   `json:"state"`
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+	} `json:"matches"`
+	Source *struct {
+		Type   string      `json:"type"`
+		Target interface{} `json:"target"`
+	} `json:"source"`
+	Descriptor struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"descriptor"`
+}
+
+// ParseGrypeFile summarizes a Grype JSON report ('grype -o json')
+func ParseGrypeFile(path string) (*Summary, error) {
+	var report grypeReport
+	err := readJSONReport(path, "Grype", &report)
+	if err != nil {
+		return nil, err
+	}
+	if report.Matches == nil || report.Source == nil {
+		return nil, fmt.Errorf("%s is not a valid Grype JSON report: matches or source is missing", path)
+	}
+
+	target, digests := grypeTarget(report.Source.Target)
+	summary := newSummary("grype", report.Descriptor.Version, target)
+	summary.ImageDigest = extractSha256(digests...)
+	for _, match := range report.Matches {
+		fix := match.Vulnerability.Fix
+		summary.add(match.Vulnerability.Severity, fix.State == "fixed" || len(fix.Versions) > 0)
+	}
+	return summary, nil
+}
+
+// grypeTarget returns the name and the candidate digests of a Grype source target.
+// The target is an object for image sources and a plain string for directory and file sources.
+func grypeTarget(target interface{}) (string, []string) {
+	switch t := target.(type) {
+	case string:
+		return t, nil
+	case map[string]interface{}:
+		name, _ := t["userInput"].(string)
+		digests := []string{}
+		if repoDigests, ok := t["repoDigests"].([]interface{}); ok {
+			for _, d := range repoDigests {
+				if digest, ok := d.(string); ok {
+					digests = append(digests, digest)
+				}
+			}
+		}
+		for _, key := range []string{"manifestDigest", "imageID"} {
+			if digest, ok := t[key].(string); ok {
+				digests = append(digests, digest)
+			}
+		}
+		return name, digests
+	}
+	return "", nil
+}
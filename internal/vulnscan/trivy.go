@@ -0,0 +1,43 @@
+package vulnscan
+
+import "fmt"
+
+type trivyReport struct {
+	SchemaVersion int    `json:"SchemaVersion"`
+	ArtifactName  string `json:"ArtifactName"`
+	ArtifactType  string `json:"ArtifactType"`
+	Metadata      struct {
+		ImageID     string   `json:"ImageID"`
+		RepoDigests []string `json:"RepoDigests"`
+	} `json:"Metadata"`
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+			FixedVersion    string `json:"FixedVersion"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// ParseTrivyFile summarizes a Trivy JSON report ('trivy image --format json')
+func ParseTrivyFile(path string) (*Summary, error) {
+	var report trivyReport
+	err := readJSONReport(path, "Trivy", &report)
+	if err != nil {
+		return nil, err
+	}
+	if report.SchemaVersion != 2 {
+		return nil, fmt.Errorf("%s is not a valid Trivy JSON report: unsupported SchemaVersion %d, only 2 is supported",
+			path, report.SchemaVersion)
+	}
+
+	summary := newSummary("trivy", "", report.ArtifactName)
+	summary.ImageDigest = extractSha256(append(report.Metadata.RepoDigests, report.Metadata.ImageID)...)
+	for _, result := range report.Results {
+		for _, vulnerability := range result.Vulnerabilities {
+			summary.add(vulnerability.Severity, vulnerability.FixedVersion != "")
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,149 @@
+package vulnscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Severities of vulnerabilities, ordered from the most to the least severe
+const (
+	SeverityCritical   = "critical"
+	SeverityHigh       = "high"
+	SeverityMedium     = "medium"
+	SeverityLow        = "low"
+	SeverityNegligible = "negligible"
+	SeverityUnknown    = "unknown"
+)
+
+// Severities are the valid vulnerability severities, ordered from the most to the least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityNegligible, SeverityUnknown}
+
+var sha256Pattern = regexp.MustCompile(`sha256:([a-f0-9]{64})`)
+
+// Summary is a summary of a vulnerability scan report
+type Summary struct {
+	Scanner        string         `json:"scanner"`
+	ScannerVersion string         `json:"scanner_version,omitempty"`
+	Target         string         `json:"target"`
+	ImageDigest    string         `json:"image_digest,omitempty"`
+	Total          int            `json:"total"`
+	Fixable        int            `json:"fixable"`
+	Unfixable      int            `json:"unfixable"`
+	BySeverity     map[string]int `json:"by_severity"`
+	// FixableBySeverity only counts the vulnerabilities that have a fix available
+	FixableBySeverity map[string]int `json:"fixable_by_severity"`
+}
+
+// Policy decides whether a vulnerability scan summary is compliant
+type Policy struct {
+	// FailOnSeverity is the least severe severity that makes the summary non-compliant.
+	// An empty value means that any vulnerability makes the summary non-compliant.
+	FailOnSeverity string
+	// IgnoreUnfixed excludes the vulnerabilities without a fix from the evaluation
+	IgnoreUnfixed bool
+}
+
+func newSummary(scanner, version, target string) *Summary {
+	summary := &Summary{
+		Scanner:           scanner,
+		ScannerVersion:    version,
+		Target:            target,
+		BySeverity:        make(map[string]int),
+		FixableBySeverity: make(map[string]int),
+	}
+	for _, severity := range Severities {
+		summary.BySeverity[severity] = 0
+		summary.FixableBySeverity[severity] = 0
+	}
+	return summary
+}
+
+// add records a vulnerability of the given severity in the summary
+func (s *Summary) add(severity string, fixable bool) {
+	severity = NormalizeSeverity(severity)
+	s.Total++
+	s.BySeverity[severity]++
+	if fixable {
+		s.Fixable++
+		s.FixableBySeverity[severity]++
+	} else {
+		s.Unfixable++
+	}
+}
+
+// NormalizeSeverity maps a scanner specific severity to one of Severities
+func NormalizeSeverity(severity string) string {
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	for _, s := range Severities {
+		if s == severity {
+			return s
+		}
+	}
+	return SeverityUnknown
+}
+
+// ValidateSeverity returns an error if severity is not one of Severities
+func ValidateSeverity(severity string) error {
+	for _, s := range Severities {
+		if s == strings.ToLower(severity) {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a valid severity. Valid severities are: %v", severity, Severities)
+}
+
+// Evaluate returns whether the summary is compliant with the policy,
+// and the reasons why it is not
+func (s *Summary) Evaluate(policy Policy) (bool, []string) {
+	counts := s.BySeverity
+	qualifier := ""
+	if policy.IgnoreUnfixed {
+		counts = s.FixableBySeverity
+		qualifier = "fixable "
+	}
+	failOn := strings.ToLower(policy.FailOnSeverity)
+	if failOn == "" {
+		failOn = SeverityUnknown
+	}
+
+	violations := []string{}
+	for _, severity := range Severities {
+		if counts[severity] > 0 {
+			violations = append(violations, fmt.Sprintf("found %d %s%s vulnerabilities", counts[severity], qualifier, severity))
+		}
+		if severity == failOn {
+			break
+		}
+	}
+	return len(violations) == 0, violations
+}
+
+// MatchesDigest returns true if the scanned image digest matches the fingerprint
+func (s *Summary) MatchesDigest(fingerprint string) bool {
+	return s.ImageDigest != "" && s.ImageDigest == strings.TrimPrefix(fingerprint, "sha256:")
+}
+
+// extractSha256 returns the first sha256 digest found in the values
+func extractSha256(values ...string) string {
+	for _, value := range values {
+		if match := sha256Pattern.FindStringSubmatch(value); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func readJSONReport(path, scanner string, report interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(content, report)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid %s JSON report: %v", path, scanner, err)
+	}
+	return nil
+}
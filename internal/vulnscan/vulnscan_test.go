@@ -0,0 +1,235 @@
+package vulnscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kosli-dev/cli/internal/utils"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const imageDigest = "7509e5bda0c762d2bac7f90d758b5b2263fa01ccbc542ab5e3df163be08e6ca9"
+
+const trivyReportJSON = `{
+  "SchemaVersion": 2,
+  "ArtifactName": "example:latest",
+  "Metadata": {
+    "ImageID": "sha256:c1aabb73d2339c5ebaa3681de2e9d9c18d57485045a4e311d9f8004bec208d67",
+    "RepoDigests": ["example@sha256:` + imageDigest + `"]
+  },
+  "Results": [
+    {
+      "Target": "example:latest (alpine 3.18.2)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-1", "Severity": "CRITICAL", "FixedVersion": "1.0.1"},
+        {"VulnerabilityID": "CVE-2", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-3", "Severity": "LOW", "FixedVersion": "2.0.0"}
+      ]
+    },
+    {"Target": "app"}
+  ]
+}`
+
+const grypeReportJSON = `{
+  "matches": [
+    {"vulnerability": {"id": "CVE-1", "severity": "Critical", "fix": {"state": "fixed", "versions": ["1.0.1"]}}},
+    {"vulnerability": {"id": "CVE-2", "severity": "Negligible", "fix": {"state": "wont-fix", "versions": []}}},
+    {"vulnerability": {"id": "CVE-3", "severity": "Unknown", "fix": {"state": "unknown"}}}
+  ],
+  "source": {
+    "type": "image",
+    "target": {
+      "userInput": "example:latest",
+      "imageID": "sha256:c1aabb73d2339c5ebaa3681de2e9d9c18d57485045a4e311d9f8004bec208d67",
+      "repoDigests": ["example@sha256:` + imageDigest + `"]
+    }
+  },
+  "descriptor": {"name": "grype", "version": "0.65.1"}
+}`
+
+type VulnScanTestSuite struct {
+	suite.Suite
+	tmpDir string
+}
+
+func (suite *VulnScanTestSuite) SetupTest() {
+	var err error
+	suite.tmpDir, err = os.MkdirTemp("", "testDir")
+	require.NoError(suite.T(), err, "error creating a temporary test directory")
+}
+
+func (suite *VulnScanTestSuite) TearDownTest() {
+	err := os.RemoveAll(suite.tmpDir)
+	require.NoErrorf(suite.T(), err, "error cleaning up the temporary test directory %s", suite.tmpDir)
+}
+
+func (suite *VulnScanTestSuite) writeReport(content string) string {
+	path := filepath.Join(suite.tmpDir, "report.json")
+	err := utils.CreateFileWithContent(path, content)
+	require.NoError(suite.T(), err)
+	return path
+}
+
+func (suite *VulnScanTestSuite) TestParseTrivyFile() {
+	for _, t := range []struct {
+		name      string
+		content   string
+		wantError bool
+		want      *Summary
+	}{
+		{
+			name:    "Trivy report is summarized",
+			content: trivyReportJSON,
+			want: &Summary{
+				Scanner:     "trivy",
+				Target:      "example:latest",
+				ImageDigest: imageDigest,
+				Total:       3,
+				Fixable:     2,
+				Unfixable:   1,
+				BySeverity: map[string]int{
+					SeverityCritical: 1, SeverityHigh: 1, SeverityMedium: 0,
+					SeverityLow: 1, SeverityNegligible: 0, SeverityUnknown: 0,
+				},
+				FixableBySeverity: map[string]int{
+					SeverityCritical: 1, SeverityHigh: 0, SeverityMedium: 0,
+					SeverityLow: 1, SeverityNegligible: 0, SeverityUnknown: 0,
+				},
+			},
+		},
+		{
+			name:      "Trivy report with an old schema version fails",
+			content:   `{"SchemaVersion": 1, "Results": []}`,
+			wantError: true,
+		},
+		{
+			name:      "invalid JSON fails",
+			content:   `not json`,
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			summary, err := ParseTrivyFile(suite.writeReport(t.content))
+			if t.wantError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+				require.Equal(suite.T(), t.want, summary)
+			}
+		})
+	}
+}
+
+func (suite *VulnScanTestSuite) TestParseGrypeFile() {
+	for _, t := range []struct {
+		name      string
+		content   string
+		wantError bool
+		want      *Summary
+	}{
+		{
+			name:    "Grype image report is summarized",
+			content: grypeReportJSON,
+			want: &Summary{
+				Scanner:        "grype",
+				ScannerVersion: "0.65.1",
+				Target:         "example:latest",
+				ImageDigest:    imageDigest,
+				Total:          3,
+				Fixable:        1,
+				Unfixable:      2,
+				BySeverity: map[string]int{
+					SeverityCritical: 1, SeverityHigh: 0, SeverityMedium: 0,
+					SeverityLow: 0, SeverityNegligible: 1, SeverityUnknown: 1,
+				},
+				FixableBySeverity: map[string]int{
+					SeverityCritical: 1, SeverityHigh: 0, SeverityMedium: 0,
+					SeverityLow: 0, SeverityNegligible: 0, SeverityUnknown: 0,
+				},
+			},
+		},
+		{
+			name:    "Grype directory report has no image digest",
+			content: `{"matches": [], "source": {"type": "directory", "target": "."}}`,
+			want: &Summary{
+				Scanner: "grype",
+				Target:  ".",
+				BySeverity: map[string]int{
+					SeverityCritical: 0, SeverityHigh: 0, SeverityMedium: 0,
+					SeverityLow: 0, SeverityNegligible: 0, SeverityUnknown: 0,
+				},
+				FixableBySeverity: map[string]int{
+					SeverityCritical: 0, SeverityHigh: 0, SeverityMedium: 0,
+					SeverityLow: 0, SeverityNegligible: 0, SeverityUnknown: 0,
+				},
+			},
+		},
+		{
+			name:      "a Trivy report is not a valid Grype report",
+			content:   trivyReportJSON,
+			wantError: true,
+		},
+	} {
+		suite.Run(t.name, func() {
+			summary, err := ParseGrypeFile(suite.writeReport(t.content))
+			if t.wantError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+				require.Equal(suite.T(), t.want, summary)
+			}
+		})
+	}
+}
+
+func (suite *VulnScanTestSuite) TestEvaluate() {
+	summary := newSummary("trivy", "", "example")
+	summary.add("HIGH", false)
+	summary.add("LOW", true)
+
+	for _, t := range []struct {
+		name           string
+		policy         Policy
+		wantCompliant  bool
+		wantViolations []string
+	}{
+		{
+			name:           "any vulnerability is non-compliant by default",
+			policy:         Policy{},
+			wantViolations: []string{"found 1 high vulnerabilities", "found 1 low vulnerabilities"},
+		},
+		{
+			name:           "failing on critical is compliant without critical vulnerabilities",
+			policy:         Policy{FailOnSeverity: "CRITICAL"},
+			wantCompliant:  true,
+			wantViolations: []string{},
+		},
+		{
+			name:           "failing on high is non-compliant with high vulnerabilities",
+			policy:         Policy{FailOnSeverity: SeverityHigh},
+			wantViolations: []string{"found 1 high vulnerabilities"},
+		},
+		{
+			name:           "unfixed vulnerabilities can be ignored",
+			policy:         Policy{FailOnSeverity: SeverityLow, IgnoreUnfixed: true},
+			wantViolations: []string{"found 1 fixable low vulnerabilities"},
+		},
+	} {
+		suite.Run(t.name, func() {
+			compliant, violations := summary.Evaluate(t.policy)
+			require.Equal(suite.T(), t.wantCompliant, compliant)
+			require.Equal(suite.T(), t.wantViolations, violations)
+		})
+	}
+}
+
+func (suite *VulnScanTestSuite) TestValidateSeverity() {
+	require.NoError(suite.T(), ValidateSeverity("High"))
+	require.Error(suite.T(), ValidateSeverity("severe"))
+}
+
+func TestVulnScanTestSuite(t *testing.T) {
+	suite.Run(t, new(VulnScanTestSuite))
+}